@@ -12,27 +12,84 @@ package apiserver
 import (
 	"context"
 	"net/http"
+	"os"
 )
 
+// CodecsAPIRouter defines the required methods for binding the api requests to a responses for the CodecsAPI
+// The CodecsAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a CodecsAPIServicer to perform the required actions, then write the service results to the http response.
+type CodecsAPIRouter interface {
+	DeleteCodecById(http.ResponseWriter, *http.Request)
+	GetCodecById(http.ResponseWriter, *http.Request)
+	GetCodecs(http.ResponseWriter, *http.Request)
+	PostCodec(http.ResponseWriter, *http.Request)
+	PutCodecById(http.ResponseWriter, *http.Request)
+	TestCodecById(http.ResponseWriter, *http.Request)
+}
+
 // ConfigurationAPIRouter defines the required methods for binding the api requests to a responses for the ConfigurationAPI
 // The ConfigurationAPIRouter implementation should parse necessary information from the http request,
 // pass the data to a ConfigurationAPIServicer to perform the required actions, then write the service results to the http response.
 type ConfigurationAPIRouter interface {
 	DeleteConfigurationById(http.ResponseWriter, *http.Request)
 	GetConfigurationById(http.ResponseWriter, *http.Request)
+	GetConfigurationDriftById(http.ResponseWriter, *http.Request)
 	GetConfigurations(http.ResponseWriter, *http.Request)
+	PatchConfigurationById(http.ResponseWriter, *http.Request)
 	PostConfiguration(http.ResponseWriter, *http.Request)
 	PutConfigurationById(http.ResponseWriter, *http.Request)
+	ReconcileConfigurationById(http.ResponseWriter, *http.Request)
+	TestConfigurationById(http.ResponseWriter, *http.Request)
 }
 
 // DevicesAPIRouter defines the required methods for binding the api requests to a responses for the DevicesAPI
 // The DevicesAPIRouter implementation should parse necessary information from the http request,
 // pass the data to a DevicesAPIServicer to perform the required actions, then write the service results to the http response.
 type DevicesAPIRouter interface {
+	ExportDevices(http.ResponseWriter, *http.Request)
+	GetDeviceKeys(http.ResponseWriter, *http.Request)
 	GetDevices(http.ResponseWriter, *http.Request)
+	ImportDevices(http.ResponseWriter, *http.Request)
+	MigrateDevices(http.ResponseWriter, *http.Request)
+	ProvisionDeviceByQrCode(http.ResponseWriter, *http.Request)
 	PutDevice(http.ResponseWriter, *http.Request)
 }
 
+// DownlinksAPIRouter defines the required methods for binding the api requests to a responses for the DownlinksAPI
+// The DownlinksAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a DownlinksAPIServicer to perform the required actions, then write the service results to the http response.
+type DownlinksAPIRouter interface {
+	DeleteDeviceDownlinks(http.ResponseWriter, *http.Request)
+	GetDeviceDownlinks(http.ResponseWriter, *http.Request)
+	GetDownlinks(http.ResponseWriter, *http.Request)
+	PostDeviceDownlink(http.ResponseWriter, *http.Request)
+}
+
+// JobsAPIRouter defines the required methods for binding the api requests to a responses for the JobsAPI
+// The JobsAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a JobsAPIServicer to perform the required actions, then write the service results to the http response.
+type JobsAPIRouter interface {
+	GetJobById(http.ResponseWriter, *http.Request)
+}
+
+// KeyBatchesAPIRouter defines the required methods for binding the api requests to a responses for the KeyBatchesAPI
+// The KeyBatchesAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a KeyBatchesAPIServicer to perform the required actions, then write the service results to the http response.
+type KeyBatchesAPIRouter interface {
+	DeleteKeyBatchById(http.ResponseWriter, *http.Request)
+	GetKeyBatches(http.ResponseWriter, *http.Request)
+	PostKeyBatch(http.ResponseWriter, *http.Request)
+}
+
+// OutboxAPIRouter defines the required methods for binding the api requests to a responses for the OutboxAPI
+// The OutboxAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a OutboxAPIServicer to perform the required actions, then write the service results to the http response.
+type OutboxAPIRouter interface {
+	DiscardOutboxOperation(http.ResponseWriter, *http.Request)
+	GetOutboxOperations(http.ResponseWriter, *http.Request)
+	RetryOutboxOperation(http.ResponseWriter, *http.Request)
+}
+
 // VersionAPIRouter defines the required methods for binding the api requests to a responses for the VersionAPI
 // The VersionAPIRouter implementation should parse necessary information from the http request,
 // pass the data to a VersionAPIServicer to perform the required actions, then write the service results to the http response.
@@ -41,6 +98,19 @@ type VersionAPIRouter interface {
 	GetVersion(http.ResponseWriter, *http.Request)
 }
 
+// CodecsAPIServicer defines the api actions for the CodecsAPI service
+// This interface intended to stay up to date with the openapi yaml used to generate it,
+// while the service implementation can be ignored with the .openapi-generator-ignore file
+// and updated with the logic required for the API.
+type CodecsAPIServicer interface {
+	DeleteCodecById(context.Context, int64) (ImplResponse, error)
+	GetCodecById(context.Context, int64) (ImplResponse, error)
+	GetCodecs(context.Context) (ImplResponse, error)
+	PostCodec(context.Context, Codec) (ImplResponse, error)
+	PutCodecById(context.Context, int64, Codec) (ImplResponse, error)
+	TestCodecById(context.Context, int64, CodecTestRequest) (ImplResponse, error)
+}
+
 // ConfigurationAPIServicer defines the api actions for the ConfigurationAPI service
 // This interface intended to stay up to date with the openapi yaml used to generate it,
 // while the service implementation can be ignored with the .openapi-generator-ignore file
@@ -48,9 +118,13 @@ type VersionAPIRouter interface {
 type ConfigurationAPIServicer interface {
 	DeleteConfigurationById(context.Context, int64) (ImplResponse, error)
 	GetConfigurationById(context.Context, int64) (ImplResponse, error)
+	GetConfigurationDriftById(context.Context, int64) (ImplResponse, error)
 	GetConfigurations(context.Context) (ImplResponse, error)
+	PatchConfigurationById(context.Context, int64, string, map[string]interface{}) (ImplResponse, error)
 	PostConfiguration(context.Context, Configuration) (ImplResponse, error)
-	PutConfigurationById(context.Context, int64, Configuration) (ImplResponse, error)
+	PutConfigurationById(context.Context, int64, string, Configuration) (ImplResponse, error)
+	ReconcileConfigurationById(context.Context, int64) (ImplResponse, error)
+	TestConfigurationById(context.Context, int64) (ImplResponse, error)
 }
 
 // DevicesAPIServicer defines the api actions for the DevicesAPI service
@@ -58,8 +132,52 @@ type ConfigurationAPIServicer interface {
 // while the service implementation can be ignored with the .openapi-generator-ignore file
 // and updated with the logic required for the API.
 type DevicesAPIServicer interface {
+	ExportDevices(context.Context, string, int64, string, string, bool) (ImplResponse, error)
+	GetDeviceKeys(context.Context, string) (ImplResponse, error)
 	GetDevices(context.Context) (ImplResponse, error)
-	PutDevice(context.Context, PutDeviceRequest) (ImplResponse, error)
+	ImportDevices(context.Context, *os.File) (ImplResponse, error)
+	MigrateDevices(context.Context, string, int64, string, string, *os.File, string) (ImplResponse, error)
+	ProvisionDeviceByQrCode(context.Context, QrCodeRequest) (ImplResponse, error)
+	PutDevice(context.Context, bool, string, PutDeviceRequest) (ImplResponse, error)
+}
+
+// DownlinksAPIServicer defines the api actions for the DownlinksAPI service
+// This interface intended to stay up to date with the openapi yaml used to generate it,
+// while the service implementation can be ignored with the .openapi-generator-ignore file
+// and updated with the logic required for the API.
+type DownlinksAPIServicer interface {
+	DeleteDeviceDownlinks(context.Context, string) (ImplResponse, error)
+	GetDeviceDownlinks(context.Context, string) (ImplResponse, error)
+	GetDownlinks(context.Context) (ImplResponse, error)
+	PostDeviceDownlink(context.Context, string, NewDownlink) (ImplResponse, error)
+}
+
+// JobsAPIServicer defines the api actions for the JobsAPI service
+// This interface intended to stay up to date with the openapi yaml used to generate it,
+// while the service implementation can be ignored with the .openapi-generator-ignore file
+// and updated with the logic required for the API.
+type JobsAPIServicer interface {
+	GetJobById(context.Context, int64) (ImplResponse, error)
+}
+
+// KeyBatchesAPIServicer defines the api actions for the KeyBatchesAPI service
+// This interface intended to stay up to date with the openapi yaml used to generate it,
+// while the service implementation can be ignored with the .openapi-generator-ignore file
+// and updated with the logic required for the API.
+type KeyBatchesAPIServicer interface {
+	DeleteKeyBatchById(context.Context, int64) (ImplResponse, error)
+	GetKeyBatches(context.Context) (ImplResponse, error)
+	PostKeyBatch(context.Context, string, *os.File) (ImplResponse, error)
+}
+
+// OutboxAPIServicer defines the api actions for the OutboxAPI service
+// This interface intended to stay up to date with the openapi yaml used to generate it,
+// while the service implementation can be ignored with the .openapi-generator-ignore file
+// and updated with the logic required for the API.
+type OutboxAPIServicer interface {
+	DiscardOutboxOperation(context.Context, int64) (ImplResponse, error)
+	GetOutboxOperations(context.Context, string) (ImplResponse, error)
+	RetryOutboxOperation(context.Context, int64) (ImplResponse, error)
 }
 
 // VersionAPIServicer defines the api actions for the VersionAPI service
@@ -0,0 +1,239 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// CodecsAPIController binds http requests to an api service and writes the service results to the http response
+type CodecsAPIController struct {
+	service      CodecsAPIServicer
+	errorHandler ErrorHandler
+}
+
+// CodecsAPIOption for how the controller is set up.
+type CodecsAPIOption func(*CodecsAPIController)
+
+// WithCodecsAPIErrorHandler inject ErrorHandler into controller
+func WithCodecsAPIErrorHandler(h ErrorHandler) CodecsAPIOption {
+	return func(c *CodecsAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewCodecsAPIController creates a default api controller
+func NewCodecsAPIController(s CodecsAPIServicer, opts ...CodecsAPIOption) Router {
+	controller := &CodecsAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the CodecsAPIController
+func (c *CodecsAPIController) Routes() Routes {
+	return Routes{
+		"DeleteCodecById": Route{
+			strings.ToUpper("Delete"),
+			"/v1/codecs/{codec-id}",
+			c.DeleteCodecById,
+		},
+		"GetCodecById": Route{
+			strings.ToUpper("Get"),
+			"/v1/codecs/{codec-id}",
+			c.GetCodecById,
+		},
+		"GetCodecs": Route{
+			strings.ToUpper("Get"),
+			"/v1/codecs",
+			c.GetCodecs,
+		},
+		"PostCodec": Route{
+			strings.ToUpper("Post"),
+			"/v1/codecs",
+			c.PostCodec,
+		},
+		"PutCodecById": Route{
+			strings.ToUpper("Put"),
+			"/v1/codecs/{codec-id}",
+			c.PutCodecById,
+		},
+		"TestCodecById": Route{
+			strings.ToUpper("Post"),
+			"/v1/codecs/{codec-id}/test",
+			c.TestCodecById,
+		},
+	}
+}
+
+// DeleteCodecById - Deletes a codec
+func (c *CodecsAPIController) DeleteCodecById(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	codecIdParam, err := parseNumericParameter[int64](
+		params["codec-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := c.service.DeleteCodecById(r.Context(), codecIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// GetCodecById - Get codec
+func (c *CodecsAPIController) GetCodecById(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	codecIdParam, err := parseNumericParameter[int64](
+		params["codec-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := c.service.GetCodecById(r.Context(), codecIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// GetCodecs - Get codecs
+func (c *CodecsAPIController) GetCodecs(w http.ResponseWriter, r *http.Request) {
+	result, err := c.service.GetCodecs(r.Context())
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// PostCodec - Creates a codec
+func (c *CodecsAPIController) PostCodec(w http.ResponseWriter, r *http.Request) {
+	codecParam := Codec{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&codecParam); err != nil && !errors.Is(err, io.EOF) {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertCodecRequired(codecParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertCodecConstraints(codecParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.PostCodec(r.Context(), codecParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// PutCodecById - Updates a codec
+func (c *CodecsAPIController) PutCodecById(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	codecIdParam, err := parseNumericParameter[int64](
+		params["codec-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	codecParam := Codec{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&codecParam); err != nil && !errors.Is(err, io.EOF) {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertCodecRequired(codecParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertCodecConstraints(codecParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.PutCodecById(r.Context(), codecIdParam, codecParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// TestCodecById - Tests a codec
+func (c *CodecsAPIController) TestCodecById(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	codecIdParam, err := parseNumericParameter[int64](
+		params["codec-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	codecTestRequestParam := CodecTestRequest{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&codecTestRequestParam); err != nil && !errors.Is(err, io.EOF) {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertCodecTestRequestRequired(codecTestRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertCodecTestRequestConstraints(codecTestRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.TestCodecById(r.Context(), codecIdParam, codecTestRequestParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
@@ -62,11 +62,21 @@ func (c *ConfigurationAPIController) Routes() Routes {
 			"/v1/configs/{config-id}",
 			c.GetConfigurationById,
 		},
+		"GetConfigurationDriftById": Route{
+			strings.ToUpper("Get"),
+			"/v1/configs/{config-id}/drift",
+			c.GetConfigurationDriftById,
+		},
 		"GetConfigurations": Route{
 			strings.ToUpper("Get"),
 			"/v1/configs",
 			c.GetConfigurations,
 		},
+		"PatchConfigurationById": Route{
+			strings.ToUpper("Patch"),
+			"/v1/configs/{config-id}",
+			c.PatchConfigurationById,
+		},
 		"PostConfiguration": Route{
 			strings.ToUpper("Post"),
 			"/v1/configs",
@@ -77,6 +87,16 @@ func (c *ConfigurationAPIController) Routes() Routes {
 			"/v1/configs/{config-id}",
 			c.PutConfigurationById,
 		},
+		"ReconcileConfigurationById": Route{
+			strings.ToUpper("Post"),
+			"/v1/configs/{config-id}/reconcile",
+			c.ReconcileConfigurationById,
+		},
+		"TestConfigurationById": Route{
+			strings.ToUpper("Post"),
+			"/v1/configs/{config-id}/test",
+			c.TestConfigurationById,
+		},
 	}
 }
 
@@ -122,6 +142,27 @@ func (c *ConfigurationAPIController) GetConfigurationById(w http.ResponseWriter,
 	EncodeJSONResponse(result.Body, &result.Code, w)
 }
 
+// GetConfigurationDriftById - Get drift report of a configuration
+func (c *ConfigurationAPIController) GetConfigurationDriftById(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	configIdParam, err := parseNumericParameter[int64](
+		params["config-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := c.service.GetConfigurationDriftById(r.Context(), configIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
 // GetConfigurations - Get configurations
 func (c *ConfigurationAPIController) GetConfigurations(w http.ResponseWriter, r *http.Request) {
 	result, err := c.service.GetConfigurations(r.Context())
@@ -134,6 +175,35 @@ func (c *ConfigurationAPIController) GetConfigurations(w http.ResponseWriter, r
 	EncodeJSONResponse(result.Body, &result.Code, w)
 }
 
+// PatchConfigurationById - Partially updates a configuration
+func (c *ConfigurationAPIController) PatchConfigurationById(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	configIdParam, err := parseNumericParameter[int64](
+		params["config-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	ifMatchParam := r.Header.Get("If-Match")
+	requestBodyParam := map[string]interface{}{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&requestBodyParam); err != nil && !errors.Is(err, io.EOF) {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := c.service.PatchConfigurationById(r.Context(), configIdParam, ifMatchParam, requestBodyParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
 // PostConfiguration - Creates a configuration
 func (c *ConfigurationAPIController) PostConfiguration(w http.ResponseWriter, r *http.Request) {
 	configurationParam := Configuration{}
@@ -172,6 +242,7 @@ func (c *ConfigurationAPIController) PutConfigurationById(w http.ResponseWriter,
 		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
 		return
 	}
+	ifMatchParam := r.Header.Get("If-Match")
 	configurationParam := Configuration{}
 	d := json.NewDecoder(r.Body)
 	d.DisallowUnknownFields()
@@ -187,7 +258,49 @@ func (c *ConfigurationAPIController) PutConfigurationById(w http.ResponseWriter,
 		c.errorHandler(w, r, err, nil)
 		return
 	}
-	result, err := c.service.PutConfigurationById(r.Context(), configIdParam, configurationParam)
+	result, err := c.service.PutConfigurationById(r.Context(), configIdParam, ifMatchParam, configurationParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// ReconcileConfigurationById - Reconcile drift of a configuration
+func (c *ConfigurationAPIController) ReconcileConfigurationById(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	configIdParam, err := parseNumericParameter[int64](
+		params["config-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := c.service.ReconcileConfigurationById(r.Context(), configIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// TestConfigurationById - Test the connection of a configuration
+func (c *ConfigurationAPIController) TestConfigurationById(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	configIdParam, err := parseNumericParameter[int64](
+		params["config-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := c.service.TestConfigurationById(r.Context(), configIdParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
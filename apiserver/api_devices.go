@@ -14,7 +14,10 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"os"
 	"strings"
+
+	"github.com/gorilla/mux"
 )
 
 // DevicesAPIController binds http requests to an api service and writes the service results to the http response
@@ -50,11 +53,36 @@ func NewDevicesAPIController(s DevicesAPIServicer, opts ...DevicesAPIOption) Rou
 // Routes returns all the api routes for the DevicesAPIController
 func (c *DevicesAPIController) Routes() Routes {
 	return Routes{
+		"ExportDevices": Route{
+			strings.ToUpper("Get"),
+			"/v1/devices/export",
+			c.ExportDevices,
+		},
+		"GetDeviceKeys": Route{
+			strings.ToUpper("Get"),
+			"/v1/devices/{dev-eui}/keys",
+			c.GetDeviceKeys,
+		},
 		"GetDevices": Route{
 			strings.ToUpper("Get"),
 			"/v1/devices",
 			c.GetDevices,
 		},
+		"ImportDevices": Route{
+			strings.ToUpper("Post"),
+			"/v1/devices/import",
+			c.ImportDevices,
+		},
+		"MigrateDevices": Route{
+			strings.ToUpper("Post"),
+			"/v1/devices/migrate",
+			c.MigrateDevices,
+		},
+		"ProvisionDeviceByQrCode": Route{
+			strings.ToUpper("Post"),
+			"/v1/devices/qr-code",
+			c.ProvisionDeviceByQrCode,
+		},
 		"PutDevice": Route{
 			strings.ToUpper("Put"),
 			"/v1/devices",
@@ -63,6 +91,90 @@ func (c *DevicesAPIController) Routes() Routes {
 	}
 }
 
+// ExportDevices - Export LoRaWAN devices
+func (c *DevicesAPIController) ExportDevices(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var formatParam string
+	if query.Has("format") {
+		param := query.Get("format")
+
+		formatParam = param
+	} else {
+		param := "json"
+		formatParam = param
+	}
+	var configIdParam int64
+	if query.Has("configId") {
+		param, err := parseNumericParameter[int64](
+			query.Get("configId"),
+			WithParse[int64](parseInt64),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		configIdParam = param
+	} else {
+	}
+	var projectIdParam string
+	if query.Has("projectId") {
+		param := query.Get("projectId")
+
+		projectIdParam = param
+	} else {
+	}
+	var appIdParam string
+	if query.Has("appId") {
+		param := query.Get("appId")
+
+		appIdParam = param
+	} else {
+	}
+	var includeKeysParam bool
+	if query.Has("includeKeys") {
+		param, err := parseBoolParameter(
+			query.Get("includeKeys"),
+			WithParse[bool](parseBool),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		includeKeysParam = param
+	} else {
+		var param bool = false
+		includeKeysParam = param
+	}
+	result, err := c.service.ExportDevices(r.Context(), formatParam, configIdParam, projectIdParam, appIdParam, includeKeysParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// GetDeviceKeys - Reveal the keys of a LoRaWAN device
+func (c *DevicesAPIController) GetDeviceKeys(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	devEuiParam := params["dev-eui"]
+	if devEuiParam == "" {
+		c.errorHandler(w, r, &RequiredError{"dev-eui"}, nil)
+		return
+	}
+	result, err := c.service.GetDeviceKeys(r.Context(), devEuiParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
 // GetDevices - Get LoRaWAN devices
 func (c *DevicesAPIController) GetDevices(w http.ResponseWriter, r *http.Request) {
 	result, err := c.service.GetDevices(r.Context())
@@ -75,8 +187,158 @@ func (c *DevicesAPIController) GetDevices(w http.ResponseWriter, r *http.Request
 	EncodeJSONResponse(result.Body, &result.Code, w)
 }
 
+// ImportDevices - Import LoRaWAN devices
+func (c *DevicesAPIController) ImportDevices(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	var fileParam *os.File
+	{
+		param, err := ReadFormFileToTempFile(r, "file")
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		fileParam = param
+	}
+
+	result, err := c.service.ImportDevices(r.Context(), fileParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// MigrateDevices - Migrate LoRaWAN devices from another network server
+func (c *DevicesAPIController) MigrateDevices(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	query := r.URL.Query()
+	var sourceParam string
+	if query.Has("source") {
+		param := query.Get("source")
+
+		sourceParam = param
+	} else {
+		c.errorHandler(w, r, &RequiredError{Field: "source"}, nil)
+		return
+	}
+	var configIdParam int64
+	if query.Has("configId") {
+		param, err := parseNumericParameter[int64](
+			query.Get("configId"),
+			WithRequire[int64](parseInt64),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		configIdParam = param
+	} else {
+		c.errorHandler(w, r, &RequiredError{Field: "configId"}, nil)
+		return
+	}
+	var appIdParam string
+	if query.Has("appId") {
+		param := query.Get("appId")
+
+		appIdParam = param
+	} else {
+		c.errorHandler(w, r, &RequiredError{Field: "appId"}, nil)
+		return
+	}
+	var assetTypeNameParam string
+	if query.Has("assetTypeName") {
+		param := query.Get("assetTypeName")
+
+		assetTypeNameParam = param
+	} else {
+		c.errorHandler(w, r, &RequiredError{Field: "assetTypeName"}, nil)
+		return
+	}
+	var fileParam *os.File
+	{
+		param, err := ReadFormFileToTempFile(r, "file")
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		fileParam = param
+	}
+
+	var projectIdParam string
+	if query.Has("projectId") {
+		param := query.Get("projectId")
+
+		projectIdParam = param
+	} else {
+	}
+	result, err := c.service.MigrateDevices(r.Context(), sourceParam, configIdParam, appIdParam, assetTypeNameParam, fileParam, projectIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// ProvisionDeviceByQrCode - Create or update a LoRaWAN device from its TR005 QR code
+func (c *DevicesAPIController) ProvisionDeviceByQrCode(w http.ResponseWriter, r *http.Request) {
+	qrCodeRequestParam := QrCodeRequest{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&qrCodeRequestParam); err != nil && !errors.Is(err, io.EOF) {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertQrCodeRequestRequired(qrCodeRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertQrCodeRequestConstraints(qrCodeRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.ProvisionDeviceByQrCode(r.Context(), qrCodeRequestParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
 // PutDevice - Create or update a LoRaWAN device
 func (c *DevicesAPIController) PutDevice(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var dryRunParam bool
+	if query.Has("dryRun") {
+		param, err := parseBoolParameter(
+			query.Get("dryRun"),
+			WithParse[bool](parseBool),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		dryRunParam = param
+	} else {
+		var param bool = false
+		dryRunParam = param
+	}
+	idempotencyKeyParam := r.Header.Get("Idempotency-Key")
 	putDeviceRequestParam := PutDeviceRequest{}
 	d := json.NewDecoder(r.Body)
 	d.DisallowUnknownFields()
@@ -92,7 +354,7 @@ func (c *DevicesAPIController) PutDevice(w http.ResponseWriter, r *http.Request)
 		c.errorHandler(w, r, err, nil)
 		return
 	}
-	result, err := c.service.PutDevice(r.Context(), putDeviceRequestParam)
+	result, err := c.service.PutDevice(r.Context(), dryRunParam, idempotencyKeyParam, putDeviceRequestParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
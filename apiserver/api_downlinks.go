@@ -0,0 +1,156 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+package apiserver
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// DownlinksAPIController binds http requests to an api service and writes the service results to the http response
+type DownlinksAPIController struct {
+	service      DownlinksAPIServicer
+	errorHandler ErrorHandler
+}
+
+// DownlinksAPIOption for how the controller is set up.
+type DownlinksAPIOption func(*DownlinksAPIController)
+
+// WithDownlinksAPIErrorHandler inject ErrorHandler into controller
+func WithDownlinksAPIErrorHandler(h ErrorHandler) DownlinksAPIOption {
+	return func(c *DownlinksAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewDownlinksAPIController creates a default api controller
+func NewDownlinksAPIController(s DownlinksAPIServicer, opts ...DownlinksAPIOption) Router {
+	controller := &DownlinksAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the DownlinksAPIController
+func (c *DownlinksAPIController) Routes() Routes {
+	return Routes{
+		"DeleteDeviceDownlinks": Route{
+			strings.ToUpper("Delete"),
+			"/v1/devices/{dev-eui}/downlinks",
+			c.DeleteDeviceDownlinks,
+		},
+		"GetDeviceDownlinks": Route{
+			strings.ToUpper("Get"),
+			"/v1/devices/{dev-eui}/downlinks",
+			c.GetDeviceDownlinks,
+		},
+		"GetDownlinks": Route{
+			strings.ToUpper("Get"),
+			"/v1/downlinks",
+			c.GetDownlinks,
+		},
+		"PostDeviceDownlink": Route{
+			strings.ToUpper("Post"),
+			"/v1/devices/{dev-eui}/downlinks",
+			c.PostDeviceDownlink,
+		},
+	}
+}
+
+// DeleteDeviceDownlinks - Flush the downlink queue of a device
+func (c *DownlinksAPIController) DeleteDeviceDownlinks(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	devEuiParam := params["dev-eui"]
+	if devEuiParam == "" {
+		c.errorHandler(w, r, &RequiredError{"dev-eui"}, nil)
+		return
+	}
+	result, err := c.service.DeleteDeviceDownlinks(r.Context(), devEuiParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// GetDeviceDownlinks - Get the downlink queue of a device
+func (c *DownlinksAPIController) GetDeviceDownlinks(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	devEuiParam := params["dev-eui"]
+	if devEuiParam == "" {
+		c.errorHandler(w, r, &RequiredError{"dev-eui"}, nil)
+		return
+	}
+	result, err := c.service.GetDeviceDownlinks(r.Context(), devEuiParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// GetDownlinks - Get downlinks
+func (c *DownlinksAPIController) GetDownlinks(w http.ResponseWriter, r *http.Request) {
+	result, err := c.service.GetDownlinks(r.Context())
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// PostDeviceDownlink - Enqueue a downlink for a device
+func (c *DownlinksAPIController) PostDeviceDownlink(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	devEuiParam := params["dev-eui"]
+	if devEuiParam == "" {
+		c.errorHandler(w, r, &RequiredError{"dev-eui"}, nil)
+		return
+	}
+	newDownlinkParam := NewDownlink{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&newDownlinkParam); err != nil && !errors.Is(err, io.EOF) {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertNewDownlinkRequired(newDownlinkParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertNewDownlinkConstraints(newDownlinkParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.PostDeviceDownlink(r.Context(), devEuiParam, newDownlinkParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
@@ -0,0 +1,78 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+package apiserver
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// JobsAPIController binds http requests to an api service and writes the service results to the http response
+type JobsAPIController struct {
+	service      JobsAPIServicer
+	errorHandler ErrorHandler
+}
+
+// JobsAPIOption for how the controller is set up.
+type JobsAPIOption func(*JobsAPIController)
+
+// WithJobsAPIErrorHandler inject ErrorHandler into controller
+func WithJobsAPIErrorHandler(h ErrorHandler) JobsAPIOption {
+	return func(c *JobsAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewJobsAPIController creates a default api controller
+func NewJobsAPIController(s JobsAPIServicer, opts ...JobsAPIOption) Router {
+	controller := &JobsAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the JobsAPIController
+func (c *JobsAPIController) Routes() Routes {
+	return Routes{
+		"GetJobById": Route{
+			strings.ToUpper("Get"),
+			"/v1/jobs/{job-id}",
+			c.GetJobById,
+		},
+	}
+}
+
+// GetJobById - Get job
+func (c *JobsAPIController) GetJobById(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	jobIdParam, err := parseNumericParameter[int64](
+		params["job-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := c.service.GetJobById(r.Context(), jobIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
@@ -0,0 +1,138 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+package apiserver
+
+import (
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// KeyBatchesAPIController binds http requests to an api service and writes the service results to the http response
+type KeyBatchesAPIController struct {
+	service      KeyBatchesAPIServicer
+	errorHandler ErrorHandler
+}
+
+// KeyBatchesAPIOption for how the controller is set up.
+type KeyBatchesAPIOption func(*KeyBatchesAPIController)
+
+// WithKeyBatchesAPIErrorHandler inject ErrorHandler into controller
+func WithKeyBatchesAPIErrorHandler(h ErrorHandler) KeyBatchesAPIOption {
+	return func(c *KeyBatchesAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewKeyBatchesAPIController creates a default api controller
+func NewKeyBatchesAPIController(s KeyBatchesAPIServicer, opts ...KeyBatchesAPIOption) Router {
+	controller := &KeyBatchesAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the KeyBatchesAPIController
+func (c *KeyBatchesAPIController) Routes() Routes {
+	return Routes{
+		"DeleteKeyBatchById": Route{
+			strings.ToUpper("Delete"),
+			"/v1/key-batches/{batch-id}",
+			c.DeleteKeyBatchById,
+		},
+		"GetKeyBatches": Route{
+			strings.ToUpper("Get"),
+			"/v1/key-batches",
+			c.GetKeyBatches,
+		},
+		"PostKeyBatch": Route{
+			strings.ToUpper("Post"),
+			"/v1/key-batches",
+			c.PostKeyBatch,
+		},
+	}
+}
+
+// DeleteKeyBatchById - Delete key batch
+func (c *KeyBatchesAPIController) DeleteKeyBatchById(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	batchIdParam, err := parseNumericParameter[int64](
+		params["batch-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := c.service.DeleteKeyBatchById(r.Context(), batchIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// GetKeyBatches - Get key batches
+func (c *KeyBatchesAPIController) GetKeyBatches(w http.ResponseWriter, r *http.Request) {
+	result, err := c.service.GetKeyBatches(r.Context())
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// PostKeyBatch - Load key batch
+func (c *KeyBatchesAPIController) PostKeyBatch(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	query := r.URL.Query()
+	var nameParam string
+	if query.Has("name") {
+		param := query.Get("name")
+
+		nameParam = param
+	} else {
+		c.errorHandler(w, r, &RequiredError{Field: "name"}, nil)
+		return
+	}
+	var fileParam *os.File
+	{
+		param, err := ReadFormFileToTempFile(r, "file")
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		fileParam = param
+	}
+
+	result, err := c.service.PostKeyBatch(r.Context(), nameParam, fileParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
@@ -0,0 +1,129 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+package apiserver
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// OutboxAPIController binds http requests to an api service and writes the service results to the http response
+type OutboxAPIController struct {
+	service      OutboxAPIServicer
+	errorHandler ErrorHandler
+}
+
+// OutboxAPIOption for how the controller is set up.
+type OutboxAPIOption func(*OutboxAPIController)
+
+// WithOutboxAPIErrorHandler inject ErrorHandler into controller
+func WithOutboxAPIErrorHandler(h ErrorHandler) OutboxAPIOption {
+	return func(c *OutboxAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewOutboxAPIController creates a default api controller
+func NewOutboxAPIController(s OutboxAPIServicer, opts ...OutboxAPIOption) Router {
+	controller := &OutboxAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the OutboxAPIController
+func (c *OutboxAPIController) Routes() Routes {
+	return Routes{
+		"DiscardOutboxOperation": Route{
+			strings.ToUpper("Delete"),
+			"/v1/outbox/{operation-id}",
+			c.DiscardOutboxOperation,
+		},
+		"GetOutboxOperations": Route{
+			strings.ToUpper("Get"),
+			"/v1/outbox",
+			c.GetOutboxOperations,
+		},
+		"RetryOutboxOperation": Route{
+			strings.ToUpper("Post"),
+			"/v1/outbox/{operation-id}/retry",
+			c.RetryOutboxOperation,
+		},
+	}
+}
+
+// DiscardOutboxOperation - Discard an outbox operation
+func (c *OutboxAPIController) DiscardOutboxOperation(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	operationIdParam, err := parseNumericParameter[int64](
+		params["operation-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := c.service.DiscardOutboxOperation(r.Context(), operationIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// GetOutboxOperations - Get outbox operations
+func (c *OutboxAPIController) GetOutboxOperations(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var statusParam string
+	if query.Has("status") {
+		param := query.Get("status")
+
+		statusParam = param
+	} else {
+	}
+	result, err := c.service.GetOutboxOperations(r.Context(), statusParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// RetryOutboxOperation - Retry an outbox operation
+func (c *OutboxAPIController) RetryOutboxOperation(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	operationIdParam, err := parseNumericParameter[int64](
+		params["operation-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := c.service.RetryOutboxOperation(r.Context(), operationIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
@@ -0,0 +1,59 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+import (
+	"time"
+)
+
+// Codec - TTN compatible JavaScript codec providing decodeUplink(input) and encodeDownlink(input) for devices of an asset type.
+type Codec struct {
+
+	// Internal identifier for the codec (created automatically).
+	Id *int64 `json:"id,omitempty"`
+
+	// Name of the Eliona asset type the codec is used for
+	AssetTypeName string `json:"assetTypeName"`
+
+	// JavaScript source defining the functions decodeUplink(input) and/or encodeDownlink(input)
+	Script string `json:"script"`
+
+	// Maximum execution time in milliseconds
+	Timeout *int32 `json:"timeout,omitempty"`
+
+	// Maximum memory in MiB a single execution may allocate
+	MemoryLimit *int32 `json:"memoryLimit,omitempty"`
+
+	// ID of the last Eliona user who created or updated the codec
+	UserId *string `json:"userId,omitempty"`
+
+	// Timestamp of the latest change
+	ModifiedAt *time.Time `json:"modifiedAt,omitempty"`
+}
+
+// AssertCodecRequired checks if the required fields are not zero-ed
+func AssertCodecRequired(obj Codec) error {
+	elements := map[string]interface{}{
+		"assetTypeName": obj.AssetTypeName,
+		"script":        obj.Script,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertCodecConstraints checks if the values respects the defined constraints
+func AssertCodecConstraints(obj Codec) error {
+	return nil
+}
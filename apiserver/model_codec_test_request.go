@@ -0,0 +1,36 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// CodecTestRequest - Input to test a codec with.
+type CodecTestRequest struct {
+
+	// Runs decodeUplink for uplink or encodeDownlink for downlink
+	Direction string `json:"direction,omitempty"`
+
+	// LoRaWAN port of the uplink
+	FPort *int32 `json:"fPort,omitempty"`
+
+	// Hex encoded uplink payload
+	Bytes string `json:"bytes,omitempty"`
+
+	// Downlink data to encode
+	Data map[string]interface{} `json:"data,omitempty"`
+}
+
+// AssertCodecTestRequestRequired checks if the required fields are not zero-ed
+func AssertCodecTestRequestRequired(obj CodecTestRequest) error {
+	return nil
+}
+
+// AssertCodecTestRequestConstraints checks if the values respects the defined constraints
+func AssertCodecTestRequestConstraints(obj CodecTestRequest) error {
+	return nil
+}
@@ -0,0 +1,39 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// CodecTestResult - Output of a codec test.
+type CodecTestResult struct {
+
+	// Decoded uplink data
+	Data map[string]interface{} `json:"data,omitempty"`
+
+	// Hex encoded downlink payload
+	Bytes string `json:"bytes,omitempty"`
+
+	// LoRaWAN port of the downlink
+	FPort *int32 `json:"fPort,omitempty"`
+
+	// Warnings reported by the codec
+	Warnings []string `json:"warnings,omitempty"`
+
+	// Errors reported by the codec or raised during execution
+	Errors []string `json:"errors,omitempty"`
+}
+
+// AssertCodecTestResultRequired checks if the required fields are not zero-ed
+func AssertCodecTestResultRequired(obj CodecTestResult) error {
+	return nil
+}
+
+// AssertCodecTestResultConstraints checks if the values respects the defined constraints
+func AssertCodecTestResultConstraints(obj CodecTestResult) error {
+	return nil
+}
@@ -18,7 +18,7 @@ type Configuration struct {
 	// API base URL
 	ApiBaseUrl string `json:"apiBaseUrl,omitempty"`
 
-	// API Bearer token
+	// API Bearer token. The token is stored encrypted and returned masked as `********`. If the token is omitted or masked when updating a configuration, the stored token is kept.
 	ApiToken string `json:"apiToken,omitempty"`
 
 	// Flag to enable or disable fetching from this API
@@ -33,12 +33,41 @@ type Configuration struct {
 	// List of Eliona project ids for which this device should collect data. For each project id all smart devices are automatically created as an asset in Eliona. The mapping between Eliona is stored as an asset mapping in the KentixONE app.
 	ProjectIDs *[]string `json:"projectIDs,omitempty"`
 
+	// Duration in seconds a gateway has to be disconnected before it is reported as offline
+	GatewayOfflineThreshold *int32 `json:"gatewayOfflineThreshold,omitempty"`
+
+	// Asset type of the Eliona assets created for devices discovered in a Loriot.io application, by application ID. Devices of applications without asset type are not discovered.
+	DefaultAssetTypes map[string]string `json:"defaultAssetTypes,omitempty"`
+
+	// Rules routing devices to projects of the configuration by application ID, device EUI prefix and title. The rules must not overlap. Without rules, devices are routed to all projects of the configuration.
+	RoutingRules []RoutingRule `json:"routingRules,omitempty"`
+
+	// Expected interval in seconds between uplinks of devices, by asset type name. Devices without reporting interval are not watched.
+	ReportingIntervals map[string]int32 `json:"reportingIntervals,omitempty"`
+
+	// Number of missed reporting intervals after which a device is reported as offline
+	DeviceOfflineIntervals *int32 `json:"deviceOfflineIntervals,omitempty"`
+
+	// How drift between Loriot.io, Eliona and the app is fixed: report, adopt, delete or recreate
+	ReconcilePolicy *string `json:"reconcilePolicy,omitempty"`
+
+	// Hexadecimal prefix of the IEEE block (OUI/MA-L with 6, MA-M with 7 or IAB/MA-S with 9 digits) in which device EUIs are generated
+	DevEUIBlock *string `json:"devEUIBlock,omitempty"`
+
+	// Version of the configuration, incremented with each update. Returned as ETag and checked with If-Match.
+	Version *int32 `json:"version,omitempty"`
+
 	// ID of the last Eliona user who created or updated the configuration
 	UserId *string `json:"userId,omitempty"`
 }
 
 // AssertConfigurationRequired checks if the required fields are not zero-ed
 func AssertConfigurationRequired(obj Configuration) error {
+	for _, el := range obj.RoutingRules {
+		if err := AssertRoutingRuleRequired(el); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
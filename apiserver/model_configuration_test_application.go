@@ -0,0 +1,47 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+package apiserver
+
+// ConfigurationTestApplication - Loriot.io application visible with the token of a configuration
+type ConfigurationTestApplication struct {
+
+	// Application hexadecimal (uppercase) ID for Loriot
+	AppID string `json:"appID,omitempty"`
+
+	// Name of the application
+	Name string `json:"name,omitempty"`
+
+	// Number of devices in the application
+	Devices int32 `json:"devices"`
+
+	// Maximum number of devices in the application
+	DeviceLimit int32 `json:"deviceLimit"`
+
+	// True if the application is suspended
+	Suspended bool `json:"suspended"`
+
+	// True if the token may receive the data of the application. Missing if the rights of the token are unknown.
+	Data *bool `json:"data,omitempty"`
+
+	// True if the token may act as application server. Missing if the rights of the token are unknown.
+	AppServer *bool `json:"appServer,omitempty"`
+
+	// True if the token may provision devices. Missing if the rights of the token are unknown.
+	DevProvisioning *bool `json:"devProvisioning,omitempty"`
+}
+
+// AssertConfigurationTestApplicationRequired checks if the required fields are not zero-ed
+func AssertConfigurationTestApplicationRequired(obj ConfigurationTestApplication) error {
+	return nil
+}
+
+// AssertConfigurationTestApplicationConstraints checks if the values respects the defined constraints
+func AssertConfigurationTestApplicationConstraints(obj ConfigurationTestApplication) error {
+	return nil
+}
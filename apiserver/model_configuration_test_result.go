@@ -0,0 +1,65 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+package apiserver
+
+import (
+	"time"
+)
+
+// ConfigurationTestResult - Diagnostic of the connection to the Loriot.io API of a configuration
+type ConfigurationTestResult struct {
+
+	// True if the API is reachable, the TLS connection is valid and the token is accepted
+	Ok bool `json:"ok"`
+
+	// True if the API responded
+	Reachable bool `json:"reachable"`
+
+	// True if the token is accepted by the API
+	Authenticated bool `json:"authenticated"`
+
+	// HTTP status code of the API
+	StatusCode *int32 `json:"statusCode,omitempty"`
+
+	// Duration of the request in milliseconds
+	LatencyMs *int64 `json:"latencyMs,omitempty"`
+
+	// TLS version of the connection
+	TlsVersion *string `json:"tlsVersion,omitempty"`
+
+	// Expiry of the certificate of the API
+	CertificateExpiresAt *time.Time `json:"certificateExpiresAt,omitempty"`
+
+	// Error establishing the TLS connection, e.g. an untrusted or expired certificate
+	TlsError *string `json:"tlsError,omitempty"`
+
+	// Error of the connection or the API
+	Error *string `json:"error,omitempty"`
+
+	// Issues not preventing the use of the configuration, e.g. applications at their device limit
+	Warnings []string `json:"warnings,omitempty"`
+
+	// Applications visible with the token
+	Applications []ConfigurationTestApplication `json:"applications,omitempty"`
+}
+
+// AssertConfigurationTestResultRequired checks if the required fields are not zero-ed
+func AssertConfigurationTestResultRequired(obj ConfigurationTestResult) error {
+	for _, el := range obj.Applications {
+		if err := AssertConfigurationTestApplicationRequired(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertConfigurationTestResultConstraints checks if the values respects the defined constraints
+func AssertConfigurationTestResultConstraints(obj ConfigurationTestResult) error {
+	return nil
+}
@@ -39,6 +39,30 @@ type DeviceAsset struct {
 
 	// Timestamp of the latest create, update or delete action
 	ModifiedAt *time.Time `json:"modifiedAt,omitempty"`
+
+	// Name of the asset type of the corresponding asset
+	AssetTypeName *string `json:"assetTypeName,omitempty"`
+
+	// Name of the payload decoder overriding the decoder of the asset type
+	Decoder *string `json:"decoder,omitempty"`
+
+	// Error of the latest failed payload decoding
+	LastDecodingError *string `json:"lastDecodingError,omitempty"`
+
+	// Timestamp of the latest failed payload decoding
+	LastDecodingErrorAt *time.Time `json:"lastDecodingErrorAt,omitempty"`
+
+	// Expected interval in seconds between uplinks of the device overriding the interval of the asset type
+	ReportingInterval *int32 `json:"reportingInterval,omitempty"`
+
+	// Timestamp of the latest uplink of the device
+	LastUplinkAt *time.Time `json:"lastUplinkAt,omitempty"`
+
+	// Connection state of the device determined by the reporting interval: online, late or offline
+	ConnectionState *string `json:"connectionState,omitempty"`
+
+	// Timestamp since the device is in the connection state
+	ConnectionStateSince *time.Time `json:"connectionStateSince,omitempty"`
 }
 
 // AssertDeviceAssetRequired checks if the required fields are not zero-ed
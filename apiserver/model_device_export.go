@@ -0,0 +1,96 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+package apiserver
+
+import (
+	"time"
+)
+
+// DeviceExport - LoRaWAN device handled by the app joined with its Eliona asset and its live state in Loriot.io
+type DeviceExport struct {
+
+	// Configuration defining the Loriot.io target the device belongs to
+	ConfigID int64 `json:"configID,omitempty"`
+
+	// Eliona project ID the asset belongs to
+	ProjectID string `json:"projectID,omitempty"`
+
+	// Application hexadecimal (uppercase) ID for Loriot
+	AppID string `json:"appID,omitempty"`
+
+	// Global ID in IEEE EUI64 address space that uniquely identifies the device
+	DevEUI string `json:"devEUI,omitempty"`
+
+	// ID of the Eliona asset
+	AssetID int32 `json:"assetID,omitempty"`
+
+	// Unique identifier for the asset
+	GlobalAssetIdentifier string `json:"globalAssetIdentifier,omitempty"`
+
+	// Name of the Eliona asset
+	AssetName *string `json:"assetName,omitempty"`
+
+	// Name of the asset type of the Eliona asset
+	AssetTypeName *string `json:"assetTypeName,omitempty"`
+
+	// Connection state of the device derived from its reporting interval
+	ConnectionState *string `json:"connectionState,omitempty"`
+
+	// Timestamp of the latest uplink received by the app
+	LastUplinkAt *time.Time `json:"lastUplinkAt,omitempty"`
+
+	// Whether the device exists in Loriot.io
+	InLoriot bool `json:"inLoriot"`
+
+	// Title of the device in Loriot.io
+	Title *string `json:"title,omitempty"`
+
+	// Device address assigned by the network
+	DevAddr *string `json:"devAddr,omitempty"`
+
+	// Timestamp the device was last seen by Loriot.io
+	LastSeen *time.Time `json:"lastSeen,omitempty"`
+
+	// Battery level reported by the device, 0 for external power and 255 if unknown
+	Bat *int32 `json:"bat,omitempty"`
+
+	// Application EUI of the device
+	AppEUI *string `json:"appEUI,omitempty"`
+
+	// Application key of the device, only exported with includeKeys
+	AppKey *string `json:"appKey,omitempty"`
+
+	// Network root key of the device for OTAA v1.1, only exported with includeKeys
+	NwkKey *string `json:"nwkKey,omitempty"`
+
+	// Network session key of the device, only exported with includeKeys
+	NwkSKey *string `json:"nwkSKey,omitempty"`
+
+	// Application session key of the device, only exported with includeKeys
+	AppSKey *string `json:"appSKey,omitempty"`
+
+	// Forwarding network session integrity key of the device for ABP v1.1, only exported with includeKeys
+	FNwkSIntKey *string `json:"fNwkSIntKey,omitempty"`
+
+	// Serving network session integrity key of the device for ABP v1.1, only exported with includeKeys
+	SNwkSIntKey *string `json:"sNwkSIntKey,omitempty"`
+
+	// Network session encryption key of the device for ABP v1.1, only exported with includeKeys
+	NwkSEncKey *string `json:"nwkSEncKey,omitempty"`
+}
+
+// AssertDeviceExportRequired checks if the required fields are not zero-ed
+func AssertDeviceExportRequired(obj DeviceExport) error {
+	return nil
+}
+
+// AssertDeviceExportConstraints checks if the values respects the defined constraints
+func AssertDeviceExportConstraints(obj DeviceExport) error {
+	return nil
+}
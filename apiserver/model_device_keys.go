@@ -0,0 +1,54 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+package apiserver
+
+import (
+	"time"
+)
+
+// DeviceKeys - Root and session keys of a LoRaWAN device stored encrypted in the key vault of the app
+type DeviceKeys struct {
+
+	// Global ID in IEEE EUI64 address space that uniquely identifies the device
+	DevEUI string `json:"devEUI,omitempty"`
+
+	// Root key of the device for OTAA
+	AppKey string `json:"appKey,omitempty"`
+
+	// Network root key of the device for OTAA v1.1
+	NwkKey string `json:"nwkKey,omitempty"`
+
+	// Network session key of the device for ABP v1.0
+	NwkSKey string `json:"nwkSKey,omitempty"`
+
+	// Application session key of the device for ABP
+	AppSKey string `json:"appSKey,omitempty"`
+
+	// Forwarding network session integrity key of the device for ABP v1.1
+	FNwkSIntKey string `json:"fNwkSIntKey,omitempty"`
+
+	// Serving network session integrity key of the device for ABP v1.1
+	SNwkSIntKey string `json:"sNwkSIntKey,omitempty"`
+
+	// Network session encryption key of the device for ABP v1.1
+	NwkSEncKey string `json:"nwkSEncKey,omitempty"`
+
+	// Timestamp the keys were last stored
+	ModifiedAt *time.Time `json:"modifiedAt,omitempty"`
+}
+
+// AssertDeviceKeysRequired checks if the required fields are not zero-ed
+func AssertDeviceKeysRequired(obj DeviceKeys) error {
+	return nil
+}
+
+// AssertDeviceKeysConstraints checks if the values respects the defined constraints
+func AssertDeviceKeysConstraints(obj DeviceKeys) error {
+	return nil
+}
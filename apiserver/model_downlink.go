@@ -0,0 +1,63 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+package apiserver
+
+import (
+	"time"
+)
+
+// Downlink - Downlink sent to a LoRaWAN device
+type Downlink struct {
+
+	// Internal identifier for the downlink
+	Id *int64 `json:"id,omitempty"`
+
+	// Configuration defining the Loriot.io target the downlink was sent with
+	ConfigID *int64 `json:"configID,omitempty"`
+
+	// Asset whose output data caused the downlink
+	AssetID *int32 `json:"assetID,omitempty"`
+
+	// Global ID in IEEE EUI64 address space that uniquely identifies the device
+	DevEUI string `json:"devEUI,omitempty"`
+
+	// LoRaWAN port of the downlink
+	FPort int32 `json:"fPort,omitempty"`
+
+	// Whether the device has to acknowledge the downlink
+	Confirmed bool `json:"confirmed,omitempty"`
+
+	// Hex encoded payload
+	Payload string `json:"payload,omitempty"`
+
+	// Status of the downlink
+	Status string `json:"status,omitempty"`
+
+	// Reason if the downlink failed
+	Error *string `json:"error,omitempty"`
+
+	// Timestamp the downlink was issued
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+	// Timestamp of the latest status change
+	ModifiedAt *time.Time `json:"modifiedAt,omitempty"`
+
+	// Eliona user who issued the downlink
+	UserID *string `json:"userID,omitempty"`
+}
+
+// AssertDownlinkRequired checks if the required fields are not zero-ed
+func AssertDownlinkRequired(obj Downlink) error {
+	return nil
+}
+
+// AssertDownlinkConstraints checks if the values respects the defined constraints
+func AssertDownlinkConstraints(obj Downlink) error {
+	return nil
+}
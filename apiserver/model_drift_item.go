@@ -0,0 +1,44 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+package apiserver
+
+// DriftItem - Difference between Loriot.io, Eliona and the app for a device
+type DriftItem struct {
+
+	// Kind of the drift: asset_missing, device_missing, orphaned, deleted_device_exists, untracked_asset or untracked_device
+	Kind string `json:"kind,omitempty"`
+
+	// Global ID in IEEE EUI64 address space that uniquely identifies the device
+	DevEUI string `json:"devEUI,omitempty"`
+
+	// Application hexadecimal (uppercase) ID for Loriot
+	AppID *string `json:"appID,omitempty"`
+
+	// Eliona project ID the asset belongs to
+	ProjectID *string `json:"projectID,omitempty"`
+
+	// ID of the Eliona asset
+	AssetID *int32 `json:"assetID,omitempty"`
+
+	// Action fixing the drift according to the reconcile policy: none, mark_deleted, delete_device, delete_asset, recreate_asset or adopt_asset
+	Action string `json:"action,omitempty"`
+
+	// Error of the failed action
+	Error *string `json:"error,omitempty"`
+}
+
+// AssertDriftItemRequired checks if the required fields are not zero-ed
+func AssertDriftItemRequired(obj DriftItem) error {
+	return nil
+}
+
+// AssertDriftItemConstraints checks if the values respects the defined constraints
+func AssertDriftItemConstraints(obj DriftItem) error {
+	return nil
+}
@@ -0,0 +1,46 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+package apiserver
+
+import (
+	"time"
+)
+
+// DriftReport - Drift between the devices in Loriot.io, the assets in Eliona and the device assets of the app for a configuration
+type DriftReport struct {
+
+	// Configuration the report belongs to
+	ConfigID int64 `json:"configID,omitempty"`
+
+	// Reconcile policy of the configuration
+	Policy string `json:"policy,omitempty"`
+
+	// Whether the actions were performed or only reported
+	Fixed bool `json:"fixed,omitempty"`
+
+	// Timestamp of the comparison
+	CheckedAt time.Time `json:"checkedAt,omitempty"`
+
+	Items []DriftItem `json:"items,omitempty"`
+}
+
+// AssertDriftReportRequired checks if the required fields are not zero-ed
+func AssertDriftReportRequired(obj DriftReport) error {
+	for _, el := range obj.Items {
+		if err := AssertDriftItemRequired(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertDriftReportConstraints checks if the values respects the defined constraints
+func AssertDriftReportConstraints(obj DriftReport) error {
+	return nil
+}
@@ -0,0 +1,62 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+package apiserver
+
+import (
+	"time"
+)
+
+// Job - Asynchronous job of the app, e.g. a bulk device import
+type Job struct {
+
+	// Internal identifier for the job
+	Id int64 `json:"id,omitempty"`
+
+	// Kind of the job
+	Kind string `json:"kind,omitempty"`
+
+	// Status of the job
+	Status string `json:"status,omitempty"`
+
+	// Number of rows of the job
+	Total int32 `json:"total,omitempty"`
+
+	// Number of successfully processed rows
+	Succeeded int32 `json:"succeeded,omitempty"`
+
+	// Number of failed rows
+	Failed int32 `json:"failed,omitempty"`
+
+	// ID of the Eliona user who started the job
+	UserId *string `json:"userId,omitempty"`
+
+	// Timestamp the job was created
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+	// Timestamp of the latest progress
+	ModifiedAt *time.Time `json:"modifiedAt,omitempty"`
+
+	// Rows of the job in the order of the upload
+	Rows []JobRow `json:"rows,omitempty"`
+}
+
+// AssertJobRequired checks if the required fields are not zero-ed
+func AssertJobRequired(obj Job) error {
+	for _, el := range obj.Rows {
+		if err := AssertJobRowRequired(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertJobConstraints checks if the values respects the defined constraints
+func AssertJobConstraints(obj Job) error {
+	return nil
+}
@@ -0,0 +1,38 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+package apiserver
+
+// JobRow - Row of a job with its processing status
+type JobRow struct {
+
+	// Number of the row in the upload, starting with 1
+	Row int32 `json:"row,omitempty"`
+
+	// Global ID in IEEE EUI64 address space that uniquely identifies the device
+	DevEUI string `json:"devEUI,omitempty"`
+
+	// Status of the row
+	Status string `json:"status,omitempty"`
+
+	// Error of the failed row
+	Error *string `json:"error,omitempty"`
+
+	// IDs of the Eliona assets created or updated for the device
+	AssetIDs []int32 `json:"assetIDs,omitempty"`
+}
+
+// AssertJobRowRequired checks if the required fields are not zero-ed
+func AssertJobRowRequired(obj JobRow) error {
+	return nil
+}
+
+// AssertJobRowConstraints checks if the values respects the defined constraints
+func AssertJobRowConstraints(obj JobRow) error {
+	return nil
+}
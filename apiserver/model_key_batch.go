@@ -0,0 +1,45 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+package apiserver
+
+import (
+	"time"
+)
+
+// KeyBatch - Batch of device keys loaded for provisioning devices by QR code
+type KeyBatch struct {
+
+	// Internal identifier for the batch
+	Id int64 `json:"id,omitempty"`
+
+	// Name of the batch, e.g. the delivery note of the devices
+	Name string `json:"name,omitempty"`
+
+	// Number of devices with keys in the batch
+	Devices int32 `json:"devices,omitempty"`
+
+	// Number of devices of the batch already provisioned
+	Provisioned int32 `json:"provisioned,omitempty"`
+
+	// ID of the Eliona user who loaded the batch
+	UserId *string `json:"userId,omitempty"`
+
+	// Timestamp the batch was loaded
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+}
+
+// AssertKeyBatchRequired checks if the required fields are not zero-ed
+func AssertKeyBatchRequired(obj KeyBatch) error {
+	return nil
+}
+
+// AssertKeyBatchConstraints checks if the values respects the defined constraints
+func AssertKeyBatchConstraints(obj KeyBatch) error {
+	return nil
+}
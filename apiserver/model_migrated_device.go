@@ -0,0 +1,47 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+package apiserver
+
+// MigratedDevice - Outcome of migrating one device
+type MigratedDevice struct {
+
+	// Global ID in IEEE EUI64 address space that uniquely identifies the device
+	DevEUI string `json:"devEUI,omitempty"`
+
+	// Name of the device in the export, used as title in Loriot.io
+	Name string `json:"name,omitempty"`
+
+	// LoRaWAN version of the device
+	MacVersion string `json:"macVersion,omitempty"`
+
+	// Activation mode the device is created with in Loriot.io: otaa10, otaa11, abp10 or abp11
+	Activation string `json:"activation,omitempty"`
+
+	// Status of the migration: migrated or failed
+	Status string `json:"status,omitempty"`
+
+	// Error of the failed migration
+	Error *string `json:"error,omitempty"`
+
+	// Values of the export that couldn't be migrated
+	Warnings []string `json:"warnings,omitempty"`
+
+	// IDs of the Eliona assets created or updated for the device
+	AssetIDs []int32 `json:"assetIDs,omitempty"`
+}
+
+// AssertMigratedDeviceRequired checks if the required fields are not zero-ed
+func AssertMigratedDeviceRequired(obj MigratedDevice) error {
+	return nil
+}
+
+// AssertMigratedDeviceConstraints checks if the values respects the defined constraints
+func AssertMigratedDeviceConstraints(obj MigratedDevice) error {
+	return nil
+}
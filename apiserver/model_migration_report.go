@@ -0,0 +1,43 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+package apiserver
+
+// MigrationReport - Outcome of migrating the devices of another network server
+type MigrationReport struct {
+
+	// Network server the devices were exported from
+	Source string `json:"source,omitempty"`
+
+	// Number of devices in the export
+	Total int32 `json:"total,omitempty"`
+
+	// Number of migrated devices
+	Migrated int32 `json:"migrated,omitempty"`
+
+	// Number of devices that couldn't be migrated
+	Failed int32 `json:"failed,omitempty"`
+
+	// Outcome per device in the order of the export
+	Devices []MigratedDevice `json:"devices,omitempty"`
+}
+
+// AssertMigrationReportRequired checks if the required fields are not zero-ed
+func AssertMigrationReportRequired(obj MigrationReport) error {
+	for _, el := range obj.Devices {
+		if err := AssertMigratedDeviceRequired(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertMigrationReportConstraints checks if the values respects the defined constraints
+func AssertMigrationReportConstraints(obj MigrationReport) error {
+	return nil
+}
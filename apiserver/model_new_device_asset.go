@@ -28,6 +28,12 @@ type NewDeviceAsset struct {
 
 	// Description for the new device and asset
 	Description string `json:"description,omitempty"`
+
+	// Name of the payload decoder for uplinks of the device. If empty the decoder registered for the asset type is used.
+	Decoder string `json:"decoder,omitempty"`
+
+	// Expected interval in seconds between uplinks of the device. If empty the reporting interval configured for the asset type is used.
+	ReportingInterval *int32 `json:"reportingInterval,omitempty"`
 }
 
 // AssertNewDeviceAssetRequired checks if the required fields are not zero-ed
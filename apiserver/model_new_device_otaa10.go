@@ -29,6 +29,12 @@ type NewDeviceOtaa10 struct {
 	// Description for the new device and asset
 	Description string `json:"description,omitempty"`
 
+	// Name of the payload decoder for uplinks of the device. If empty the decoder registered for the asset type is used.
+	Decoder string `json:"decoder,omitempty"`
+
+	// Expected interval in seconds between uplinks of the device. If empty the reporting interval configured for the asset type is used.
+	ReportingInterval *int32 `json:"reportingInterval,omitempty"`
+
 	AppEUI string `json:"appEUI,omitempty"`
 
 	AppKey string `json:"appKey,omitempty"`
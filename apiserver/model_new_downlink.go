@@ -0,0 +1,45 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+package apiserver
+
+import (
+	"errors"
+)
+
+// NewDownlink - Downlink to enqueue for a LoRaWAN device, given either as raw payload or as data for the encoder of the device
+type NewDownlink struct {
+
+	// LoRaWAN port of the downlink. Required for raw payloads, overrides the port chosen by the encoder otherwise.
+	FPort *int32 `json:"fPort,omitempty"`
+
+	// Whether the device has to acknowledge the downlink
+	Confirmed bool `json:"confirmed,omitempty"`
+
+	// Hex encoded raw payload
+	Payload *string `json:"payload,omitempty"`
+
+	// Data encoded by the encoder of the device or its asset type
+	Data map[string]interface{} `json:"data,omitempty"`
+}
+
+// AssertNewDownlinkRequired checks if the required fields are not zero-ed
+func AssertNewDownlinkRequired(obj NewDownlink) error {
+	return nil
+}
+
+// AssertNewDownlinkConstraints checks if the values respects the defined constraints
+func AssertNewDownlinkConstraints(obj NewDownlink) error {
+	if obj.FPort != nil && *obj.FPort < 1 {
+		return &ParsingError{Err: errors.New(errMsgMinValueConstraint)}
+	}
+	if obj.FPort != nil && *obj.FPort > 223 {
+		return &ParsingError{Err: errors.New(errMsgMaxValueConstraint)}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+package apiserver
+
+import (
+	"time"
+)
+
+// OutboxOperation - Change of a Loriot.io device triggered by an Eliona asset change, performed with retries
+type OutboxOperation struct {
+
+	// Internal identifier for the operation
+	Id int64 `json:"id,omitempty"`
+
+	// Configuration defining the Loriot.io target of the operation
+	ConfigID int64 `json:"configID,omitempty"`
+
+	// Asset whose change caused the operation
+	AssetID int32 `json:"assetID,omitempty"`
+
+	// Global ID in IEEE EUI64 address space that uniquely identifies the device
+	DevEUI string `json:"devEUI,omitempty"`
+
+	// Operation performed for the device in Loriot.io
+	Operation string `json:"operation,omitempty"`
+
+	// Status of the operation
+	Status string `json:"status,omitempty"`
+
+	// Number of failed attempts
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// Timestamp of the next attempt of a pending operation
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty"`
+
+	// Error of the latest failed attempt
+	LastError *string `json:"lastError,omitempty"`
+
+	// Timestamp the operation was created
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+	// Timestamp of the latest status change
+	ModifiedAt *time.Time `json:"modifiedAt,omitempty"`
+}
+
+// AssertOutboxOperationRequired checks if the required fields are not zero-ed
+func AssertOutboxOperationRequired(obj OutboxOperation) error {
+	return nil
+}
+
+// AssertOutboxOperationConstraints checks if the values respects the defined constraints
+func AssertOutboxOperationConstraints(obj OutboxOperation) error {
+	return nil
+}
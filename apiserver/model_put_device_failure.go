@@ -0,0 +1,34 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+package apiserver
+
+// PutDeviceFailure - Failed creation or update of a LoRaWAN device. All applied steps are rolled back.
+type PutDeviceFailure struct {
+
+	// Error causing the rollback
+	Error string `json:"error,omitempty"`
+
+	// Steps performed, in order, with their final outcome
+	Steps []PutDeviceStep `json:"steps,omitempty"`
+}
+
+// AssertPutDeviceFailureRequired checks if the required fields are not zero-ed
+func AssertPutDeviceFailureRequired(obj PutDeviceFailure) error {
+	for _, el := range obj.Steps {
+		if err := AssertPutDeviceStepRequired(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertPutDeviceFailureConstraints checks if the values respects the defined constraints
+func AssertPutDeviceFailureConstraints(obj PutDeviceFailure) error {
+	return nil
+}
@@ -0,0 +1,34 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+package apiserver
+
+// PutDevicePlan - Planned creation or update of a LoRaWAN device. Nothing is changed.
+type PutDevicePlan struct {
+
+	// Activation mode derived from the keys of the request: otaa10, otaa11, abp10 or abp11
+	Activation string `json:"activation,omitempty"`
+
+	// Steps that would be performed, in order, with their planned outcome
+	Steps []PutDeviceStep `json:"steps,omitempty"`
+}
+
+// AssertPutDevicePlanRequired checks if the required fields are not zero-ed
+func AssertPutDevicePlanRequired(obj PutDevicePlan) error {
+	for _, el := range obj.Steps {
+		if err := AssertPutDeviceStepRequired(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertPutDevicePlanConstraints checks if the values respects the defined constraints
+func AssertPutDevicePlanConstraints(obj PutDevicePlan) error {
+	return nil
+}
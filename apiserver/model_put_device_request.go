@@ -12,7 +12,7 @@ package apiserver
 type PutDeviceRequest struct {
 
 	// Global ID in IEEE EUI64 address space that uniquely identifies the device
-	DevEUI string `json:"devEUI"`
+	DevEUI string `json:"devEUI,omitempty"`
 
 	// Application hexadecimal (uppercase) ID for Loriot
 	AppID string `json:"appID"`
@@ -29,6 +29,15 @@ type PutDeviceRequest struct {
 	// Description for the new device and asset
 	Description string `json:"description,omitempty"`
 
+	// Name of the payload decoder for uplinks of the device. If empty the decoder registered for the asset type is used.
+	Decoder string `json:"decoder,omitempty"`
+
+	// Generate the device EUI, if empty, within the device EUI block of the configuration and the keys of the activation mode left empty with cryptographically random values. The keys are only revealed by the key vault.
+	GenerateKeys bool `json:"generateKeys,omitempty"`
+
+	// Expected interval in seconds between uplinks of the device. If empty the reporting interval configured for the asset type is used.
+	ReportingInterval *int32 `json:"reportingInterval,omitempty"`
+
 	AppEUI string `json:"appEUI,omitempty"`
 
 	AppKey string `json:"appKey,omitempty"`
@@ -65,7 +74,6 @@ type PutDeviceRequest struct {
 // AssertPutDeviceRequestRequired checks if the required fields are not zero-ed
 func AssertPutDeviceRequestRequired(obj PutDeviceRequest) error {
 	elements := map[string]interface{}{
-		"devEUI":        obj.DevEUI,
 		"appID":         obj.AppID,
 		"assetTypeName": obj.AssetTypeName,
 	}
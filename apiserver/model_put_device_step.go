@@ -0,0 +1,41 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+package apiserver
+
+// PutDeviceStep - Step of creating or updating a LoRaWAN device and its assets
+type PutDeviceStep struct {
+
+	// Configuration defining the Loriot.io target of the step
+	ConfigID *int64 `json:"configID,omitempty"`
+
+	// Eliona project ID of the asset, if the step belongs to a project
+	ProjectID *string `json:"projectID,omitempty"`
+
+	// Step performed: loriot_device, root_asset, device_asset or app_asset
+	Step string `json:"step,omitempty"`
+
+	// Outcome of the step: created, updated, unchanged, failed, compensated or compensation_failed
+	Status string `json:"status,omitempty"`
+
+	// ID of the Eliona asset, if the step belongs to an asset
+	AssetID *int32 `json:"assetID,omitempty"`
+
+	// Error of the failed step or compensation
+	Error *string `json:"error,omitempty"`
+}
+
+// AssertPutDeviceStepRequired checks if the required fields are not zero-ed
+func AssertPutDeviceStepRequired(obj PutDeviceStep) error {
+	return nil
+}
+
+// AssertPutDeviceStepConstraints checks if the values respects the defined constraints
+func AssertPutDeviceStepConstraints(obj PutDeviceStep) error {
+	return nil
+}
@@ -0,0 +1,51 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+package apiserver
+
+type QrCodeRequest struct {
+
+	// Content of the device QR code in the LoRa Alliance TR005 format
+	QrCode string `json:"qrCode"`
+
+	// Application hexadecimal (uppercase) ID for Loriot
+	AppID string `json:"appID"`
+
+	// Name of the asset type to create corresponding asset in Eliona
+	AssetTypeName string `json:"assetTypeName"`
+
+	// Configuration id to define the target Loriot.io. If empty all configs are used.
+	ConfigID *int32 `json:"configID,omitempty"`
+
+	// Title for the new device and asset. If empty the serial number of the QR code or the device EUI is used.
+	Title string `json:"title,omitempty"`
+
+	// Description for the new device and asset
+	Description string `json:"description,omitempty"`
+}
+
+// AssertQrCodeRequestRequired checks if the required fields are not zero-ed
+func AssertQrCodeRequestRequired(obj QrCodeRequest) error {
+	elements := map[string]interface{}{
+		"qrCode":        obj.QrCode,
+		"appID":         obj.AppID,
+		"assetTypeName": obj.AssetTypeName,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertQrCodeRequestConstraints checks if the values respects the defined constraints
+func AssertQrCodeRequestConstraints(obj QrCodeRequest) error {
+	return nil
+}
@@ -0,0 +1,32 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+package apiserver
+
+// QueuedDownlink - Downlink waiting in the Loriot.io downlink queue of a device
+type QueuedDownlink struct {
+
+	// LoRaWAN port of the downlink
+	FPort int32 `json:"fPort,omitempty"`
+
+	// Whether the device has to acknowledge the downlink
+	Confirmed bool `json:"confirmed,omitempty"`
+
+	// Hex encoded payload
+	Payload string `json:"payload,omitempty"`
+}
+
+// AssertQueuedDownlinkRequired checks if the required fields are not zero-ed
+func AssertQueuedDownlinkRequired(obj QueuedDownlink) error {
+	return nil
+}
+
+// AssertQueuedDownlinkConstraints checks if the values respects the defined constraints
+func AssertQueuedDownlinkConstraints(obj QueuedDownlink) error {
+	return nil
+}
@@ -0,0 +1,47 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+package apiserver
+
+// RoutingRule - Routes the devices matching all given criteria to projects of the configuration. A rule without criteria matches all devices.
+type RoutingRule struct {
+
+	// Loriot.io application ID the device belongs to
+	AppID *string `json:"appID,omitempty"`
+
+	// Hexadecimal prefix of the device EUI
+	DevEUIPrefix *string `json:"devEUIPrefix,omitempty"`
+
+	// Pattern the device title has to match. `*` matches any text and `?` any single character, case-insensitive.
+	TitlePattern *string `json:"titlePattern,omitempty"`
+
+	// IDs of the Eliona projects the device assets are created in. Must be projects of the configuration.
+	ProjectIDs []string `json:"projectIDs"`
+
+	// Asset type of the Eliona assets created for discovered devices. Without asset type, the default asset type of the application is used.
+	AssetType *string `json:"assetType,omitempty"`
+}
+
+// AssertRoutingRuleRequired checks if the required fields are not zero-ed
+func AssertRoutingRuleRequired(obj RoutingRule) error {
+	elements := map[string]interface{}{
+		"projectIDs": obj.ProjectIDs,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertRoutingRuleConstraints checks if the values respects the defined constraints
+func AssertRoutingRuleConstraints(obj RoutingRule) error {
+	return nil
+}
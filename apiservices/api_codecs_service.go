@@ -0,0 +1,110 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiservices
+
+import (
+	"context"
+	"errors"
+	"loriot-io/apiserver"
+	"loriot-io/app"
+	"net/http"
+)
+
+// CodecsAPIService is a service that implements the logic for the CodecsAPIServicer
+// This service should implement the business logic for every endpoint for the CodecsAPI API.
+// Include any external packages or services that will be required by this service.
+type CodecsAPIService struct {
+}
+
+// NewCodecsAPIService creates a default api service
+func NewCodecsAPIService() apiserver.CodecsAPIServicer {
+	return &CodecsAPIService{}
+}
+
+// GetCodecs - Get codecs
+func (s *CodecsAPIService) GetCodecs(ctx context.Context) (apiserver.ImplResponse, error) {
+	codecs, err := app.GetCodecs(ctx)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, codecs), nil
+}
+
+// PostCodec - Creates a codec
+func (s *CodecsAPIService) PostCodec(ctx context.Context, codec apiserver.Codec) (apiserver.ImplResponse, error) {
+	insertedCodec, err := app.InsertCodec(ctx, codec)
+	if errors.Is(err, app.ErrBadRequest) {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusCreated, insertedCodec), nil
+}
+
+// GetCodecById - Get codec
+func (s *CodecsAPIService) GetCodecById(ctx context.Context, codecId int64) (apiserver.ImplResponse, error) {
+	codec, err := app.GetCodec(ctx, codecId)
+	if errors.Is(err, app.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, err
+	}
+	if errors.Is(err, app.ErrBadRequest) {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, nil
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, codec), nil
+}
+
+// PutCodecById - Updates a codec
+func (s *CodecsAPIService) PutCodecById(ctx context.Context, codecId int64, codec apiserver.Codec) (apiserver.ImplResponse, error) {
+	updatedCodec, err := app.UpdateCodec(ctx, codecId, codec)
+	if errors.Is(err, app.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, err
+	}
+	if errors.Is(err, app.ErrBadRequest) {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, updatedCodec), nil
+}
+
+// DeleteCodecById - Deletes a codec
+func (s *CodecsAPIService) DeleteCodecById(ctx context.Context, codecId int64) (apiserver.ImplResponse, error) {
+	err := app.DeleteCodec(ctx, codecId)
+	if errors.Is(err, app.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, err
+	}
+	if errors.Is(err, app.ErrBadRequest) {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, nil
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.ImplResponse{Code: http.StatusNoContent}, nil
+}
+
+// TestCodecById - Tests a codec
+func (s *CodecsAPIService) TestCodecById(ctx context.Context, codecId int64, codecTestRequest apiserver.CodecTestRequest) (apiserver.ImplResponse, error) {
+	result, err := app.TestCodec(ctx, codecId, codecTestRequest)
+	if errors.Is(err, app.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, err
+	}
+	if errors.Is(err, app.ErrBadRequest) {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, result), nil
+}
@@ -18,9 +18,13 @@ package apiservices
 import (
 	"context"
 	"errors"
+	"fmt"
 	"loriot-io/apiserver"
 	"loriot-io/app"
+	"loriot-io/broker"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
 // ConfigurationApiService is a service that implements the logic for the ConfigurationApiServicer
@@ -39,44 +43,153 @@ func (s *ConfigurationApiService) GetConfigurations(ctx context.Context) (apiser
 	if err != nil {
 		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
 	}
+	for i := range configs {
+		configs[i] = app.RedactConfig(configs[i])
+	}
 	return apiserver.Response(http.StatusOK, configs), nil
 }
 
 func (s *ConfigurationApiService) PostConfiguration(ctx context.Context, config apiserver.Configuration) (apiserver.ImplResponse, error) {
+	if response, err := validateConfig(ctx, config); response != nil || err != nil {
+		return *response, err
+	}
 	insertedConfig, err := app.InsertConfig(ctx, config)
 	if err != nil {
-		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+		return configErrorResponse(err)
 	}
-	return apiserver.Response(http.StatusCreated, insertedConfig), nil
+	broker.SyncConfigWorkers()
+	return apiserver.Response(http.StatusCreated, app.RedactConfig(insertedConfig)), nil
 }
 
 func (s *ConfigurationApiService) GetConfigurationById(ctx context.Context, configId int64) (apiserver.ImplResponse, error) {
 	config, err := app.GetConfig(ctx, configId)
-	if errors.Is(err, app.ErrBadRequest) {
-		return apiserver.ImplResponse{Code: http.StatusBadRequest}, nil
-	}
 	if err != nil {
-		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+		return configErrorResponse(err)
 	}
-	return apiserver.Response(http.StatusOK, config), nil
+	return apiserver.Response(http.StatusOK, app.RedactConfig(*config)), nil
 }
 
-func (s *ConfigurationApiService) PutConfigurationById(ctx context.Context, configId int64, config apiserver.Configuration) (apiserver.ImplResponse, error) {
+func (s *ConfigurationApiService) PutConfigurationById(ctx context.Context, configId int64, ifMatch string, config apiserver.Configuration) (apiserver.ImplResponse, error) {
+	version, err := parseIfMatch(ifMatch)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	}
+	if version == nil {
+		version = config.Version
+	}
 	config.Id = &configId
-	upsertedConfig, err := app.UpsertConfig(ctx, config)
+	return updateConfig(ctx, config, version)
+}
+
+func (s *ConfigurationApiService) PatchConfigurationById(ctx context.Context, configId int64, ifMatch string, patch map[string]interface{}) (apiserver.ImplResponse, error) {
+	version, err := parseIfMatch(ifMatch)
 	if err != nil {
-		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	}
+	config, patchedVersion, err := app.PatchConfig(ctx, configId, patch)
+	if err != nil {
+		return configErrorResponse(err)
+	}
+	if version != nil && *version != patchedVersion {
+		return apiserver.ImplResponse{Code: http.StatusConflict}, fmt.Errorf("%w: config %d has version %d, not %d", app.ErrConflict, configId, patchedVersion, *version)
 	}
-	return apiserver.Response(http.StatusCreated, upsertedConfig), nil
+	// The patch is based on this version, so a concurrent update in between is detected as conflict
+	return updateConfig(ctx, config, &patchedVersion)
 }
 
 func (s *ConfigurationApiService) DeleteConfigurationById(ctx context.Context, configId int64) (apiserver.ImplResponse, error) {
 	err := app.DeleteConfig(ctx, configId)
-	if errors.Is(err, app.ErrBadRequest) {
-		return apiserver.ImplResponse{Code: http.StatusBadRequest}, nil
+	if err != nil {
+		return configErrorResponse(err)
+	}
+	broker.SyncConfigWorkers()
+	return apiserver.ImplResponse{Code: http.StatusNoContent}, nil
+}
+
+func (s *ConfigurationApiService) GetConfigurationDriftById(ctx context.Context, configId int64) (apiserver.ImplResponse, error) {
+	config, err := app.GetConfig(ctx, configId)
+	if err != nil {
+		return configErrorResponse(err)
 	}
+	report, err := broker.GetDrift(ctx, *config)
 	if err != nil {
 		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
 	}
-	return apiserver.ImplResponse{Code: http.StatusNoContent}, nil
+	return apiserver.Response(http.StatusOK, report), nil
+}
+
+func (s *ConfigurationApiService) ReconcileConfigurationById(ctx context.Context, configId int64) (apiserver.ImplResponse, error) {
+	config, err := app.GetConfig(ctx, configId)
+	if err != nil {
+		return configErrorResponse(err)
+	}
+	report, err := broker.Reconcile(ctx, *config)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, report), nil
+}
+
+func (s *ConfigurationApiService) TestConfigurationById(ctx context.Context, configId int64) (apiserver.ImplResponse, error) {
+	result, err := broker.TestConfig(ctx, configId)
+	if err != nil {
+		return configErrorResponse(err)
+	}
+	return apiserver.Response(http.StatusOK, result), nil
+}
+
+// updateConfig validates and saves the configuration, if it still has the version.
+func updateConfig(ctx context.Context, config apiserver.Configuration, version *int32) (apiserver.ImplResponse, error) {
+	if response, err := validateConfig(ctx, config); response != nil || err != nil {
+		return *response, err
+	}
+	updatedConfig, err := app.UpdateConfig(ctx, config, version)
+	if err != nil {
+		return configErrorResponse(err)
+	}
+	broker.SyncConfigWorkers()
+	return apiserver.Response(http.StatusOK, app.RedactConfig(updatedConfig)), nil
+}
+
+// validateConfig tests the connection of the configuration before it is saved. Returns the response to send instead
+// of saving the configuration, or nil if the configuration can be saved.
+func validateConfig(ctx context.Context, config apiserver.Configuration) (*apiserver.ImplResponse, error) {
+	result, err := broker.ValidateConfig(ctx, config)
+	if err != nil {
+		response, err := configErrorResponse(err)
+		return &response, err
+	}
+	if result != nil && !result.Ok {
+		return &apiserver.ImplResponse{Code: http.StatusUnprocessableEntity, Body: result}, nil
+	}
+	return nil, nil
+}
+
+// configErrorResponse returns the status code of the error: 404 for unknown configurations, 409 for version
+// conflicts, 400 for invalid requests and 500 otherwise.
+func configErrorResponse(err error) (apiserver.ImplResponse, error) {
+	switch {
+	case errors.Is(err, app.ErrNotFound):
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, err
+	case errors.Is(err, app.ErrConflict):
+		return apiserver.ImplResponse{Code: http.StatusConflict}, err
+	case errors.Is(err, app.ErrBadRequest):
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	default:
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+}
+
+// parseIfMatch returns the version required by the If-Match header, or nil if the header is empty or matches any
+// version.
+func parseIfMatch(ifMatch string) (*int32, error) {
+	ifMatch = strings.TrimSpace(ifMatch)
+	if ifMatch == "" || ifMatch == "*" {
+		return nil, nil
+	}
+	version, err := strconv.ParseInt(strings.Trim(strings.TrimPrefix(ifMatch, "W/"), `"`), 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid If-Match header '%s'", app.ErrBadRequest, ifMatch)
+	}
+	return func(v int32) *int32 { return &v }(int32(version)), nil
 }
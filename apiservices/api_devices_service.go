@@ -11,10 +11,13 @@ package apiservices
 
 import (
 	"context"
+	"errors"
 	"loriot-io/apiserver"
 	"loriot-io/app"
 	"loriot-io/broker"
+	"loriot-io/migration"
 	"net/http"
+	"os"
 )
 
 // DevicesAPIService is a service that implements the logic for the DevicesAPIServicer
@@ -28,6 +31,24 @@ func NewDevicesAPIService() apiserver.DevicesAPIServicer {
 	return &DevicesAPIService{}
 }
 
+// ExportDevices - Export LoRaWAN devices. The route is served by ExportDevicesHandler, which streams the export
+// instead of returning it as a whole.
+func (s *DevicesAPIService) ExportDevices(ctx context.Context, format string, configId int64, projectId string, appId string, includeKeys bool) (apiserver.ImplResponse, error) {
+	return apiserver.ImplResponse{Code: http.StatusNotImplemented}, errors.New("devices are exported by the streaming export handler")
+}
+
+// GetDeviceKeys - Reveal the keys of a LoRaWAN device
+func (s *DevicesAPIService) GetDeviceKeys(ctx context.Context, devEui string) (apiserver.ImplResponse, error) {
+	keys, err := app.RevealDeviceKeys(ctx, devEui)
+	if errors.Is(err, app.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, err
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, keys), nil
+}
+
 // GetDevices - Get LoRaWAN devices
 func (s *DevicesAPIService) GetDevices(ctx context.Context) (apiserver.ImplResponse, error) {
 	devices, err := app.GetDeviceAssets(ctx)
@@ -37,14 +58,92 @@ func (s *DevicesAPIService) GetDevices(ctx context.Context) (apiserver.ImplRespo
 	return apiserver.Response(http.StatusOK, devices), err
 }
 
-// PutDevice - Create or update a LoRaWAN device
-func (s *DevicesAPIService) PutDevice(ctx context.Context, putDeviceRequest apiserver.PutDeviceRequest) (apiserver.ImplResponse, error) {
-	deviceAssets, err := broker.UpsertDevice(ctx, putDeviceRequest)
+// ImportDevices - Import LoRaWAN devices
+func (s *DevicesAPIService) ImportDevices(ctx context.Context, file *os.File) (apiserver.ImplResponse, error) {
+	defer file.Close()
+	job, err := broker.ImportDevices(ctx, file)
+	if errors.Is(err, app.ErrBadRequest) {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusAccepted, job), nil
+}
+
+// MigrateDevices - Migrate LoRaWAN devices from another network server
+func (s *DevicesAPIService) MigrateDevices(ctx context.Context, source string, configId int64, appId string, assetTypeName string, file *os.File, projectId string) (apiserver.ImplResponse, error) {
+	defer file.Close()
+	target := broker.MigrationTarget{
+		Target: migration.Target{
+			ConfigID:      configId,
+			AppID:         appId,
+			AssetTypeName: assetTypeName,
+		},
+		ProjectID: projectId,
+	}
+	report, err := broker.MigrateDevices(ctx, source, file, target)
+	if errors.Is(err, app.ErrBadRequest) {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, report), nil
+}
+
+// ProvisionDeviceByQrCode - Create or update a LoRaWAN device from its TR005 QR code
+func (s *DevicesAPIService) ProvisionDeviceByQrCode(ctx context.Context, qrCodeRequest apiserver.QrCodeRequest) (apiserver.ImplResponse, error) {
+	deviceAssets, steps, err := broker.ProvisionQRCode(ctx, qrCodeRequest)
+	if errors.Is(err, app.ErrBadRequest) {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	}
+	if errors.Is(err, app.ErrConflict) {
+		return apiserver.ImplResponse{Code: http.StatusConflict}, err
+	}
+	if err != nil {
+		return apiserver.Response(http.StatusInternalServerError, apiserver.PutDeviceFailure{
+			Error: err.Error(),
+			Steps: steps,
+		}), nil
+	}
 	if deviceAssets == nil {
-		return apiserver.Response(http.StatusBadRequest, nil), err
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, nil
+	}
+	return apiserver.Response(http.StatusOK, deviceAssets), nil
+}
+
+// PutDevice - Create or update a LoRaWAN device
+func (s *DevicesAPIService) PutDevice(ctx context.Context, dryRun bool, idempotencyKey string, putDeviceRequest apiserver.PutDeviceRequest) (apiserver.ImplResponse, error) {
+	if dryRun {
+		plan, err := broker.PlanDevice(ctx, putDeviceRequest)
+		if errors.Is(err, app.ErrBadRequest) {
+			return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+		}
+		if errors.Is(err, app.ErrConflict) {
+			return apiserver.ImplResponse{Code: http.StatusConflict}, err
+		}
+		if err != nil {
+			return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+		}
+		return apiserver.Response(http.StatusOK, plan), nil
+	}
+	deviceAssets, steps, err := broker.UpsertDeviceIdempotent(ctx, idempotencyKey, putDeviceRequest)
+	if errors.Is(err, app.ErrBadRequest) {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	}
+	if errors.Is(err, app.ErrConflict) {
+		return apiserver.ImplResponse{Code: http.StatusConflict}, err
 	}
 	if err != nil {
-		return apiserver.Response(http.StatusInternalServerError, deviceAssets), err
+		// The steps already applied are rolled back. Report the outcome of each step to the caller.
+		return apiserver.Response(http.StatusInternalServerError, apiserver.PutDeviceFailure{
+			Error: err.Error(),
+			Steps: steps,
+		}), nil
+	}
+	if deviceAssets == nil {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, nil
 	}
-	return apiserver.Response(http.StatusOK, deviceAssets), err
+	return apiserver.Response(http.StatusOK, deviceAssets), nil
 }
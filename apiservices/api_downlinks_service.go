@@ -0,0 +1,74 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+package apiservices
+
+import (
+	"context"
+	"errors"
+	"loriot-io/apiserver"
+	"loriot-io/app"
+	"loriot-io/broker"
+	"net/http"
+)
+
+// DownlinksAPIService is a service that implements the logic for the DownlinksAPIServicer
+// This service should implement the business logic for every endpoint for the DownlinksAPI API.
+// Include any external packages or services that will be required by this service.
+type DownlinksAPIService struct {
+}
+
+// NewDownlinksAPIService creates a default api service
+func NewDownlinksAPIService() apiserver.DownlinksAPIServicer {
+	return &DownlinksAPIService{}
+}
+
+// GetDownlinks - Get downlinks
+func (s *DownlinksAPIService) GetDownlinks(ctx context.Context) (apiserver.ImplResponse, error) {
+	downlinks, err := app.GetDownlinks(ctx)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, downlinks), nil
+}
+
+// GetDeviceDownlinks - Get the downlink queue of a device
+func (s *DownlinksAPIService) GetDeviceDownlinks(ctx context.Context, devEui string) (apiserver.ImplResponse, error) {
+	queue, err := broker.GetDeviceDownlinkQueue(ctx, devEui)
+	if errors.Is(err, app.ErrBadRequest) {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, queue), nil
+}
+
+// PostDeviceDownlink - Enqueue a downlink for a device
+func (s *DownlinksAPIService) PostDeviceDownlink(ctx context.Context, devEui string, newDownlink apiserver.NewDownlink) (apiserver.ImplResponse, error) {
+	downlink, err := broker.EnqueueDeviceDownlink(ctx, devEui, newDownlink)
+	if errors.Is(err, app.ErrBadRequest) {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusCreated, downlink), nil
+}
+
+// DeleteDeviceDownlinks - Flush the downlink queue of a device
+func (s *DownlinksAPIService) DeleteDeviceDownlinks(ctx context.Context, devEui string) (apiserver.ImplResponse, error) {
+	err := broker.FlushDeviceDownlinkQueue(ctx, devEui)
+	if errors.Is(err, app.ErrBadRequest) {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.ImplResponse{Code: http.StatusNoContent}, nil
+}
@@ -0,0 +1,43 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+package apiservices
+
+import (
+	"context"
+	"errors"
+	"loriot-io/apiserver"
+	"loriot-io/app"
+	"net/http"
+)
+
+// JobsAPIService is a service that implements the logic for the JobsAPIServicer
+// This service should implement the business logic for every endpoint for the JobsAPI API.
+// Include any external packages or services that will be required by this service.
+type JobsAPIService struct {
+}
+
+// NewJobsAPIService creates a default api service
+func NewJobsAPIService() apiserver.JobsAPIServicer {
+	return &JobsAPIService{}
+}
+
+// GetJobById - Get job
+func (s *JobsAPIService) GetJobById(ctx context.Context, jobId int64) (apiserver.ImplResponse, error) {
+	job, err := app.GetJob(ctx, jobId)
+	if errors.Is(err, app.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, err
+	}
+	if errors.Is(err, app.ErrBadRequest) {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, job), nil
+}
@@ -0,0 +1,64 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+package apiservices
+
+import (
+	"context"
+	"errors"
+	"loriot-io/apiserver"
+	"loriot-io/app"
+	"loriot-io/broker"
+	"net/http"
+	"os"
+)
+
+// KeyBatchesAPIService is a service that implements the logic for the KeyBatchesAPIServicer
+// This service should implement the business logic for every endpoint for the KeyBatchesAPI API.
+// Include any external packages or services that will be required by this service.
+type KeyBatchesAPIService struct {
+}
+
+// NewKeyBatchesAPIService creates a default api service
+func NewKeyBatchesAPIService() apiserver.KeyBatchesAPIServicer {
+	return &KeyBatchesAPIService{}
+}
+
+// DeleteKeyBatchById - Delete key batch
+func (s *KeyBatchesAPIService) DeleteKeyBatchById(ctx context.Context, batchId int64) (apiserver.ImplResponse, error) {
+	err := app.DeleteKeyBatch(ctx, batchId)
+	if errors.Is(err, app.ErrBadRequest) {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.ImplResponse{Code: http.StatusNoContent}, nil
+}
+
+// GetKeyBatches - Get key batches
+func (s *KeyBatchesAPIService) GetKeyBatches(ctx context.Context) (apiserver.ImplResponse, error) {
+	batches, err := app.GetKeyBatches(ctx)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, batches), nil
+}
+
+// PostKeyBatch - Load key batch
+func (s *KeyBatchesAPIService) PostKeyBatch(ctx context.Context, name string, file *os.File) (apiserver.ImplResponse, error) {
+	defer file.Close()
+	batch, err := broker.LoadKeyBatch(ctx, name, file)
+	if errors.Is(err, app.ErrBadRequest) {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusCreated, batch), nil
+}
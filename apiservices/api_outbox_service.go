@@ -0,0 +1,67 @@
+/*
+ * Loriot.io app API
+ *
+ * API to access and configure the Loriot.io app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+package apiservices
+
+import (
+	"context"
+	"errors"
+	"loriot-io/apiserver"
+	"loriot-io/app"
+	"net/http"
+)
+
+// OutboxAPIService is a service that implements the logic for the OutboxAPIServicer
+// This service should implement the business logic for every endpoint for the OutboxAPI API.
+// Include any external packages or services that will be required by this service.
+type OutboxAPIService struct {
+}
+
+// NewOutboxAPIService creates a default api service
+func NewOutboxAPIService() apiserver.OutboxAPIServicer {
+	return &OutboxAPIService{}
+}
+
+// GetOutboxOperations - Get outbox operations
+func (s *OutboxAPIService) GetOutboxOperations(ctx context.Context, status string) (apiserver.ImplResponse, error) {
+	operations, err := app.GetOutboxOperations(ctx, status)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, operations), nil
+}
+
+// RetryOutboxOperation - Retry an outbox operation
+func (s *OutboxAPIService) RetryOutboxOperation(ctx context.Context, operationId int64) (apiserver.ImplResponse, error) {
+	operation, err := app.RetryOutboxOperation(ctx, operationId)
+	if errors.Is(err, app.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, err
+	}
+	if errors.Is(err, app.ErrBadRequest) {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, operation), nil
+}
+
+// DiscardOutboxOperation - Discard an outbox operation
+func (s *OutboxAPIService) DiscardOutboxOperation(ctx context.Context, operationId int64) (apiserver.ImplResponse, error) {
+	err := app.DiscardOutboxOperation(ctx, operationId)
+	if errors.Is(err, app.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, err
+	}
+	if errors.Is(err, app.ErrBadRequest) {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.ImplResponse{Code: http.StatusNoContent}, nil
+}
@@ -0,0 +1,73 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package apiservices
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// configETagRoutes are the routes responding with a single configuration.
+var configETagRoutes = map[string]bool{
+	"GetConfigurationById":   true,
+	"PostConfiguration":      true,
+	"PutConfigurationById":   true,
+	"PatchConfigurationById": true,
+}
+
+// configETagMiddleware sets the ETag header of responses with a single configuration to the version of the
+// configuration, to be sent back as If-Match header on updates. The generated controllers cannot set headers, so the
+// response is buffered until the version is known.
+func configETagMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := mux.CurrentRoute(r)
+		if route == nil || !configETagRoutes[route.GetName()] {
+			next.ServeHTTP(w, r)
+			return
+		}
+		response := &bufferedResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(response, r)
+		if response.status >= 200 && response.status < 300 {
+			var config struct {
+				Version *int32 `json:"version"`
+			}
+			if err := json.Unmarshal(response.body.Bytes(), &config); err == nil && config.Version != nil {
+				w.Header().Set("ETag", fmt.Sprintf(`"%d"`, *config.Version))
+			}
+		}
+		w.WriteHeader(response.status)
+		_, _ = w.Write(response.body.Bytes())
+	})
+}
+
+// bufferedResponseWriter keeps the status and body of the response to write them later.
+type bufferedResponseWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *bufferedResponseWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
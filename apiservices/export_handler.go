@@ -0,0 +1,108 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package apiservices
+
+import (
+	"fmt"
+	"loriot-io/apiserver"
+	"loriot-io/broker"
+	"net/http"
+	"strconv"
+
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// ExportDevicesHandler streams the device export to the client. It replaces the handler of the generated
+// ExportDevices route, which would encode the whole export in memory before responding.
+func ExportDevicesHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := broker.DeviceExportFilter{
+		ProjectID: query.Get("projectId"),
+		AppID:     query.Get("appId"),
+	}
+	if query.Has("configId") {
+		configID, err := strconv.ParseInt(query.Get("configId"), 10, 64)
+		if err != nil {
+			apiserver.DefaultErrorHandler(w, r, &apiserver.ParsingError{Err: err}, nil)
+			return
+		}
+		filter.ConfigID = configID
+	}
+	if query.Has("includeKeys") {
+		includeKeys, err := strconv.ParseBool(query.Get("includeKeys"))
+		if err != nil {
+			apiserver.DefaultErrorHandler(w, r, &apiserver.ParsingError{Err: err}, nil)
+			return
+		}
+		filter.IncludeKeys = includeKeys
+	}
+
+	format := query.Get("format")
+	if format == "" {
+		format = broker.ExportFormatJson
+	}
+	response := &exportResponseWriter{ResponseWriter: w}
+	var err error
+	switch format {
+	case broker.ExportFormatJson:
+		response.contentType = "application/json; charset=UTF-8"
+		err = broker.ExportDevicesJson(r.Context(), filter, response)
+	case broker.ExportFormatCsv:
+		response.contentType = "text/csv; charset=UTF-8"
+		response.filename = "devices.csv"
+		err = broker.ExportDevicesCsv(r.Context(), filter, response)
+	default:
+		apiserver.DefaultErrorHandler(w, r, fmt.Errorf("unknown export format: %s", format), &apiserver.ImplResponse{Code: http.StatusBadRequest})
+		return
+	}
+	if err == nil {
+		return
+	}
+	if !response.started {
+		apiserver.DefaultErrorHandler(w, r, err, &apiserver.ImplResponse{Code: http.StatusInternalServerError})
+		return
+	}
+	// The status is already sent, abort the response so that the client notices the incomplete export
+	log.Error("app", "Error during device export: %v", err)
+	panic(http.ErrAbortHandler)
+}
+
+// exportResponseWriter sends the response headers with the first written page of the export, so that errors before
+// can still be answered with an error status.
+type exportResponseWriter struct {
+	http.ResponseWriter
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (w *exportResponseWriter) Write(data []byte) (int, error) {
+	if !w.started {
+		w.Header().Set("Content-Type", w.contentType)
+		if w.filename != "" {
+			w.Header().Set("Content-Disposition", "attachment; filename="+w.filename)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.started = true
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *exportResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
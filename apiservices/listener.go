@@ -26,13 +26,20 @@ import (
 
 // ListenApi starts the API server and listen for requests
 func ListenApi() {
+	router := apiserver.NewRouter(
+		apiserver.NewCodecsAPIController(NewCodecsAPIService()),
+		apiserver.NewDevicesAPIController(NewDevicesAPIService()),
+		apiserver.NewDownlinksAPIController(NewDownlinksAPIService()),
+		apiserver.NewJobsAPIController(NewJobsAPIService()),
+		apiserver.NewKeyBatchesAPIController(NewKeyBatchesAPIService()),
+		apiserver.NewOutboxAPIController(NewOutboxAPIService()),
+		apiserver.NewConfigurationAPIController(NewConfigurationApiService()),
+		apiserver.NewVersionAPIController(NewVersionApiService()),
+	)
+	router.Use(configETagMiddleware)
+	router.Get("ExportDevices").Handler(apiserver.Logger(http.HandlerFunc(ExportDevicesHandler), "ExportDevices"))
 	err := http.ListenAndServe(":"+common.Getenv("API_SERVER_PORT", "3000"),
 		frontend.NewEnvironmentHandler(
-			utilshttp.NewCORSEnabledHandler(
-				apiserver.NewRouter(
-					apiserver.NewDevicesAPIController(NewDevicesAPIService()),
-					apiserver.NewConfigurationAPIController(NewConfigurationApiService()),
-					apiserver.NewVersionAPIController(NewVersionApiService()),
-				))))
+			utilshttp.NewCORSEnabledHandler(router)))
 	log.Fatal("main", "API server: %v", err)
 }
@@ -17,17 +17,21 @@ package app
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
 	"loriot-io/apiserver"
 	"loriot-io/appdb"
 	"loriot-io/loriot"
 	http2 "net/http"
+	"strings"
 	"time"
 
 	"github.com/eliona-smart-building-assistant/go-utils/common"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
 func GetDeviceAssets(ctx context.Context) ([]apiserver.DeviceAsset, error) {
@@ -46,6 +50,14 @@ func GetDeviceAssets(ctx context.Context) ([]apiserver.DeviceAsset, error) {
 			AssetID:               dbAsset.AssetID,
 			LatestStatusCode:      dbAsset.LatestStatusCode.Ptr(),
 			ModifiedAt:            dbAsset.ModifiedAt.Ptr(),
+			AssetTypeName:         dbAsset.AssetType.Ptr(),
+			Decoder:               dbAsset.Decoder.Ptr(),
+			LastDecodingError:     dbAsset.LastDecodingError.Ptr(),
+			LastDecodingErrorAt:   dbAsset.LastDecodingErrorAt.Ptr(),
+			ReportingInterval:     dbAsset.ReportingInterval.Ptr(),
+			LastUplinkAt:          dbAsset.LastUplinkAt.Ptr(),
+			ConnectionState:       dbAsset.ConnectionState.Ptr(),
+			ConnectionStateSince:  dbAsset.ConnectionStateSince.Ptr(),
 		})
 	}
 	return deviceAssets, nil
@@ -67,7 +79,9 @@ func GetDbDeviceAssetById(assetId *int32) (*appdb.Asset, error) {
 	return dbDeviceAssets[0], nil
 }
 
-func UpsertDeviceAsset(ctx context.Context, config apiserver.Configuration, device loriot.Device, asset api.Asset, statusCode int32) (*apiserver.DeviceAsset, error) {
+// UpsertDeviceAsset remembers the asset of the device. The decoder and reporting interval of the request override the
+// ones of the asset type; if the request is nil already stored settings are kept.
+func UpsertDeviceAsset(ctx context.Context, config apiserver.Configuration, device loriot.Device, asset api.Asset, statusCode int32, request *apiserver.PutDeviceRequest) (*apiserver.DeviceAsset, error) {
 	var dbAsset appdb.Asset
 	if asset.Id.Get() == nil {
 		return nil, fmt.Errorf("no asset and no id present for %s", asset.AssetType)
@@ -80,7 +94,25 @@ func UpsertDeviceAsset(ctx context.Context, config apiserver.Configuration, devi
 	dbAsset.ConfigurationID = null.Int64FromPtr(config.Id).Int64
 	dbAsset.LatestStatusCode = null.Int32From(statusCode)
 	dbAsset.ModifiedAt = null.TimeFrom(time.Now())
-	err := dbAsset.UpsertG(ctx, true, []string{appdb.AssetColumns.AssetID}, boil.Blacklist(appdb.AssetColumns.AssetID), boil.Infer())
+	dbAsset.AssetType = null.NewString(asset.AssetType, asset.AssetType != "")
+	dbAsset.AssetName = null.StringFrom(assetName(asset))
+	dbAsset.AssetDescription = null.StringFrom(assetDescription(asset))
+	updateBlacklist := []string{
+		appdb.AssetColumns.AssetID,
+		appdb.AssetColumns.LastDecodingError,
+		appdb.AssetColumns.LastDecodingErrorAt,
+		appdb.AssetColumns.LastUplinkAt,
+		appdb.AssetColumns.ConnectionState,
+		appdb.AssetColumns.ConnectionStateSince,
+		appdb.AssetColumns.AlarmRuleID,
+	}
+	if request != nil {
+		dbAsset.Decoder = null.NewString(request.Decoder, request.Decoder != "")
+		dbAsset.ReportingInterval = null.Int32FromPtr(request.ReportingInterval)
+	} else {
+		updateBlacklist = append(updateBlacklist, appdb.AssetColumns.Decoder, appdb.AssetColumns.ReportingInterval)
+	}
+	err := dbAsset.UpsertG(ctx, true, []string{appdb.AssetColumns.AssetID}, boil.Blacklist(updateBlacklist...), boil.Infer())
 	if err != nil {
 		return nil, fmt.Errorf("error upserting asset %d device: %w", asset.Id.Get(), err)
 	}
@@ -93,5 +125,240 @@ func UpsertDeviceAsset(ctx context.Context, config apiserver.Configuration, devi
 		AssetID:               *asset.Id.Get(),
 		LatestStatusCode:      common.Ptr(statusCode),
 		ModifiedAt:            common.Ptr(time.Now()),
+		AssetTypeName:         dbAsset.AssetType.Ptr(),
+		Decoder:               dbAsset.Decoder.Ptr(),
+		ReportingInterval:     dbAsset.ReportingInterval.Ptr(),
 	}), nil
 }
+
+// GetDbDeviceAssetsByDevEUI returns all not deleted assets of the device with the given EUI within the configuration.
+func GetDbDeviceAssetsByDevEUI(ctx context.Context, configID int64, devEUI string) ([]*appdb.Asset, error) {
+	dbAssets, err := appdb.Assets(
+		appdb.AssetWhere.ConfigurationID.EQ(configID),
+		qm.Where("upper("+appdb.AssetColumns.DevEui+") = ?", strings.ToUpper(devEUI)),
+		appdb.AssetWhere.LatestStatusCode.NEQ(null.Int32From(http2.StatusNoContent)),
+	).AllG(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching assets for device %s: %v", devEUI, err)
+	}
+	return dbAssets, nil
+}
+
+// GetDbDeviceAssetByDevEUI returns a not deleted asset of the device with the given EUI in any configuration, or nil
+// if the device is unknown.
+func GetDbDeviceAssetByDevEUI(ctx context.Context, devEUI string) (*appdb.Asset, error) {
+	dbAsset, err := appdb.Assets(
+		qm.Where("upper("+appdb.AssetColumns.DevEui+") = ?", strings.ToUpper(devEUI)),
+		appdb.AssetWhere.LatestStatusCode.NEQ(null.Int32From(http2.StatusNoContent)),
+		qm.OrderBy(appdb.AssetColumns.ConfigurationID),
+	).OneG(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching asset for device %s: %v", devEUI, err)
+	}
+	return dbAsset, nil
+}
+
+// DeviceAssetExists checks if an asset of the device was ever created in the project for the configuration,
+// including assets deleted in Eliona.
+func DeviceAssetExists(ctx context.Context, configID int64, projectID string, devEUI string) (bool, error) {
+	exists, err := appdb.Assets(
+		appdb.AssetWhere.ConfigurationID.EQ(configID),
+		appdb.AssetWhere.ProjectID.EQ(projectID),
+		qm.Where("upper("+appdb.AssetColumns.DevEui+") = ?", strings.ToUpper(devEUI)),
+	).ExistsG(ctx)
+	if err != nil {
+		return false, fmt.Errorf("checking assets for device %s: %v", devEUI, err)
+	}
+	return exists, nil
+}
+
+// RecordDecodingError remembers the error of the latest failed payload decoding for the device asset.
+func RecordDecodingError(ctx context.Context, dbAsset *appdb.Asset, decodingErr error) error {
+	dbAsset.LastDecodingError = null.StringFrom(decodingErr.Error())
+	dbAsset.LastDecodingErrorAt = null.TimeFrom(time.Now())
+	_, err := dbAsset.UpdateG(ctx, boil.Whitelist(appdb.AssetColumns.LastDecodingError, appdb.AssetColumns.LastDecodingErrorAt))
+	if err != nil {
+		return fmt.Errorf("error recording decoding error for asset %d: %w", dbAsset.AssetID, err)
+	}
+	return nil
+}
+
+// RecordUplink remembers the time of the latest uplink of the device asset.
+func RecordUplink(ctx context.Context, dbAsset *appdb.Asset, receivedAt time.Time) error {
+	dbAsset.LastUplinkAt = null.TimeFrom(receivedAt)
+	_, err := dbAsset.UpdateG(ctx, boil.Whitelist(appdb.AssetColumns.LastUplinkAt))
+	if err != nil {
+		return fmt.Errorf("error recording uplink for asset %d: %w", dbAsset.AssetID, err)
+	}
+	return nil
+}
+
+// SetDeviceConnectionState remembers the connection state of the device asset and since when it is in this state.
+func SetDeviceConnectionState(ctx context.Context, dbAsset *appdb.Asset, state string, since time.Time) error {
+	dbAsset.ConnectionState = null.StringFrom(state)
+	dbAsset.ConnectionStateSince = null.TimeFrom(since)
+	_, err := dbAsset.UpdateG(ctx, boil.Whitelist(appdb.AssetColumns.ConnectionState, appdb.AssetColumns.ConnectionStateSince))
+	if err != nil {
+		return fmt.Errorf("error setting connection state for asset %d: %w", dbAsset.AssetID, err)
+	}
+	return nil
+}
+
+// SetDeviceAlarmRule remembers the alarm rule raising the offline alarm for the device asset.
+func SetDeviceAlarmRule(ctx context.Context, dbAsset *appdb.Asset, alarmRuleID int32) error {
+	dbAsset.AlarmRuleID = null.Int32From(alarmRuleID)
+	_, err := dbAsset.UpdateG(ctx, boil.Whitelist(appdb.AssetColumns.AlarmRuleID))
+	if err != nil {
+		return fmt.Errorf("error setting alarm rule for asset %d: %w", dbAsset.AssetID, err)
+	}
+	return nil
+}
+
+// GetDbDeviceAssets returns all assets of devices within the configuration, including assets deleted in Eliona.
+func GetDbDeviceAssets(ctx context.Context, configID int64) ([]*appdb.Asset, error) {
+	dbAssets, err := appdb.Assets(
+		appdb.AssetWhere.ConfigurationID.EQ(configID),
+		qm.OrderBy(appdb.AssetColumns.ModifiedAt+" desc nulls last"),
+	).AllG(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching assets for config %d: %v", configID, err)
+	}
+	return dbAssets, nil
+}
+
+// GetExportDbDeviceAssets returns the not deleted device assets ordered by configuration, project and device EUI.
+// Empty filters match all device assets.
+func GetExportDbDeviceAssets(ctx context.Context, configID int64, projectID string, appID string) ([]*appdb.Asset, error) {
+	mods := []qm.QueryMod{
+		appdb.AssetWhere.LatestStatusCode.NEQ(null.Int32From(http2.StatusNoContent)),
+		qm.OrderBy(appdb.AssetColumns.ConfigurationID + ", " + appdb.AssetColumns.ProjectID + ", " + appdb.AssetColumns.DevEui),
+	}
+	if configID != 0 {
+		mods = append(mods, appdb.AssetWhere.ConfigurationID.EQ(configID))
+	}
+	if projectID != "" {
+		mods = append(mods, appdb.AssetWhere.ProjectID.EQ(projectID))
+	}
+	if appID != "" {
+		mods = append(mods, qm.Where("upper("+appdb.AssetColumns.AppID+") = ?", strings.ToUpper(appID)))
+	}
+	dbAssets, err := appdb.Assets(mods...).AllG(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching assets for export: %v", err)
+	}
+	return dbAssets, nil
+}
+
+// SetDeviceAssetStatusCode remembers the latest action performed for the device asset, e.g. 204 if the asset or
+// device was deleted.
+func SetDeviceAssetStatusCode(ctx context.Context, dbAsset *appdb.Asset, statusCode int32) error {
+	dbAsset.LatestStatusCode = null.Int32From(statusCode)
+	dbAsset.ModifiedAt = null.TimeFrom(time.Now())
+	_, err := dbAsset.UpdateG(ctx, boil.Whitelist(appdb.AssetColumns.LatestStatusCode, appdb.AssetColumns.ModifiedAt))
+	if err != nil {
+		return fmt.Errorf("error setting status code for asset %d: %w", dbAsset.AssetID, err)
+	}
+	return nil
+}
+
+// ReplaceDeviceAsset moves the device asset to an asset re-created in Eliona, keeping its settings.
+func ReplaceDeviceAsset(ctx context.Context, dbAsset *appdb.Asset, asset api.Asset) error {
+	if asset.Id.Get() == nil {
+		return fmt.Errorf("no asset id present for re-created asset of %d", dbAsset.AssetID)
+	}
+	now := time.Now()
+	_, err := appdb.Assets(
+		appdb.AssetWhere.AssetID.EQ(dbAsset.AssetID),
+	).UpdateAllG(ctx, appdb.M{
+		appdb.AssetColumns.AssetID:              *asset.Id.Get(),
+		appdb.AssetColumns.GlobalAssetID:        asset.GlobalAssetIdentifier,
+		appdb.AssetColumns.AssetName:            assetName(asset),
+		appdb.AssetColumns.AssetDescription:     assetDescription(asset),
+		appdb.AssetColumns.LatestStatusCode:     http2.StatusCreated,
+		appdb.AssetColumns.ModifiedAt:           now,
+		appdb.AssetColumns.AlarmRuleID:          nil,
+		appdb.AssetColumns.ConnectionState:      nil,
+		appdb.AssetColumns.ConnectionStateSince: nil,
+	})
+	if err != nil {
+		return fmt.Errorf("error replacing asset %d by %d: %w", dbAsset.AssetID, *asset.Id.Get(), err)
+	}
+	dbAsset.AssetID = *asset.Id.Get()
+	dbAsset.GlobalAssetID = asset.GlobalAssetIdentifier
+	dbAsset.AssetName = null.StringFrom(assetName(asset))
+	dbAsset.AssetDescription = null.StringFrom(assetDescription(asset))
+	dbAsset.LatestStatusCode = null.Int32From(http2.StatusCreated)
+	dbAsset.ModifiedAt = null.TimeFrom(now)
+	dbAsset.AlarmRuleID = null.Int32{}
+	dbAsset.ConnectionState = null.String{}
+	dbAsset.ConnectionStateSince = null.Time{}
+	return nil
+}
+
+// GetActiveDbDeviceAssets returns all not deleted assets of devices within the configuration.
+func GetActiveDbDeviceAssets(ctx context.Context, configID int64) ([]*appdb.Asset, error) {
+	dbAssets, err := appdb.Assets(
+		appdb.AssetWhere.ConfigurationID.EQ(configID),
+		appdb.AssetWhere.LatestStatusCode.NEQ(null.Int32From(http2.StatusNoContent)),
+	).AllG(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching assets for config %d: %v", configID, err)
+	}
+	return dbAssets, nil
+}
+
+// SetDeviceAssetNames remembers the name and description of the asset as last synchronized to the device.
+func SetDeviceAssetNames(ctx context.Context, dbAsset *appdb.Asset, asset api.Asset) error {
+	dbAsset.AssetName = null.StringFrom(assetName(asset))
+	dbAsset.AssetDescription = null.StringFrom(assetDescription(asset))
+	_, err := dbAsset.UpdateG(ctx, boil.Whitelist(appdb.AssetColumns.AssetName, appdb.AssetColumns.AssetDescription))
+	if err != nil {
+		return fmt.Errorf("error setting names for asset %d: %w", dbAsset.AssetID, err)
+	}
+	return nil
+}
+
+// DeviceAssetNamesChanged checks if the name or description of the asset differ from the ones last synchronized to the
+// device. Assets whose names were never remembered are considered unchanged.
+func DeviceAssetNamesChanged(dbAsset *appdb.Asset, asset api.Asset) bool {
+	if !dbAsset.AssetName.Valid {
+		return false
+	}
+	return dbAsset.AssetName.String != assetName(asset) || dbAsset.AssetDescription.String != assetDescription(asset)
+}
+
+func assetName(asset api.Asset) string {
+	if asset.Name.Get() == nil {
+		return ""
+	}
+	return *asset.Name.Get()
+}
+
+func assetDescription(asset api.Asset) string {
+	if asset.Description.Get() == nil {
+		return ""
+	}
+	return *asset.Description.Get()
+}
+
+// DeleteDbDeviceAsset forgets the device asset, e.g. if its creation is rolled back.
+func DeleteDbDeviceAsset(ctx context.Context, assetID int32) error {
+	_, err := appdb.Assets(
+		appdb.AssetWhere.AssetID.EQ(assetID),
+	).DeleteAllG(ctx)
+	if err != nil {
+		return fmt.Errorf("error deleting asset %d: %w", assetID, err)
+	}
+	return nil
+}
+
+// RestoreDbDeviceAsset sets the device asset back to the given previous state, e.g. if its update is rolled back.
+func RestoreDbDeviceAsset(ctx context.Context, previous *appdb.Asset) error {
+	if _, err := previous.UpdateG(ctx, boil.Infer()); err != nil {
+		return fmt.Errorf("error restoring asset %d: %w", previous.AssetID, err)
+	}
+	return nil
+}
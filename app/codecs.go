@@ -0,0 +1,293 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"loriot-io/apiserver"
+	"loriot-io/appdb"
+	"loriot-io/codec"
+	"loriot-io/decoder"
+	"sync"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-eliona/frontend"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const (
+	CodecTestDirectionUplink   = "uplink"
+	CodecTestDirectionDownlink = "downlink"
+)
+
+func GetCodecs(ctx context.Context) ([]apiserver.Codec, error) {
+	dbCodecs, err := appdb.Codecs().AllG(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching codecs from database: %v", err)
+	}
+	var apiCodecs []apiserver.Codec
+	for _, dbCodec := range dbCodecs {
+		apiCodecs = append(apiCodecs, apiCodecFromDbCodec(dbCodec))
+	}
+	return apiCodecs, nil
+}
+
+func GetCodec(ctx context.Context, codecID int64) (*apiserver.Codec, error) {
+	dbCodec, err := getDbCodec(ctx, codecID)
+	if err != nil {
+		return nil, err
+	}
+	return common.Ptr(apiCodecFromDbCodec(dbCodec)), nil
+}
+
+// InsertCodec stores a new codec. Returns ErrBadRequest if the script can't be compiled.
+func InsertCodec(ctx context.Context, apiCodec apiserver.Codec) (apiserver.Codec, error) {
+	apiCodec.Id = nil
+	dbCodec, err := dbCodecFromApiCodec(ctx, apiCodec)
+	if err != nil {
+		return apiserver.Codec{}, err
+	}
+	if err := dbCodec.InsertG(ctx, boil.Infer()); err != nil {
+		return apiserver.Codec{}, fmt.Errorf("inserting codec: %v", err)
+	}
+	return apiCodecFromDbCodec(&dbCodec), nil
+}
+
+// UpdateCodec replaces the codec with the given ID. Returns ErrNotFound if the codec doesn't exist and ErrBadRequest if
+// the script can't be compiled.
+func UpdateCodec(ctx context.Context, codecID int64, apiCodec apiserver.Codec) (apiserver.Codec, error) {
+	if _, err := getDbCodec(ctx, codecID); err != nil {
+		return apiserver.Codec{}, err
+	}
+	apiCodec.Id = &codecID
+	dbCodec, err := dbCodecFromApiCodec(ctx, apiCodec)
+	if err != nil {
+		return apiserver.Codec{}, err
+	}
+	dbCodec.ID = codecID
+	if _, err := dbCodec.UpdateG(ctx, boil.Infer()); err != nil {
+		return apiserver.Codec{}, fmt.Errorf("updating codec: %v", err)
+	}
+	return apiCodecFromDbCodec(&dbCodec), nil
+}
+
+func DeleteCodec(ctx context.Context, codecID int64) error {
+	count, err := appdb.Codecs(
+		appdb.CodecWhere.ID.EQ(codecID),
+	).DeleteAllG(ctx)
+	if err != nil {
+		return fmt.Errorf("deleting codec from database: %v", err)
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: codec %d not found", ErrNotFound, codecID)
+	}
+	forgetCodec(codecID)
+	return nil
+}
+
+// TestCodec runs the input through the codec. Errors raised by the script are returned as part of the result.
+func TestCodec(ctx context.Context, codecID int64, input apiserver.CodecTestRequest) (apiserver.CodecTestResult, error) {
+	dbCodec, err := getDbCodec(ctx, codecID)
+	if err != nil {
+		return apiserver.CodecTestResult{}, err
+	}
+	script, err := compileDbCodec(dbCodec)
+	if err != nil {
+		return apiserver.CodecTestResult{Errors: []string{err.Error()}}, nil
+	}
+
+	var result codec.Result
+	switch input.Direction {
+	case "", CodecTestDirectionUplink:
+		payload, err := hex.DecodeString(input.Bytes)
+		if err != nil {
+			return apiserver.CodecTestResult{}, fmt.Errorf("%w: invalid hex bytes: %v", ErrBadRequest, err)
+		}
+		var port int
+		if input.FPort != nil {
+			port = int(*input.FPort)
+		}
+		result, err = script.DecodeUplink(port, payload, time.Now())
+	case CodecTestDirectionDownlink:
+		result, err = script.EncodeDownlink(input.Data)
+	default:
+		return apiserver.CodecTestResult{}, fmt.Errorf("%w: unknown direction %s", ErrBadRequest, input.Direction)
+	}
+	if err != nil {
+		result.Errors = append(result.Errors, err.Error())
+	}
+
+	testResult := apiserver.CodecTestResult{
+		Data:     result.Data,
+		Warnings: result.Warnings,
+		Errors:   result.Errors,
+	}
+	if result.Bytes != nil {
+		testResult.Bytes = hex.EncodeToString(result.Bytes)
+	}
+	if result.FPort != nil {
+		testResult.FPort = common.Ptr(int32(*result.FPort))
+	}
+	return testResult, nil
+}
+
+// CodecDecoder provides the codec stored for the asset type as decoder.
+func CodecDecoder(assetType string) (decoder.Decoder, bool, error) {
+	script, err := getCodecScriptByAssetType(assetType)
+	if err != nil || script == nil {
+		return nil, false, err
+	}
+	return script, true, nil
+}
+
+// CodecEncoder provides the codec stored for the asset type as encoder.
+func CodecEncoder(assetType string) (decoder.Encoder, bool, error) {
+	script, err := getCodecScriptByAssetType(assetType)
+	if err != nil || script == nil {
+		return nil, false, err
+	}
+	return script, true, nil
+}
+
+// cachedCodec is a compiled codec in the version of its modification time.
+type cachedCodec struct {
+	modifiedAt time.Time
+	script     *codec.Script
+}
+
+// codecCache holds the compiled codecs by codec ID, so a codec is only compiled again after it was modified.
+var codecCache = struct {
+	sync.Mutex
+	codecs map[int64]cachedCodec
+}{codecs: make(map[int64]cachedCodec)}
+
+// getCodecScriptByAssetType returns the compiled codec of the asset type. Only the ID and modification time of the
+// codec are read from the database if the compiled codec is cached.
+func getCodecScriptByAssetType(assetType string) (*codec.Script, error) {
+	ctx := context.Background()
+	dbCodec, err := appdb.Codecs(
+		qm.Select(appdb.CodecColumns.ID, appdb.CodecColumns.ModifiedAt),
+		appdb.CodecWhere.AssetType.EQ(assetType),
+	).OneG(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching codec for asset type %s: %v", assetType, err)
+	}
+
+	codecCache.Lock()
+	cached, ok := codecCache.codecs[dbCodec.ID]
+	codecCache.Unlock()
+	if ok && cached.modifiedAt.Equal(dbCodec.ModifiedAt.Time) {
+		return cached.script, nil
+	}
+
+	dbCodec, err = appdb.FindCodecG(ctx, dbCodec.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching codec for asset type %s: %v", assetType, err)
+	}
+	script, err := compileDbCodec(dbCodec)
+	if err != nil {
+		return nil, err
+	}
+	codecCache.Lock()
+	codecCache.codecs[dbCodec.ID] = cachedCodec{modifiedAt: dbCodec.ModifiedAt.Time, script: script}
+	codecCache.Unlock()
+	return script, nil
+}
+
+func forgetCodec(codecID int64) {
+	codecCache.Lock()
+	defer codecCache.Unlock()
+	delete(codecCache.codecs, codecID)
+}
+
+func getDbCodec(ctx context.Context, codecID int64) (*appdb.Codec, error) {
+	dbCodec, err := appdb.FindCodecG(ctx, codecID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: codec %d not found", ErrNotFound, codecID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching codec from database: %v", err)
+	}
+	return dbCodec, nil
+}
+
+func compileDbCodec(dbCodec *appdb.Codec) (*codec.Script, error) {
+	return codec.Compile(dbCodec.Script, codec.Limits{
+		Timeout:     time.Duration(dbCodec.Timeout) * time.Millisecond,
+		MemoryLimit: uint64(dbCodec.MemoryLimit) << 20,
+	})
+}
+
+func dbCodecFromApiCodec(ctx context.Context, apiCodec apiserver.Codec) (dbCodec appdb.Codec, err error) {
+	dbCodec.AssetType = apiCodec.AssetTypeName
+	dbCodec.Script = apiCodec.Script
+	dbCodec.Timeout = int32(codec.DefaultLimits.Timeout / time.Millisecond)
+	if apiCodec.Timeout != nil {
+		dbCodec.Timeout = *apiCodec.Timeout
+	}
+	dbCodec.MemoryLimit = int32(codec.DefaultLimits.MemoryLimit >> 20)
+	if apiCodec.MemoryLimit != nil {
+		dbCodec.MemoryLimit = *apiCodec.MemoryLimit
+	}
+	exists, err := appdb.Codecs(
+		appdb.CodecWhere.AssetType.EQ(dbCodec.AssetType),
+		appdb.CodecWhere.ID.NEQ(null.Int64FromPtr(apiCodec.Id).Int64),
+	).ExistsG(ctx)
+	if err != nil {
+		return dbCodec, fmt.Errorf("checking codecs for asset type %s: %v", dbCodec.AssetType, err)
+	}
+	if exists {
+		return dbCodec, fmt.Errorf("%w: codec for asset type %s already exists", ErrBadRequest, dbCodec.AssetType)
+	}
+	if dbCodec.Timeout <= 0 || dbCodec.MemoryLimit <= 0 {
+		return dbCodec, fmt.Errorf("%w: timeout and memory limit must be positive", ErrBadRequest)
+	}
+	if _, err := compileDbCodec(&dbCodec); err != nil {
+		return dbCodec, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+	dbCodec.ModifiedAt = null.TimeFrom(time.Now())
+
+	env := frontend.GetEnvironment(ctx)
+	if env != nil {
+		dbCodec.UserID = null.StringFrom(env.UserId)
+	}
+	return dbCodec, nil
+}
+
+func apiCodecFromDbCodec(dbCodec *appdb.Codec) apiserver.Codec {
+	return apiserver.Codec{
+		Id:            common.Ptr(dbCodec.ID),
+		AssetTypeName: dbCodec.AssetType,
+		Script:        dbCodec.Script,
+		Timeout:       common.Ptr(dbCodec.Timeout),
+		MemoryLimit:   common.Ptr(dbCodec.MemoryLimit),
+		UserId:        dbCodec.UserID.Ptr(),
+		ModifiedAt:    dbCodec.ModifiedAt.Ptr(),
+	}
+}
@@ -17,6 +17,7 @@ package app
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
@@ -26,44 +27,119 @@ import (
 	"github.com/eliona-smart-building-assistant/go-utils/log"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 	"loriot-io/apiserver"
 	"loriot-io/appdb"
+	"loriot-io/secret"
+	"regexp"
+	"strings"
 )
 
 var ErrBadRequest = errors.New("bad request")
 
+// ErrNotFound is returned if the requested object doesn't exist.
+var ErrNotFound = errors.New("not found")
+
+// ErrConflict is returned if the object was modified concurrently, e.g. its version doesn't match.
+var ErrConflict = errors.New("conflict")
+
+var devEUIBlockRegex = regexp.MustCompile(`^([A-Fa-f0-9]{6}|[A-Fa-f0-9]{7}|[A-Fa-f0-9]{9})$`)
+
+// Policies how drift between Loriot.io, Eliona and the app is fixed.
+const (
+	ReconcilePolicyReport   = "report"
+	ReconcilePolicyAdopt    = "adopt"
+	ReconcilePolicyDelete   = "delete"
+	ReconcilePolicyRecreate = "recreate"
+)
+
+// TokenMask replaces the API token in configurations returned by the API. A configuration sent back with the mask or
+// without token keeps its stored token.
+const TokenMask = "********"
+
 func InsertConfig(ctx context.Context, config apiserver.Configuration) (apiserver.Configuration, error) {
+	if isTokenUnchanged(config.ApiToken) {
+		return apiserver.Configuration{}, fmt.Errorf("%w: apiToken is required", ErrBadRequest)
+	}
 	dbConfig, err := dbConfigFromApiConfig(ctx, config)
 	if err != nil {
-		return apiserver.Configuration{}, fmt.Errorf("creating DB config from API config: %v", err)
+		return apiserver.Configuration{}, fmt.Errorf("creating DB config from API config: %w", err)
 	}
 	if err := dbConfig.InsertG(ctx, boil.Infer()); err != nil {
 		return apiserver.Configuration{}, fmt.Errorf("inserting DB config: %v", err)
 	}
-	return config, nil
+	return apiConfigFromDbConfig(&dbConfig)
 }
 
-func UpsertConfig(ctx context.Context, config apiserver.Configuration) (apiserver.Configuration, error) {
+// UpdateConfig replaces the stored configuration and increments its version. If a version is given, the
+// configuration is only updated if it still has this version. Returns the updated configuration.
+func UpdateConfig(ctx context.Context, config apiserver.Configuration, version *int32) (updated apiserver.Configuration, err error) {
 	dbConfig, err := dbConfigFromApiConfig(ctx, config)
 	if err != nil {
-		return apiserver.Configuration{}, fmt.Errorf("creating DB config from API config: %v", err)
+		return apiserver.Configuration{}, fmt.Errorf("creating DB config from API config: %w", err)
 	}
-	if err := dbConfig.UpsertG(ctx, true, []string{"id"}, boil.Blacklist("id"), boil.Infer()); err != nil {
-		return apiserver.Configuration{}, fmt.Errorf("inserting DB config: %v", err)
+	tx, err := boil.BeginTx(ctx, nil)
+	if err != nil {
+		return apiserver.Configuration{}, fmt.Errorf("starting transaction: %v", err)
 	}
-	return config, nil
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	storedConfig, err := lockConfig(ctx, tx, dbConfig.ID)
+	if err != nil {
+		return apiserver.Configuration{}, err
+	}
+	if version != nil && *version != storedConfig.Version {
+		return apiserver.Configuration{}, fmt.Errorf("%w: config %d has version %d, not %d", ErrConflict, dbConfig.ID, storedConfig.Version, *version)
+	}
+	if isTokenUnchanged(config.ApiToken) {
+		dbConfig.APIToken = storedConfig.APIToken
+	}
+	dbConfig.Version = storedConfig.Version + 1
+	if _, err = dbConfig.Update(ctx, tx, boil.Infer()); err != nil {
+		return apiserver.Configuration{}, fmt.Errorf("updating DB config: %v", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return apiserver.Configuration{}, fmt.Errorf("committing transaction: %v", err)
+	}
+	return apiConfigFromDbConfig(&dbConfig)
+}
+
+// PatchConfig applies the JSON merge patch (RFC 7396) to the stored configuration. Returns the patched configuration
+// without saving it, together with the version it is based on.
+func PatchConfig(ctx context.Context, configID int64, patch map[string]interface{}) (apiserver.Configuration, int32, error) {
+	dbConfig, err := appdb.FindConfigurationG(ctx, configID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return apiserver.Configuration{}, 0, fmt.Errorf("%w: config %d", ErrNotFound, configID)
+	}
+	if err != nil {
+		return apiserver.Configuration{}, 0, fmt.Errorf("fetching config from database: %v", err)
+	}
+	config, err := apiConfigFromDbConfig(dbConfig)
+	if err != nil {
+		return apiserver.Configuration{}, 0, fmt.Errorf("creating API config from DB config: %v", err)
+	}
+	patched, err := mergePatchConfig(config, patch)
+	if err != nil {
+		return apiserver.Configuration{}, 0, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+	patched.Id = &configID
+	return patched, dbConfig.Version, nil
 }
 
 func GetConfig(ctx context.Context, configID int64) (*apiserver.Configuration, error) {
 	dbConfig, err := appdb.Configurations(
 		appdb.ConfigurationWhere.ID.EQ(configID),
 	).OneG(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: config %d", ErrNotFound, configID)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("fetching config from database: %v", err)
 	}
-	if dbConfig == nil {
-		return nil, ErrBadRequest
-	}
 	apiConfig, err := apiConfigFromDbConfig(dbConfig)
 	if err != nil {
 		return nil, fmt.Errorf("creating API config from DB config: %v", err)
@@ -71,6 +147,21 @@ func GetConfig(ctx context.Context, configID int64) (*apiserver.Configuration, e
 	return &apiConfig, nil
 }
 
+// lockConfig reads the stored configuration and locks it until the transaction ends.
+func lockConfig(ctx context.Context, tx boil.ContextExecutor, configID int64) (*appdb.Configuration, error) {
+	dbConfig, err := appdb.Configurations(
+		appdb.ConfigurationWhere.ID.EQ(configID),
+		qm.For("update"),
+	).One(ctx, tx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: config %d", ErrNotFound, configID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching config from database: %v", err)
+	}
+	return dbConfig, nil
+}
+
 func DeleteConfig(ctx context.Context, configID int64) error {
 	if _, err := appdb.Assets(
 		appdb.AssetWhere.ConfigurationID.EQ(configID),
@@ -87,14 +178,18 @@ func DeleteConfig(ctx context.Context, configID int64) error {
 		return fmt.Errorf("shouldn't happen: deleted more (%v) configs by ID", count)
 	}
 	if count == 0 {
-		return ErrBadRequest
+		return fmt.Errorf("%w: config %d", ErrNotFound, configID)
 	}
 	return nil
 }
 
 func dbConfigFromApiConfig(ctx context.Context, apiConfig apiserver.Configuration) (dbConfig appdb.Configuration, err error) {
 	dbConfig.APIBaseURL = apiConfig.ApiBaseUrl
-	dbConfig.APIToken = apiConfig.ApiToken
+	if !isTokenUnchanged(apiConfig.ApiToken) {
+		if dbConfig.APIToken, err = encryptToken(apiConfig.ApiToken); err != nil {
+			return dbConfig, err
+		}
+	}
 
 	dbConfig.ID = null.Int64FromPtr(apiConfig.Id).Int64
 	dbConfig.Enable = null.BoolFromPtr(apiConfig.Enable)
@@ -105,6 +200,46 @@ func dbConfigFromApiConfig(ctx context.Context, apiConfig apiserver.Configuratio
 	if apiConfig.ProjectIDs != nil {
 		dbConfig.ProjectIds = *apiConfig.ProjectIDs
 	}
+	if apiConfig.GatewayOfflineThreshold != nil {
+		dbConfig.GatewayOfflineThreshold = *apiConfig.GatewayOfflineThreshold
+	}
+	if apiConfig.DeviceOfflineIntervals != nil {
+		dbConfig.DeviceOfflineIntervals = *apiConfig.DeviceOfflineIntervals
+	}
+	dbConfig.ReconcilePolicy = ReconcilePolicyReport
+	if apiConfig.ReconcilePolicy != nil {
+		switch *apiConfig.ReconcilePolicy {
+		case ReconcilePolicyReport, ReconcilePolicyAdopt, ReconcilePolicyDelete, ReconcilePolicyRecreate:
+			dbConfig.ReconcilePolicy = *apiConfig.ReconcilePolicy
+		default:
+			return dbConfig, fmt.Errorf("%w: unknown reconcile policy '%s'", ErrBadRequest, *apiConfig.ReconcilePolicy)
+		}
+	}
+	if apiConfig.DevEUIBlock != nil && *apiConfig.DevEUIBlock != "" {
+		if !IsValidDevEUIBlock(*apiConfig.DevEUIBlock) {
+			return dbConfig, fmt.Errorf("%w: invalid device EUI block '%s'", ErrBadRequest, *apiConfig.DevEUIBlock)
+		}
+		dbConfig.DevEuiBlock = null.StringFrom(strings.ToUpper(*apiConfig.DevEUIBlock))
+	}
+	routingRules, err := normalizeRoutingRules(apiConfig)
+	if err != nil {
+		return dbConfig, err
+	}
+	if routingRules != nil {
+		if err := dbConfig.RoutingRules.Marshal(routingRules); err != nil {
+			return dbConfig, fmt.Errorf("marshalling routing rules: %v", err)
+		}
+	}
+	if apiConfig.ReportingIntervals != nil {
+		if err := dbConfig.ReportingIntervals.Marshal(apiConfig.ReportingIntervals); err != nil {
+			return dbConfig, fmt.Errorf("marshalling reporting intervals: %v", err)
+		}
+	}
+	if apiConfig.DefaultAssetTypes != nil {
+		if err := dbConfig.DefaultAssetTypes.Marshal(apiConfig.DefaultAssetTypes); err != nil {
+			return dbConfig, fmt.Errorf("marshalling default asset types: %v", err)
+		}
+	}
 
 	env := frontend.GetEnvironment(ctx)
 	if env != nil {
@@ -116,7 +251,9 @@ func dbConfigFromApiConfig(ctx context.Context, apiConfig apiserver.Configuratio
 
 func apiConfigFromDbConfig(dbConfig *appdb.Configuration) (apiConfig apiserver.Configuration, err error) {
 	apiConfig.ApiBaseUrl = dbConfig.APIBaseURL
-	apiConfig.ApiToken = dbConfig.APIToken
+	if apiConfig.ApiToken, err = secret.DecryptString(dbConfig.APIToken); err != nil {
+		return apiConfig, fmt.Errorf("decrypting API token: %v", err)
+	}
 
 	apiConfig.Id = &dbConfig.ID
 	apiConfig.Enable = dbConfig.Enable.Ptr()
@@ -124,6 +261,26 @@ func apiConfigFromDbConfig(dbConfig *appdb.Configuration) (apiConfig apiserver.C
 	apiConfig.RequestTimeout = &dbConfig.RequestTimeout
 	apiConfig.ProjectIDs = common.Ptr[[]string](dbConfig.ProjectIds)
 	apiConfig.UserId = dbConfig.UserID.Ptr()
+	apiConfig.GatewayOfflineThreshold = &dbConfig.GatewayOfflineThreshold
+	apiConfig.DeviceOfflineIntervals = &dbConfig.DeviceOfflineIntervals
+	apiConfig.ReconcilePolicy = &dbConfig.ReconcilePolicy
+	apiConfig.Version = &dbConfig.Version
+	apiConfig.DevEUIBlock = dbConfig.DevEuiBlock.Ptr()
+	if dbConfig.ReportingIntervals.Valid {
+		if err := dbConfig.ReportingIntervals.Unmarshal(&apiConfig.ReportingIntervals); err != nil {
+			return apiConfig, fmt.Errorf("unmarshalling reporting intervals: %v", err)
+		}
+	}
+	if dbConfig.DefaultAssetTypes.Valid {
+		if err := dbConfig.DefaultAssetTypes.Unmarshal(&apiConfig.DefaultAssetTypes); err != nil {
+			return apiConfig, fmt.Errorf("unmarshalling default asset types: %v", err)
+		}
+	}
+	if dbConfig.RoutingRules.Valid {
+		if err := dbConfig.RoutingRules.Unmarshal(&apiConfig.RoutingRules); err != nil {
+			return apiConfig, fmt.Errorf("unmarshalling routing rules: %v", err)
+		}
+	}
 	return apiConfig, nil
 }
 
@@ -143,6 +300,76 @@ func GetConfigs(ctx context.Context) ([]apiserver.Configuration, error) {
 	return apiConfigs, nil
 }
 
+// RedactConfig returns the configuration with the API token replaced by the TokenMask.
+func RedactConfig(config apiserver.Configuration) apiserver.Configuration {
+	if config.ApiToken != "" {
+		config.ApiToken = TokenMask
+	}
+	return config
+}
+
+// EncryptConfigTokens encrypts the API tokens stored unencrypted, e.g. before a secret key was configured.
+func EncryptConfigTokens(ctx context.Context) error {
+	if !secret.Configured() {
+		log.Warn("app", "API tokens are stored unencrypted: %v", secret.ErrNotConfigured)
+		return nil
+	}
+	dbConfigs, err := appdb.Configurations(
+		qm.Where(appdb.ConfigurationColumns.APIToken+" not like ?", secret.EncryptedPrefix+"%"),
+	).AllG(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching configs with unencrypted token: %v", err)
+	}
+	for _, dbConfig := range dbConfigs {
+		if dbConfig.APIToken, err = secret.EncryptString(dbConfig.APIToken); err != nil {
+			return fmt.Errorf("encrypting API token of config %d: %v", dbConfig.ID, err)
+		}
+		if _, err := dbConfig.UpdateG(ctx, boil.Whitelist(appdb.ConfigurationColumns.APIToken)); err != nil {
+			return fmt.Errorf("updating API token of config %d: %v", dbConfig.ID, err)
+		}
+		log.Info("app", "Encrypted API token of config %d", dbConfig.ID)
+	}
+	return nil
+}
+
+// WithStoredToken returns the configuration with its stored API token, if the token is omitted or masked.
+func WithStoredToken(ctx context.Context, config apiserver.Configuration) (apiserver.Configuration, error) {
+	if !isTokenUnchanged(config.ApiToken) {
+		return config, nil
+	}
+	if config.Id == nil {
+		return config, fmt.Errorf("%w: apiToken is required", ErrBadRequest)
+	}
+	storedConfig, err := GetConfig(ctx, *config.Id)
+	if err != nil {
+		return config, err
+	}
+	config.ApiToken = storedConfig.ApiToken
+	return config, nil
+}
+
+// encryptToken encrypts the API token if a secret key is configured.
+func encryptToken(token string) (string, error) {
+	if !secret.Configured() {
+		return token, nil
+	}
+	encrypted, err := secret.EncryptString(token)
+	if err != nil {
+		return "", fmt.Errorf("encrypting API token: %v", err)
+	}
+	return encrypted, nil
+}
+
+func isTokenUnchanged(token string) bool {
+	return token == "" || token == TokenMask
+}
+
+// IsValidDevEUIBlock checks if the block is the hexadecimal prefix of an IEEE assignment: an OUI (MA-L) with 6 digits,
+// an MA-M with 7 digits or an IAB (MA-S) with 9 digits.
+func IsValidDevEUIBlock(block string) bool {
+	return devEUIBlockRegex.MatchString(block)
+}
+
 func ProjIds(config apiserver.Configuration) []string {
 	if config.ProjectIDs == nil {
 		return []string{}
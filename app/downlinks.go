@@ -0,0 +1,162 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"loriot-io/apiserver"
+	"loriot-io/appdb"
+	"strings"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-eliona/frontend"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const (
+	DownlinkStatusQueued = "queued"
+	DownlinkStatusSent   = "sent"
+	DownlinkStatusAcked  = "acked"
+	DownlinkStatusFailed = "failed"
+)
+
+// maxDownlinks limits the number of downlinks returned by the API.
+const maxDownlinks = 1000
+
+// InsertDownlink remembers a downlink with the given status. Downlinks issued by an Eliona user are recorded with
+// the user.
+func InsertDownlink(ctx context.Context, dbDownlink appdb.Downlink) (*appdb.Downlink, error) {
+	dbDownlink.DevEui = strings.ToUpper(dbDownlink.DevEui)
+	dbDownlink.CreatedAt = time.Now()
+	dbDownlink.ModifiedAt = null.TimeFrom(dbDownlink.CreatedAt)
+	if env := frontend.GetEnvironment(ctx); env != nil {
+		dbDownlink.UserID = null.StringFrom(env.UserId)
+	}
+	if err := dbDownlink.InsertG(ctx, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("inserting downlink for device %s: %v", dbDownlink.DevEui, err)
+	}
+	return &dbDownlink, nil
+}
+
+// SetDownlinkStatus changes the status of the downlink.
+func SetDownlinkStatus(ctx context.Context, dbDownlink *appdb.Downlink, status string, downlinkErr error) error {
+	dbDownlink.Status = status
+	dbDownlink.Error = null.String{}
+	if downlinkErr != nil {
+		dbDownlink.Error = null.StringFrom(downlinkErr.Error())
+	}
+	dbDownlink.ModifiedAt = null.TimeFrom(time.Now())
+	_, err := dbDownlink.UpdateG(ctx, boil.Whitelist(appdb.DownlinkColumns.Status, appdb.DownlinkColumns.Error, appdb.DownlinkColumns.ModifiedAt))
+	if err != nil {
+		return fmt.Errorf("updating status of downlink %d: %v", dbDownlink.ID, err)
+	}
+	return nil
+}
+
+// AdvanceDownlinkStatus changes the status of the oldest downlink of the device having one of the given statuses.
+// Loriot answers downlinks in the order they are enqueued. Returns nil if there is no such downlink.
+func AdvanceDownlinkStatus(ctx context.Context, configID int64, devEUI string, from []string, status string, downlinkErr error) (*appdb.Downlink, error) {
+	dbDownlink, err := appdb.Downlinks(
+		appdb.DownlinkWhere.ConfigurationID.EQ(configID),
+		appdb.DownlinkWhere.DevEui.EQ(strings.ToUpper(devEUI)),
+		appdb.DownlinkWhere.Status.IN(from),
+		qm.OrderBy(appdb.DownlinkColumns.ID),
+	).OneG(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching downlink of device %s: %v", devEUI, err)
+	}
+	return dbDownlink, SetDownlinkStatus(ctx, dbDownlink, status, downlinkErr)
+}
+
+// AcknowledgeDownlink marks the oldest sent confirmed downlink of the device as acknowledged. Returns nil if there is
+// no such downlink.
+func AcknowledgeDownlink(ctx context.Context, configID int64, devEUI string) (*appdb.Downlink, error) {
+	dbDownlink, err := appdb.Downlinks(
+		appdb.DownlinkWhere.ConfigurationID.EQ(configID),
+		appdb.DownlinkWhere.DevEui.EQ(strings.ToUpper(devEUI)),
+		appdb.DownlinkWhere.Status.EQ(DownlinkStatusSent),
+		appdb.DownlinkWhere.Confirmed.EQ(true),
+		qm.OrderBy(appdb.DownlinkColumns.ID),
+	).OneG(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching downlink of device %s: %v", devEUI, err)
+	}
+	return dbDownlink, SetDownlinkStatus(ctx, dbDownlink, DownlinkStatusAcked, nil)
+}
+
+// DiscardQueuedDownlinks marks all queued downlinks of the device as failed, e.g. after the downlink queue was
+// flushed.
+func DiscardQueuedDownlinks(ctx context.Context, configID int64, devEUI string, reason error) error {
+	_, err := appdb.Downlinks(
+		appdb.DownlinkWhere.ConfigurationID.EQ(configID),
+		appdb.DownlinkWhere.DevEui.EQ(strings.ToUpper(devEUI)),
+		appdb.DownlinkWhere.Status.EQ(DownlinkStatusQueued),
+	).UpdateAllG(ctx, appdb.M{
+		appdb.DownlinkColumns.Status:     DownlinkStatusFailed,
+		appdb.DownlinkColumns.Error:      reason.Error(),
+		appdb.DownlinkColumns.ModifiedAt: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("discarding queued downlinks of device %s: %v", devEUI, err)
+	}
+	return nil
+}
+
+// GetDownlinks returns the latest downlinks, newest first.
+func GetDownlinks(ctx context.Context) ([]apiserver.Downlink, error) {
+	dbDownlinks, err := appdb.Downlinks(
+		qm.OrderBy(appdb.DownlinkColumns.ID+" desc"),
+		qm.Limit(maxDownlinks),
+	).AllG(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching downlinks: %v", err)
+	}
+	var downlinks []apiserver.Downlink
+	for _, dbDownlink := range dbDownlinks {
+		downlinks = append(downlinks, ApiDownlinkFromDbDownlink(dbDownlink))
+	}
+	return downlinks, nil
+}
+
+// ApiDownlinkFromDbDownlink converts a stored downlink for the API.
+func ApiDownlinkFromDbDownlink(dbDownlink *appdb.Downlink) apiserver.Downlink {
+	return apiserver.Downlink{
+		Id:         common.Ptr(dbDownlink.ID),
+		ConfigID:   common.Ptr(dbDownlink.ConfigurationID),
+		AssetID:    dbDownlink.AssetID.Ptr(),
+		DevEUI:     dbDownlink.DevEui,
+		FPort:      dbDownlink.Port,
+		Confirmed:  dbDownlink.Confirmed,
+		Payload:    dbDownlink.Payload,
+		Status:     dbDownlink.Status,
+		Error:      dbDownlink.Error.Ptr(),
+		CreatedAt:  common.Ptr(dbDownlink.CreatedAt),
+		ModifiedAt: dbDownlink.ModifiedAt.Ptr(),
+		UserID:     dbDownlink.UserID.Ptr(),
+	}
+}
@@ -0,0 +1,92 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"loriot-io/apiserver"
+	"loriot-io/appdb"
+	"loriot-io/loriot"
+	"strings"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// GetDbGatewayAssets returns the assets of the gateway with the given EUI within the configuration, one per project.
+func GetDbGatewayAssets(ctx context.Context, configID int64, eui string) ([]*appdb.Gateway, error) {
+	dbGateways, err := appdb.Gateways(
+		appdb.GatewayWhere.ConfigurationID.EQ(configID),
+		qm.Where("upper("+appdb.GatewayColumns.Eui+") = ?", strings.ToUpper(eui)),
+	).AllG(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching assets for gateway %s: %v", eui, err)
+	}
+	return dbGateways, nil
+}
+
+// UpsertGatewayAsset remembers the asset of the gateway.
+func UpsertGatewayAsset(ctx context.Context, config apiserver.Configuration, gateway loriot.Gateway, asset api.Asset) (*appdb.Gateway, error) {
+	if asset.Id.Get() == nil {
+		return nil, fmt.Errorf("no id present for asset of gateway %s", gateway.EUI)
+	}
+	dbGateway := appdb.Gateway{
+		AssetID:         *asset.Id.Get(),
+		ConfigurationID: null.Int64FromPtr(config.Id).Int64,
+		ProjectID:       asset.ProjectId,
+		GlobalAssetID:   asset.GlobalAssetIdentifier,
+		NetworkID:       gateway.NetworkID,
+		GatewayID:       gateway.Id,
+		Eui:             strings.ToUpper(gateway.EUI),
+		ModifiedAt:      null.TimeFrom(time.Now()),
+	}
+	updateBlacklist := boil.Blacklist(appdb.GatewayColumns.AssetID, appdb.GatewayColumns.DisconnectedSince, appdb.GatewayColumns.Offline, appdb.GatewayColumns.AlarmRuleID)
+	err := dbGateway.UpsertG(ctx, true, []string{appdb.GatewayColumns.AssetID}, updateBlacklist, boil.Infer())
+	if err != nil {
+		return nil, fmt.Errorf("error upserting asset %d for gateway %s: %w", *asset.Id.Get(), gateway.EUI, err)
+	}
+	return &dbGateway, nil
+}
+
+// SetGatewayAlarmRule remembers the alarm rule raising the offline alarm for the gateway asset.
+func SetGatewayAlarmRule(ctx context.Context, dbGateway *appdb.Gateway, alarmRuleID int32) error {
+	dbGateway.AlarmRuleID = null.Int32From(alarmRuleID)
+	_, err := dbGateway.UpdateG(ctx, boil.Whitelist(appdb.GatewayColumns.AlarmRuleID))
+	if err != nil {
+		return fmt.Errorf("error setting alarm rule for gateway asset %d: %w", dbGateway.AssetID, err)
+	}
+	return nil
+}
+
+// SetGatewayState remembers since when the gateway is disconnected and whether it is reported as offline, for all
+// assets of the gateway within the configuration.
+func SetGatewayState(ctx context.Context, configID int64, eui string, disconnectedSince null.Time, offline bool) error {
+	_, err := appdb.Gateways(
+		appdb.GatewayWhere.ConfigurationID.EQ(configID),
+		qm.Where("upper("+appdb.GatewayColumns.Eui+") = ?", strings.ToUpper(eui)),
+	).UpdateAllG(ctx, appdb.M{
+		appdb.GatewayColumns.DisconnectedSince: disconnectedSince,
+		appdb.GatewayColumns.Offline:           offline,
+	})
+	if err != nil {
+		return fmt.Errorf("error setting state of gateway %s: %w", eui, err)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"loriot-io/apiserver"
+	"loriot-io/appdb"
+	"time"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+// IdempotencyKeyRetention defines how long the response to a request with idempotency key is remembered.
+const IdempotencyKeyRetention = 24 * time.Hour
+
+// IdempotencyKeyLease defines how long a request reserves its idempotency key. Reservations of requests not completed
+// within the lease, e.g. because the app was stopped, can be taken over by a retry.
+const IdempotencyKeyLease = 10 * time.Minute
+
+// States of an idempotency key.
+const (
+	IdempotencyKeyStatePending = "pending"
+	IdempotencyKeyStateDone    = "done"
+)
+
+// ReserveIdempotencyKey reserves the idempotency key for the request, unless another request holds or completed it.
+// Expired keys and reservations exceeding the lease are taken over. The reservation is atomic across all replicas of
+// the app.
+func ReserveIdempotencyKey(ctx context.Context, key string, requestHash string) (bool, error) {
+	now := time.Now()
+	result, err := queries.Raw(`
+		insert into loriot_io.idempotency_key (key, request_hash, state, created_at)
+		values ($1, $2, $3, $4)
+		on conflict (key) do update
+			set request_hash = excluded.request_hash, state = excluded.state, response = null, created_at = excluded.created_at
+			where (idempotency_key.state = $3 and idempotency_key.created_at < $5) or idempotency_key.created_at <= $6`,
+		key, requestHash, IdempotencyKeyStatePending, now, now.Add(-IdempotencyKeyLease), now.Add(-IdempotencyKeyRetention),
+	).ExecContext(ctx, boil.GetContextDB())
+	if err != nil {
+		return false, fmt.Errorf("reserving idempotency key %s: %v", key, err)
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("reserving idempotency key %s: %v", key, err)
+	}
+	return count == 1, nil
+}
+
+// GetIdempotentDeviceAssets returns the device assets responded to an earlier request with the same idempotency key.
+// Returns false if the key is unknown, expired or reserved by a request still in progress, and ErrBadRequest if the
+// key was used for a different request.
+func GetIdempotentDeviceAssets(ctx context.Context, key string, requestHash string) ([]apiserver.DeviceAsset, bool, error) {
+	dbKey, err := appdb.IdempotencyKeys(
+		appdb.IdempotencyKeyWhere.Key.EQ(key),
+		appdb.IdempotencyKeyWhere.CreatedAt.GT(time.Now().Add(-IdempotencyKeyRetention)),
+	).OneG(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("selecting idempotency key %s: %v", key, err)
+	}
+	if dbKey.RequestHash != requestHash {
+		return nil, false, fmt.Errorf("%w: idempotency key %s was already used for a different request", ErrBadRequest, key)
+	}
+	if dbKey.State != IdempotencyKeyStateDone {
+		return nil, false, nil
+	}
+	var deviceAssets []apiserver.DeviceAsset
+	if err := dbKey.Response.Unmarshal(&deviceAssets); err != nil {
+		return nil, false, fmt.Errorf("unmarshalling response of idempotency key %s: %v", key, err)
+	}
+	return deviceAssets, true, nil
+}
+
+// CompleteIdempotencyKey remembers the device assets responded to the request holding the idempotency key.
+func CompleteIdempotencyKey(ctx context.Context, key string, deviceAssets []apiserver.DeviceAsset) error {
+	var response null.JSON
+	if err := response.Marshal(deviceAssets); err != nil {
+		return fmt.Errorf("marshalling response of idempotency key %s: %v", key, err)
+	}
+	_, err := appdb.IdempotencyKeys(
+		appdb.IdempotencyKeyWhere.Key.EQ(key),
+		appdb.IdempotencyKeyWhere.State.EQ(IdempotencyKeyStatePending),
+	).UpdateAllG(ctx, appdb.M{
+		appdb.IdempotencyKeyColumns.State:     IdempotencyKeyStateDone,
+		appdb.IdempotencyKeyColumns.Response:  response,
+		appdb.IdempotencyKeyColumns.CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("completing idempotency key %s: %v", key, err)
+	}
+	return nil
+}
+
+// ReleaseIdempotencyKey removes the reservation of a failed request, so it can be retried with the same key.
+func ReleaseIdempotencyKey(ctx context.Context, key string) error {
+	_, err := appdb.IdempotencyKeys(
+		appdb.IdempotencyKeyWhere.Key.EQ(key),
+		appdb.IdempotencyKeyWhere.State.EQ(IdempotencyKeyStatePending),
+	).DeleteAllG(ctx)
+	if err != nil {
+		return fmt.Errorf("releasing idempotency key %s: %v", key, err)
+	}
+	return nil
+}
+
+// DeleteExpiredIdempotencyKeys forgets the responses remembered longer than the retention.
+func DeleteExpiredIdempotencyKeys(ctx context.Context) error {
+	_, err := appdb.IdempotencyKeys(
+		appdb.IdempotencyKeyWhere.CreatedAt.LTE(time.Now().Add(-IdempotencyKeyRetention)),
+	).DeleteAllG(ctx)
+	if err != nil {
+		return fmt.Errorf("deleting expired idempotency keys: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"loriot-io/apiserver"
+	"loriot-io/appdb"
+	"loriot-io/secret"
+	"strings"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-eliona/frontend"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const (
+	JobKindDeviceImport = "device_import"
+)
+
+const (
+	JobStatusPending = "pending"
+	JobStatusRunning = "running"
+	JobStatusDone    = "done"
+)
+
+const (
+	JobRowStatusPending = "pending"
+	JobRowStatusDone    = "done"
+	JobRowStatusFailed  = "failed"
+)
+
+// InsertDeviceImportJob remembers a pending job importing the devices. Jobs started by an Eliona user are recorded
+// with the user. The keys of the devices are stored encrypted until the rows are processed, so importing keys
+// requires a configured secret key.
+func InsertDeviceImportJob(ctx context.Context, requests []apiserver.PutDeviceRequest) (*appdb.Job, error) {
+	for idx, request := range requests {
+		if len(deviceRequestKeys(&request)) > 0 && !secret.Configured() {
+			return nil, fmt.Errorf("%w: keys of row %d cannot be imported: %v", ErrBadRequest, idx+1, secret.ErrNotConfigured)
+		}
+	}
+	dbJob := appdb.Job{
+		Kind:      JobKindDeviceImport,
+		Status:    JobStatusPending,
+		CreatedAt: time.Now(),
+	}
+	dbJob.ModifiedAt = null.TimeFrom(dbJob.CreatedAt)
+	if env := frontend.GetEnvironment(ctx); env != nil {
+		dbJob.UserID = null.StringFrom(env.UserId)
+	}
+	if err := dbJob.InsertG(ctx, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("inserting device import job: %v", err)
+	}
+	for idx, request := range requests {
+		dbRow := appdb.JobRow{
+			JobID:      dbJob.ID,
+			RowNumber:  int32(idx + 1),
+			DevEui:     strings.ToUpper(request.DevEUI),
+			Status:     JobRowStatusPending,
+			ModifiedAt: dbJob.ModifiedAt,
+		}
+		if err := encryptDeviceRequest(&request); err != nil {
+			return nil, errors.Join(fmt.Errorf("row %d of job %d: %w", dbRow.RowNumber, dbJob.ID, err), deleteJob(ctx, &dbJob))
+		}
+		if err := dbRow.Request.Marshal(request); err != nil {
+			return nil, errors.Join(fmt.Errorf("marshalling row %d of job %d: %v", dbRow.RowNumber, dbJob.ID, err), deleteJob(ctx, &dbJob))
+		}
+		if err := dbRow.InsertG(ctx, boil.Infer()); err != nil {
+			return nil, errors.Join(fmt.Errorf("inserting row %d of job %d: %v", dbRow.RowNumber, dbJob.ID, err), deleteJob(ctx, &dbJob))
+		}
+	}
+	return &dbJob, nil
+}
+
+func deleteJob(ctx context.Context, dbJob *appdb.Job) error {
+	if _, err := dbJob.DeleteG(ctx); err != nil {
+		return fmt.Errorf("deleting job %d: %v", dbJob.ID, err)
+	}
+	return nil
+}
+
+// GetUnfinishedJobs returns the jobs not done yet, e.g. because the app was restarted while they were running.
+func GetUnfinishedJobs(ctx context.Context, kind string) ([]*appdb.Job, error) {
+	dbJobs, err := appdb.Jobs(
+		appdb.JobWhere.Kind.EQ(kind),
+		appdb.JobWhere.Status.NEQ(JobStatusDone),
+		qm.OrderBy(appdb.JobColumns.ID),
+	).AllG(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching unfinished jobs: %v", err)
+	}
+	return dbJobs, nil
+}
+
+// GetPendingJobRows returns the rows of the job not processed yet in the order of the upload.
+func GetPendingJobRows(ctx context.Context, jobID int64) ([]*appdb.JobRow, error) {
+	dbRows, err := appdb.JobRows(
+		appdb.JobRowWhere.JobID.EQ(jobID),
+		appdb.JobRowWhere.Status.EQ(JobRowStatusPending),
+		qm.OrderBy(appdb.JobRowColumns.RowNumber),
+	).AllG(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching pending rows of job %d: %v", jobID, err)
+	}
+	return dbRows, nil
+}
+
+// JobRowDeviceRequest returns the device request of the row with the decrypted keys.
+func JobRowDeviceRequest(dbRow *appdb.JobRow) (apiserver.PutDeviceRequest, error) {
+	var request apiserver.PutDeviceRequest
+	if err := dbRow.Request.Unmarshal(&request); err != nil {
+		return request, fmt.Errorf("unmarshalling row %d of job %d: %v", dbRow.RowNumber, dbRow.JobID, err)
+	}
+	if err := decryptDeviceRequest(&request); err != nil {
+		return request, fmt.Errorf("row %d of job %d: %w", dbRow.RowNumber, dbRow.JobID, err)
+	}
+	return request, nil
+}
+
+// encryptDeviceRequest encrypts the keys of the device request.
+func encryptDeviceRequest(request *apiserver.PutDeviceRequest) error {
+	if err := cryptDeviceRequest(request, secret.EncryptString); err != nil {
+		return fmt.Errorf("encrypting keys of device %s: %w", request.DevEUI, err)
+	}
+	return nil
+}
+
+// decryptDeviceRequest decrypts the keys of the device request. Keys stored before they were encrypted are kept
+// unchanged.
+func decryptDeviceRequest(request *apiserver.PutDeviceRequest) error {
+	if err := cryptDeviceRequest(request, secret.DecryptString); err != nil {
+		return fmt.Errorf("decrypting keys of device %s: %w", request.DevEUI, err)
+	}
+	return nil
+}
+
+func cryptDeviceRequest(request *apiserver.PutDeviceRequest, crypt func(string) (string, error)) error {
+	for _, key := range deviceRequestKeys(request) {
+		var err error
+		if *key, err = crypt(*key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deviceRequestKeys returns the keys given in the device request.
+func deviceRequestKeys(request *apiserver.PutDeviceRequest) []*string {
+	var keys []*string
+	for _, key := range []*string{
+		&request.AppKey, &request.NwkKey, &request.NwkSKey, &request.AppSKey,
+		&request.FNwkSIntKey, &request.SNwkSIntKey, &request.NwkSEncKey,
+	} {
+		if *key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// SetJobStatus changes the status of the job.
+func SetJobStatus(ctx context.Context, dbJob *appdb.Job, status string) error {
+	dbJob.Status = status
+	dbJob.ModifiedAt = null.TimeFrom(time.Now())
+	_, err := dbJob.UpdateG(ctx, boil.Whitelist(appdb.JobColumns.Status, appdb.JobColumns.ModifiedAt))
+	if err != nil {
+		return fmt.Errorf("updating status of job %d: %v", dbJob.ID, err)
+	}
+	return nil
+}
+
+// SetJobRowDone marks the row as successfully processed with the resulting asset IDs. The request is forgotten,
+// because it contains the keys of the device.
+func SetJobRowDone(ctx context.Context, dbRow *appdb.JobRow, deviceAssets []apiserver.DeviceAsset) error {
+	dbRow.AssetIds = nil
+	for _, deviceAsset := range deviceAssets {
+		dbRow.AssetIds = append(dbRow.AssetIds, int64(deviceAsset.AssetID))
+	}
+	return setJobRowStatus(ctx, dbRow, JobRowStatusDone, nil)
+}
+
+// SetJobRowFailed marks the row as failed with the error. The request is forgotten, because it contains the keys of
+// the device.
+func SetJobRowFailed(ctx context.Context, dbRow *appdb.JobRow, rowErr error) error {
+	return setJobRowStatus(ctx, dbRow, JobRowStatusFailed, rowErr)
+}
+
+func setJobRowStatus(ctx context.Context, dbRow *appdb.JobRow, status string, rowErr error) error {
+	dbRow.Status = status
+	dbRow.Request = null.JSON{}
+	if rowErr != nil {
+		dbRow.Error = null.StringFrom(rowErr.Error())
+	}
+	dbRow.ModifiedAt = null.TimeFrom(time.Now())
+	_, err := dbRow.UpdateG(ctx, boil.Whitelist(
+		appdb.JobRowColumns.Status,
+		appdb.JobRowColumns.Request,
+		appdb.JobRowColumns.Error,
+		appdb.JobRowColumns.AssetIds,
+		appdb.JobRowColumns.ModifiedAt,
+	))
+	if err != nil {
+		return fmt.Errorf("updating status of row %d of job %d: %v", dbRow.RowNumber, dbRow.JobID, err)
+	}
+	return nil
+}
+
+// GetJob returns the job with the status of all its rows.
+func GetJob(ctx context.Context, id int64) (*apiserver.Job, error) {
+	dbJob, err := appdb.FindJobG(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: job %d not found", ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching job %d: %v", id, err)
+	}
+	dbRows, err := appdb.JobRows(
+		appdb.JobRowWhere.JobID.EQ(id),
+		qm.OrderBy(appdb.JobRowColumns.RowNumber),
+	).AllG(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching rows of job %d: %v", id, err)
+	}
+	return common.Ptr(apiJobFromDbJob(dbJob, dbRows)), nil
+}
+
+func apiJobFromDbJob(dbJob *appdb.Job, dbRows []*appdb.JobRow) apiserver.Job {
+	job := apiserver.Job{
+		Id:         dbJob.ID,
+		Kind:       dbJob.Kind,
+		Status:     dbJob.Status,
+		Total:      int32(len(dbRows)),
+		UserId:     dbJob.UserID.Ptr(),
+		CreatedAt:  common.Ptr(dbJob.CreatedAt),
+		ModifiedAt: dbJob.ModifiedAt.Ptr(),
+		Rows:       []apiserver.JobRow{},
+	}
+	for _, dbRow := range dbRows {
+		switch dbRow.Status {
+		case JobRowStatusDone:
+			job.Succeeded++
+		case JobRowStatusFailed:
+			job.Failed++
+		}
+		row := apiserver.JobRow{
+			Row:    dbRow.RowNumber,
+			DevEUI: dbRow.DevEui,
+			Status: dbRow.Status,
+			Error:  dbRow.Error.Ptr(),
+		}
+		for _, assetID := range dbRow.AssetIds {
+			row.AssetIDs = append(row.AssetIDs, int32(assetID))
+		}
+		job.Rows = append(job.Rows, row)
+	}
+	return job
+}
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"loriot-io/apiserver"
+	"loriot-io/appdb"
+	"strings"
+	"testing"
+)
+
+func TestJobRowDeviceRequest(t *testing.T) {
+	t.Setenv("SECRET_KEY", testSecretKey)
+	request := apiserver.PutDeviceRequest{
+		DevEUI:  "0004A30B001C0530",
+		AppKey:  "00112233445566778899AABBCCDDEEFF",
+		NwkSKey: "FFEEDDCCBBAA99887766554433221100",
+	}
+	encrypted := request
+	if err := encryptDeviceRequest(&encrypted); err != nil {
+		t.Fatal(err)
+	}
+	dbRow := appdb.JobRow{JobID: 1, RowNumber: 1}
+	if err := dbRow.Request.Marshal(encrypted); err != nil {
+		t.Fatal(err)
+	}
+	if stored := string(dbRow.Request.JSON); strings.Contains(stored, "00112233") || strings.Contains(stored, "FFEEDDCC") {
+		t.Errorf("keys stored in plaintext: %s", stored)
+	}
+	got, err := JobRowDeviceRequest(&dbRow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.AppKey != request.AppKey || got.NwkSKey != request.NwkSKey || got.AppSKey != "" {
+		t.Errorf("got %+v, want %+v", got, request)
+	}
+}
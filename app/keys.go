@@ -0,0 +1,205 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"loriot-io/apiserver"
+	"loriot-io/appdb"
+	"loriot-io/secret"
+	"strings"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-eliona/frontend"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// InsertKeyBatch remembers the keys of the devices as a new batch. The keys and owner tokens are stored encrypted.
+// Keys already loaded for a device are replaced and the device can be provisioned again. Batches loaded by an Eliona
+// user are recorded with the user.
+func InsertKeyBatch(ctx context.Context, name string, dbKeys []appdb.DeviceKey) (*apiserver.KeyBatch, error) {
+	dbBatch := appdb.KeyBatch{
+		Name:      name,
+		CreatedAt: time.Now(),
+	}
+	if env := frontend.GetEnvironment(ctx); env != nil {
+		dbBatch.UserID = null.StringFrom(env.UserId)
+	}
+	if err := dbBatch.InsertG(ctx, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("inserting key batch %s: %v", name, err)
+	}
+	for _, dbKey := range dbKeys {
+		dbKey.DevEui = strings.ToUpper(dbKey.DevEui)
+		dbKey.KeyBatchID = dbBatch.ID
+		dbKey.ProvisionedAt = null.Time{}
+		if err := encryptDeviceKey(&dbKey); err != nil {
+			return nil, errors.Join(err, DeleteKeyBatch(ctx, dbBatch.ID))
+		}
+		if err := dbKey.UpsertG(ctx, true, []string{appdb.DeviceKeyColumns.DevEui}, boil.Infer(), boil.Infer()); err != nil {
+			return nil, errors.Join(fmt.Errorf("inserting keys of device %s: %v", dbKey.DevEui, err), DeleteKeyBatch(ctx, dbBatch.ID))
+		}
+	}
+	return &apiserver.KeyBatch{
+		Id:        dbBatch.ID,
+		Name:      dbBatch.Name,
+		Devices:   int32(len(dbKeys)),
+		UserId:    dbBatch.UserID.Ptr(),
+		CreatedAt: common.Ptr(dbBatch.CreatedAt),
+	}, nil
+}
+
+// GetKeyBatches returns all key batches, newest first, with the number of their devices.
+func GetKeyBatches(ctx context.Context) ([]apiserver.KeyBatch, error) {
+	dbBatches, err := appdb.KeyBatches(
+		qm.Load(appdb.KeyBatchRels.DeviceKeys),
+		qm.OrderBy(appdb.KeyBatchColumns.ID+" desc"),
+	).AllG(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching key batches: %v", err)
+	}
+	batches := []apiserver.KeyBatch{}
+	for _, dbBatch := range dbBatches {
+		batch := apiserver.KeyBatch{
+			Id:        dbBatch.ID,
+			Name:      dbBatch.Name,
+			UserId:    dbBatch.UserID.Ptr(),
+			CreatedAt: common.Ptr(dbBatch.CreatedAt),
+		}
+		if dbBatch.R != nil {
+			batch.Devices = int32(len(dbBatch.R.DeviceKeys))
+			for _, dbKey := range dbBatch.R.DeviceKeys {
+				if dbKey.ProvisionedAt.Valid {
+					batch.Provisioned++
+				}
+			}
+		}
+		batches = append(batches, batch)
+	}
+	return batches, nil
+}
+
+// DeleteKeyBatch forgets the batch with the keys of all its devices.
+func DeleteKeyBatch(ctx context.Context, id int64) error {
+	count, err := appdb.KeyBatches(
+		appdb.KeyBatchWhere.ID.EQ(id),
+	).DeleteAllG(ctx)
+	if err != nil {
+		return fmt.Errorf("deleting key batch %d: %v", id, err)
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: key batch %d not found", ErrBadRequest, id)
+	}
+	return nil
+}
+
+// GetDeviceKey returns the loaded keys of the device decrypted, or nil if no keys are loaded.
+func GetDeviceKey(ctx context.Context, devEUI string) (*appdb.DeviceKey, error) {
+	dbKey, err := appdb.FindDeviceKeyG(ctx, strings.ToUpper(devEUI))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching keys of device %s: %v", devEUI, err)
+	}
+	if err := decryptDeviceKey(dbKey); err != nil {
+		return nil, err
+	}
+	return dbKey, nil
+}
+
+// SetDeviceKeyProvisioned remembers that the device was provisioned with its keys.
+func SetDeviceKeyProvisioned(ctx context.Context, dbKey *appdb.DeviceKey) error {
+	dbKey.ProvisionedAt = null.TimeFrom(time.Now())
+	_, err := dbKey.UpdateG(ctx, boil.Whitelist(appdb.DeviceKeyColumns.ProvisionedAt))
+	if err != nil {
+		return fmt.Errorf("updating keys of device %s: %v", dbKey.DevEui, err)
+	}
+	return nil
+}
+
+// EncryptDeviceKeys encrypts the loaded keys stored unencrypted, i.e. loaded before the keys were encrypted.
+func EncryptDeviceKeys(ctx context.Context) error {
+	dbKeys, err := appdb.DeviceKeys(
+		qm.Where(appdb.DeviceKeyColumns.AppKey+" not like ?", secret.EncryptedPrefix+"%"),
+	).AllG(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching unencrypted device keys: %v", err)
+	}
+	if len(dbKeys) == 0 {
+		return nil
+	}
+	if !secret.Configured() {
+		log.Warn("app", "Loaded keys of %d devices are stored unencrypted: %v", len(dbKeys), secret.ErrNotConfigured)
+		return nil
+	}
+	for _, dbKey := range dbKeys {
+		if err := encryptDeviceKey(dbKey); err != nil {
+			return err
+		}
+		if _, err := dbKey.UpdateG(ctx, boil.Whitelist(deviceKeySecretColumns...)); err != nil {
+			return fmt.Errorf("updating keys of device %s: %v", dbKey.DevEui, err)
+		}
+	}
+	log.Info("app", "Encrypted the loaded keys of %d devices", len(dbKeys))
+	return nil
+}
+
+// deviceKeySecretColumns are the columns of the loaded device keys stored encrypted.
+var deviceKeySecretColumns = []string{
+	appdb.DeviceKeyColumns.AppKey,
+	appdb.DeviceKeyColumns.NWKKey,
+	appdb.DeviceKeyColumns.OwnerToken,
+}
+
+// encryptDeviceKey encrypts the keys and the owner token of the device.
+func encryptDeviceKey(dbKey *appdb.DeviceKey) error {
+	if err := cryptDeviceKey(dbKey, secret.EncryptString); err != nil {
+		return fmt.Errorf("encrypting keys of device %s: %w", dbKey.DevEui, err)
+	}
+	return nil
+}
+
+// decryptDeviceKey decrypts the keys and the owner token of the device. Values stored before the keys were encrypted
+// are kept unchanged.
+func decryptDeviceKey(dbKey *appdb.DeviceKey) error {
+	if err := cryptDeviceKey(dbKey, secret.DecryptString); err != nil {
+		return fmt.Errorf("decrypting keys of device %s: %w", dbKey.DevEui, err)
+	}
+	return nil
+}
+
+func cryptDeviceKey(dbKey *appdb.DeviceKey, crypt func(string) (string, error)) error {
+	var err error
+	if dbKey.AppKey, err = crypt(dbKey.AppKey); err != nil {
+		return err
+	}
+	for _, value := range []*null.String{&dbKey.NWKKey, &dbKey.OwnerToken} {
+		if !value.Valid {
+			continue
+		}
+		if value.String, err = crypt(value.String); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"loriot-io/appdb"
+	"strings"
+	"testing"
+
+	"github.com/volatiletech/null/v8"
+)
+
+const testSecretKey = "BwcHBwcHBwcHBwcHBwcHBwcHBwcHBwcHBwcHBwcHBwc="
+
+func TestEncryptDeviceKey(t *testing.T) {
+	t.Setenv("SECRET_KEY", testSecretKey)
+	dbKey := appdb.DeviceKey{
+		DevEui:     "0004A30B001C0530",
+		AppKey:     "00112233445566778899AABBCCDDEEFF",
+		NWKKey:     null.StringFrom("FFEEDDCCBBAA99887766554433221100"),
+		OwnerToken: null.StringFrom("AABB1122"),
+	}
+	encrypted := dbKey
+	if err := encryptDeviceKey(&encrypted); err != nil {
+		t.Fatal(err)
+	}
+	for _, value := range []string{encrypted.AppKey, encrypted.NWKKey.String, encrypted.OwnerToken.String} {
+		if strings.Contains(value, "00112233") || strings.Contains(value, "FFEEDDCC") || strings.Contains(value, "AABB1122") {
+			t.Errorf("value stored in plaintext: %s", value)
+		}
+	}
+	if err := decryptDeviceKey(&encrypted); err != nil {
+		t.Fatal(err)
+	}
+	if encrypted != dbKey {
+		t.Errorf("got %+v, want %+v", encrypted, dbKey)
+	}
+}
+
+func TestDecryptUnencryptedDeviceKey(t *testing.T) {
+	t.Setenv("SECRET_KEY", testSecretKey)
+	dbKey := appdb.DeviceKey{DevEui: "0004A30B001C0530", AppKey: "00112233445566778899AABBCCDDEEFF"}
+	decrypted := dbKey
+	if err := decryptDeviceKey(&decrypted); err != nil {
+		t.Fatal(err)
+	}
+	if decrypted != dbKey {
+		t.Errorf("got %+v, want %+v", decrypted, dbKey)
+	}
+}
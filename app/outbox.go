@@ -0,0 +1,238 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"loriot-io/apiserver"
+	"loriot-io/appdb"
+	"strings"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const (
+	OutboxOperationUpdate = "update"
+	OutboxOperationDelete = "delete"
+)
+
+const (
+	OutboxStatusPending   = "pending"
+	OutboxStatusDone      = "done"
+	OutboxStatusDead      = "dead"
+	OutboxStatusDiscarded = "discarded"
+)
+
+// maxOutboxOperations limits the number of outbox operations returned by the API.
+const maxOutboxOperations = 1000
+
+// InsertOutboxOperation remembers a pending operation for the device in Loriot.io caused by the change of the asset.
+func InsertOutboxOperation(ctx context.Context, configID int64, devEUI string, operation string, asset api.Asset) (*appdb.Outbox, error) {
+	if asset.Id.Get() == nil {
+		return nil, fmt.Errorf("no asset id present for %s operation of device %s", operation, devEUI)
+	}
+	dbOutbox := appdb.Outbox{
+		ConfigurationID: configID,
+		AssetID:         *asset.Id.Get(),
+		DevEui:          strings.ToUpper(devEUI),
+		Operation:       operation,
+		Status:          OutboxStatusPending,
+		CreatedAt:       time.Now(),
+	}
+	dbOutbox.NextAttemptAt = dbOutbox.CreatedAt
+	dbOutbox.ModifiedAt = null.TimeFrom(dbOutbox.CreatedAt)
+	if err := dbOutbox.Asset.Marshal(asset); err != nil {
+		return nil, fmt.Errorf("marshalling asset for %s operation of device %s: %v", operation, devEUI, err)
+	}
+	if err := dbOutbox.InsertG(ctx, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("inserting %s operation for device %s: %v", operation, devEUI, err)
+	}
+	return &dbOutbox, nil
+}
+
+// OutboxAsset returns the changed asset the operation was created for.
+func OutboxAsset(dbOutbox *appdb.Outbox) (api.Asset, error) {
+	var asset api.Asset
+	if err := dbOutbox.Asset.Unmarshal(&asset); err != nil {
+		return asset, fmt.Errorf("unmarshalling asset of outbox operation %d: %v", dbOutbox.ID, err)
+	}
+	return asset, nil
+}
+
+// GetPendingOutboxOperations returns the pending operations of the configuration in the order they were created.
+func GetPendingOutboxOperations(ctx context.Context, configID int64) ([]*appdb.Outbox, error) {
+	dbOutboxes, err := appdb.Outboxes(
+		appdb.OutboxWhere.ConfigurationID.EQ(configID),
+		appdb.OutboxWhere.Status.EQ(OutboxStatusPending),
+		qm.OrderBy(appdb.OutboxColumns.ID),
+	).AllG(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching pending outbox operations of config %d: %v", configID, err)
+	}
+	return dbOutboxes, nil
+}
+
+// GetOpenOutboxAssetIDs returns the IDs of the assets with pending or dead operations, whose changes are not yet
+// performed in Loriot.io.
+func GetOpenOutboxAssetIDs(ctx context.Context) (map[int32]bool, error) {
+	dbOutboxes, err := appdb.Outboxes(
+		qm.Select(appdb.OutboxColumns.AssetID),
+		appdb.OutboxWhere.Status.IN([]string{OutboxStatusPending, OutboxStatusDead}),
+	).AllG(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching open outbox operations: %v", err)
+	}
+	assetIDs := make(map[int32]bool)
+	for _, dbOutbox := range dbOutboxes {
+		assetIDs[dbOutbox.AssetID] = true
+	}
+	return assetIDs, nil
+}
+
+// SetOutboxOperationDone marks the operation as successfully performed.
+func SetOutboxOperationDone(ctx context.Context, dbOutbox *appdb.Outbox) error {
+	dbOutbox.Status = OutboxStatusDone
+	dbOutbox.ModifiedAt = null.TimeFrom(time.Now())
+	_, err := dbOutbox.UpdateG(ctx, boil.Whitelist(appdb.OutboxColumns.Status, appdb.OutboxColumns.ModifiedAt))
+	if err != nil {
+		return fmt.Errorf("updating status of outbox operation %d: %v", dbOutbox.ID, err)
+	}
+	return nil
+}
+
+// SetOutboxOperationFailed records a failed attempt of the operation. The operation is attempted again at the given
+// time, or moved to the dead letters if dead.
+func SetOutboxOperationFailed(ctx context.Context, dbOutbox *appdb.Outbox, attemptErr error, nextAttemptAt time.Time, dead bool) error {
+	dbOutbox.Attempts++
+	dbOutbox.LastError = null.StringFrom(attemptErr.Error())
+	dbOutbox.NextAttemptAt = nextAttemptAt
+	if dead {
+		dbOutbox.Status = OutboxStatusDead
+	}
+	dbOutbox.ModifiedAt = null.TimeFrom(time.Now())
+	_, err := dbOutbox.UpdateG(ctx, boil.Whitelist(
+		appdb.OutboxColumns.Attempts,
+		appdb.OutboxColumns.LastError,
+		appdb.OutboxColumns.NextAttemptAt,
+		appdb.OutboxColumns.Status,
+		appdb.OutboxColumns.ModifiedAt,
+	))
+	if err != nil {
+		return fmt.Errorf("updating status of outbox operation %d: %v", dbOutbox.ID, err)
+	}
+	return nil
+}
+
+// GetOutboxOperations returns the latest outbox operations, newest first. If status is not empty only operations
+// with this status are returned.
+func GetOutboxOperations(ctx context.Context, status string) ([]apiserver.OutboxOperation, error) {
+	mods := []qm.QueryMod{
+		qm.OrderBy(appdb.OutboxColumns.ID + " desc"),
+		qm.Limit(maxOutboxOperations),
+	}
+	if status != "" {
+		mods = append(mods, appdb.OutboxWhere.Status.EQ(status))
+	}
+	dbOutboxes, err := appdb.Outboxes(mods...).AllG(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching outbox operations: %v", err)
+	}
+	operations := []apiserver.OutboxOperation{}
+	for _, dbOutbox := range dbOutboxes {
+		operations = append(operations, apiOutboxOperationFromDbOutbox(dbOutbox))
+	}
+	return operations, nil
+}
+
+// RetryOutboxOperation makes a pending or dead operation due immediately with a fresh number of attempts.
+func RetryOutboxOperation(ctx context.Context, id int64) (*apiserver.OutboxOperation, error) {
+	dbOutbox, err := getOpenOutboxOperation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	dbOutbox.Status = OutboxStatusPending
+	dbOutbox.Attempts = 0
+	dbOutbox.NextAttemptAt = time.Now()
+	dbOutbox.ModifiedAt = null.TimeFrom(dbOutbox.NextAttemptAt)
+	_, err = dbOutbox.UpdateG(ctx, boil.Whitelist(
+		appdb.OutboxColumns.Status,
+		appdb.OutboxColumns.Attempts,
+		appdb.OutboxColumns.NextAttemptAt,
+		appdb.OutboxColumns.ModifiedAt,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("retrying outbox operation %d: %v", id, err)
+	}
+	return common.Ptr(apiOutboxOperationFromDbOutbox(dbOutbox)), nil
+}
+
+// DiscardOutboxOperation gives up a pending or dead operation without performing it.
+func DiscardOutboxOperation(ctx context.Context, id int64) error {
+	dbOutbox, err := getOpenOutboxOperation(ctx, id)
+	if err != nil {
+		return err
+	}
+	dbOutbox.Status = OutboxStatusDiscarded
+	dbOutbox.ModifiedAt = null.TimeFrom(time.Now())
+	_, err = dbOutbox.UpdateG(ctx, boil.Whitelist(appdb.OutboxColumns.Status, appdb.OutboxColumns.ModifiedAt))
+	if err != nil {
+		return fmt.Errorf("discarding outbox operation %d: %v", id, err)
+	}
+	return nil
+}
+
+// getOpenOutboxOperation returns the operation if it is pending or dead. Unknown operations return ErrNotFound, other
+// operations ErrBadRequest.
+func getOpenOutboxOperation(ctx context.Context, id int64) (*appdb.Outbox, error) {
+	dbOutbox, err := appdb.FindOutboxG(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: outbox operation %d not found", ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching outbox operation %d: %v", id, err)
+	}
+	if dbOutbox.Status != OutboxStatusPending && dbOutbox.Status != OutboxStatusDead {
+		return nil, fmt.Errorf("%w: outbox operation %d is %s", ErrBadRequest, id, dbOutbox.Status)
+	}
+	return dbOutbox, nil
+}
+
+func apiOutboxOperationFromDbOutbox(dbOutbox *appdb.Outbox) apiserver.OutboxOperation {
+	operation := apiserver.OutboxOperation{
+		Id:         dbOutbox.ID,
+		ConfigID:   dbOutbox.ConfigurationID,
+		AssetID:    dbOutbox.AssetID,
+		DevEUI:     dbOutbox.DevEui,
+		Operation:  dbOutbox.Operation,
+		Status:     dbOutbox.Status,
+		Attempts:   dbOutbox.Attempts,
+		LastError:  dbOutbox.LastError.Ptr(),
+		CreatedAt:  common.Ptr(dbOutbox.CreatedAt),
+		ModifiedAt: dbOutbox.ModifiedAt.Ptr(),
+	}
+	if dbOutbox.Status == OutboxStatusPending {
+		operation.NextAttemptAt = common.Ptr(dbOutbox.NextAttemptAt)
+	}
+	return operation
+}
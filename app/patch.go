@@ -0,0 +1,67 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"loriot-io/apiserver"
+)
+
+// mergePatchConfig applies the JSON merge patch to the configuration. Unknown properties are rejected.
+func mergePatchConfig(config apiserver.Configuration, patch map[string]interface{}) (apiserver.Configuration, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return config, fmt.Errorf("marshalling config: %v", err)
+	}
+	var target map[string]interface{}
+	if err := json.Unmarshal(data, &target); err != nil {
+		return config, fmt.Errorf("unmarshalling config: %v", err)
+	}
+	data, err = json.Marshal(mergePatch(target, patch))
+	if err != nil {
+		return config, fmt.Errorf("marshalling patched config: %v", err)
+	}
+	var patched apiserver.Configuration
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&patched); err != nil {
+		return config, fmt.Errorf("invalid patch: %v", err)
+	}
+	return patched, nil
+}
+
+// mergePatch applies the merge patch to the target as defined by RFC 7396. Members of the patch set to null are
+// removed from the target, objects are merged recursively and all other values replace the target.
+func mergePatch(target interface{}, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{})
+	}
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+		targetObject[name] = mergePatch(targetObject[name], value)
+	}
+	return targetObject
+}
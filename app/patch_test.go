@@ -0,0 +1,65 @@
+package app
+
+import (
+	"loriot-io/apiserver"
+	"reflect"
+	"testing"
+)
+
+func TestMergePatch(t *testing.T) {
+	target := map[string]interface{}{
+		"a": "b",
+		"c": map[string]interface{}{"d": "e", "f": "g"},
+		"h": []interface{}{"i"},
+	}
+	patch := map[string]interface{}{
+		"a": "z",
+		"c": map[string]interface{}{"f": nil},
+		"h": []interface{}{"j", "k"},
+		"l": "m",
+	}
+	want := map[string]interface{}{
+		"a": "z",
+		"c": map[string]interface{}{"d": "e"},
+		"h": []interface{}{"j", "k"},
+		"l": "m",
+	}
+	if got := mergePatch(target, patch); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestMergePatchConfig(t *testing.T) {
+	enable := true
+	refreshInterval := int32(60)
+	projectIDs := []string{"1", "2"}
+	config := apiserver.Configuration{
+		ApiBaseUrl:      "https://eu1.loriot.io",
+		ApiToken:        "token",
+		Enable:          &enable,
+		RefreshInterval: refreshInterval,
+		ProjectIDs:      &projectIDs,
+	}
+	patched, err := mergePatchConfig(config, map[string]interface{}{
+		"enable":     false,
+		"projectIDs": nil,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patched.Enable == nil || *patched.Enable || patched.ProjectIDs != nil {
+		t.Errorf("patch not applied: %+v", patched)
+	}
+	if patched.ApiBaseUrl != config.ApiBaseUrl || patched.ApiToken != config.ApiToken || patched.RefreshInterval != refreshInterval {
+		t.Errorf("unpatched properties changed: %+v", patched)
+	}
+	if !*config.Enable || config.ProjectIDs == nil {
+		t.Errorf("original config modified: %+v", config)
+	}
+}
+
+func TestMergePatchConfigUnknownProperty(t *testing.T) {
+	if _, err := mergePatchConfig(apiserver.Configuration{}, map[string]interface{}{"unknown": 1}); err == nil {
+		t.Error("expected error for unknown property")
+	}
+}
@@ -0,0 +1,198 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"fmt"
+	"loriot-io/apiserver"
+	"regexp"
+	"strings"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+var devEUIPrefixRegex = regexp.MustCompile(`^[A-Fa-f0-9]{1,16}$`)
+
+// RouteDevice returns the projects the assets of a device are created in and the asset type of discovered devices.
+// Without routing rules, devices are routed to all projects of the configuration. With routing rules, the device is
+// routed by the rule it matches, and devices matching no rule aren't routed at all.
+func RouteDevice(config apiserver.Configuration, appID string, devEUI string, title string) (projectIDs []string, assetType string, routed bool) {
+	if len(config.RoutingRules) == 0 {
+		return ProjIds(config), defaultAssetType(config, appID), true
+	}
+	for _, rule := range config.RoutingRules {
+		if !matchesRoutingRule(rule, appID, devEUI, title) {
+			continue
+		}
+		assetType = defaultAssetType(config, appID)
+		if rule.AssetType != nil && *rule.AssetType != "" {
+			assetType = *rule.AssetType
+		}
+		return rule.ProjectIDs, assetType, true
+	}
+	return nil, "", false
+}
+
+func defaultAssetType(config apiserver.Configuration, appID string) string {
+	for id, assetType := range config.DefaultAssetTypes {
+		if strings.EqualFold(id, appID) {
+			return assetType
+		}
+	}
+	return ""
+}
+
+func matchesRoutingRule(rule apiserver.RoutingRule, appID string, devEUI string, title string) bool {
+	if rule.AppID != nil && !strings.EqualFold(*rule.AppID, appID) {
+		return false
+	}
+	if rule.DevEUIPrefix != nil && !strings.HasPrefix(strings.ToUpper(devEUI), strings.ToUpper(*rule.DevEUIPrefix)) {
+		return false
+	}
+	if rule.TitlePattern != nil && !matchTitlePattern([]rune(strings.ToLower(*rule.TitlePattern)), []rune(strings.ToLower(title))) {
+		return false
+	}
+	return true
+}
+
+// normalizeRoutingRules validates the routing rules of the configuration and removes empty criteria. The rules have
+// to route to projects of the configuration and must not overlap, so each device is routed by one rule at most.
+func normalizeRoutingRules(config apiserver.Configuration) ([]apiserver.RoutingRule, error) {
+	var rules []apiserver.RoutingRule
+	for i, rule := range config.RoutingRules {
+		rule.AppID = nonEmpty(rule.AppID)
+		rule.DevEUIPrefix = nonEmpty(rule.DevEUIPrefix)
+		rule.TitlePattern = nonEmpty(rule.TitlePattern)
+		rule.AssetType = nonEmpty(rule.AssetType)
+		if rule.DevEUIPrefix != nil {
+			if !devEUIPrefixRegex.MatchString(*rule.DevEUIPrefix) {
+				return nil, fmt.Errorf("%w: routing rule %d: invalid device EUI prefix '%s'", ErrBadRequest, i+1, *rule.DevEUIPrefix)
+			}
+			rule.DevEUIPrefix = common.Ptr(strings.ToUpper(*rule.DevEUIPrefix))
+		}
+		if len(rule.ProjectIDs) == 0 {
+			return nil, fmt.Errorf("%w: routing rule %d has no project IDs", ErrBadRequest, i+1)
+		}
+		for _, projectID := range rule.ProjectIDs {
+			if !containsString(ProjIds(config), projectID) {
+				return nil, fmt.Errorf("%w: routing rule %d: project %s is not a project of the configuration", ErrBadRequest, i+1, projectID)
+			}
+		}
+		for j, other := range rules {
+			if routingRulesOverlap(other, rule) {
+				return nil, fmt.Errorf("%w: routing rules %d and %d overlap", ErrBadRequest, j+1, i+1)
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// routingRulesOverlap checks if a device could match both rules. Rules don't overlap if they differ in at least one
+// criterion given by both rules.
+func routingRulesOverlap(a apiserver.RoutingRule, b apiserver.RoutingRule) bool {
+	if a.AppID != nil && b.AppID != nil && !strings.EqualFold(*a.AppID, *b.AppID) {
+		return false
+	}
+	if a.DevEUIPrefix != nil && b.DevEUIPrefix != nil {
+		prefixA, prefixB := strings.ToUpper(*a.DevEUIPrefix), strings.ToUpper(*b.DevEUIPrefix)
+		if !strings.HasPrefix(prefixA, prefixB) && !strings.HasPrefix(prefixB, prefixA) {
+			return false
+		}
+	}
+	if a.TitlePattern != nil && b.TitlePattern != nil &&
+		!titlePatternsOverlap([]rune(strings.ToLower(*a.TitlePattern)), []rune(strings.ToLower(*b.TitlePattern))) {
+		return false
+	}
+	return true
+}
+
+// matchTitlePattern checks if the title matches the pattern, where `*` matches any text and `?` any single character.
+func matchTitlePattern(pattern []rune, title []rune) bool {
+	p, t := 0, 0
+	star, starT := -1, 0
+	for t < len(title) {
+		switch {
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == title[t]):
+			p++
+			t++
+		case p < len(pattern) && pattern[p] == '*':
+			star, starT = p, t
+			p++
+		case star >= 0:
+			// Let the last star match one more character
+			starT++
+			p, t = star+1, starT
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+// titlePatternsOverlap checks if a title exists matching both patterns. Both patterns are read in parallel like the
+// characters of such a title, where a star may match any number of characters of the other pattern.
+func titlePatternsOverlap(a []rune, b []rune) bool {
+	type state struct{ i, j int }
+	visited := make(map[state]bool)
+	var overlap func(i, j int) bool
+	overlap = func(i, j int) bool {
+		if visited[state{i, j}] {
+			return false
+		}
+		visited[state{i, j}] = true
+		if i == len(a) && j == len(b) {
+			return true
+		}
+		if i < len(a) && a[i] == '*' {
+			// The star matches no more characters, or the next character of the other pattern
+			if overlap(i+1, j) || (j < len(b) && overlap(i, j+1)) {
+				return true
+			}
+		}
+		if j < len(b) && b[j] == '*' {
+			if overlap(i, j+1) || (i < len(a) && overlap(i+1, j)) {
+				return true
+			}
+		}
+		if i < len(a) && j < len(b) && a[i] != '*' && b[j] != '*' {
+			if a[i] == '?' || b[j] == '?' || a[i] == b[j] {
+				return overlap(i+1, j+1)
+			}
+		}
+		return false
+	}
+	return overlap(0, 0)
+}
+
+func nonEmpty(s *string) *string {
+	if s == nil || strings.TrimSpace(*s) == "" {
+		return nil
+	}
+	return s
+}
+
+func containsString(slice []string, s string) bool {
+	for _, element := range slice {
+		if element == s {
+			return true
+		}
+	}
+	return false
+}
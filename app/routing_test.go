@@ -0,0 +1,136 @@
+package app
+
+import (
+	"errors"
+	"loriot-io/apiserver"
+	"reflect"
+	"testing"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+func TestRouteDevice(t *testing.T) {
+	config := apiserver.Configuration{
+		ProjectIDs:        &[]string{"10", "11", "12"},
+		DefaultAssetTypes: map[string]string{"BE7A0001": "loriot_io_cayenne_lpp"},
+		RoutingRules: []apiserver.RoutingRule{
+			{AppID: common.Ptr("be7a0001"), TitlePattern: common.Ptr("Room *"), ProjectIDs: []string{"10"}},
+			{AppID: common.Ptr("BE7A0001"), TitlePattern: common.Ptr("Floor ?"), ProjectIDs: []string{"11"}, AssetType: common.Ptr("floor_sensor")},
+			{DevEUIPrefix: common.Ptr("70B3D5"), ProjectIDs: []string{"11", "12"}},
+		},
+	}
+	tests := []struct {
+		appID, devEUI, title string
+		wantProjectIDs       []string
+		wantAssetType        string
+		wantRouted           bool
+	}{
+		{"BE7A0001", "0004A30B001C0530", "room 42", []string{"10"}, "loriot_io_cayenne_lpp", true},
+		{"BE7A0001", "0004A30B001C0530", "Floor 3", []string{"11"}, "floor_sensor", true},
+		{"BE7A0002", "70b3d57ed0000001", "Room 42", []string{"11", "12"}, "", true},
+		{"BE7A0001", "0004A30B001C0530", "Floor 13", nil, "", false},
+	}
+	for _, tt := range tests {
+		projectIDs, assetType, routed := RouteDevice(config, tt.appID, tt.devEUI, tt.title)
+		if !reflect.DeepEqual(projectIDs, tt.wantProjectIDs) || assetType != tt.wantAssetType || routed != tt.wantRouted {
+			t.Errorf("RouteDevice(%s, %s, %s) = %v, %s, %v, want %v, %s, %v", tt.appID, tt.devEUI, tt.title,
+				projectIDs, assetType, routed, tt.wantProjectIDs, tt.wantAssetType, tt.wantRouted)
+		}
+	}
+}
+
+func TestRouteDeviceWithoutRules(t *testing.T) {
+	config := apiserver.Configuration{ProjectIDs: &[]string{"10", "11"}}
+	projectIDs, _, routed := RouteDevice(config, "BE7A0001", "0004A30B001C0530", "Room 42")
+	if !routed || !reflect.DeepEqual(projectIDs, []string{"10", "11"}) {
+		t.Errorf("got %v, %v, want all projects", projectIDs, routed)
+	}
+}
+
+func TestNormalizeRoutingRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   []apiserver.RoutingRule
+		wantErr bool
+	}{
+		{"different applications", []apiserver.RoutingRule{
+			{AppID: common.Ptr("BE7A0001"), ProjectIDs: []string{"10"}},
+			{AppID: common.Ptr("BE7A0002"), ProjectIDs: []string{"11"}},
+		}, false},
+		{"disjoint prefixes", []apiserver.RoutingRule{
+			{DevEUIPrefix: common.Ptr("70B3D5"), ProjectIDs: []string{"10"}},
+			{DevEUIPrefix: common.Ptr("0004a3"), ProjectIDs: []string{"11"}},
+		}, false},
+		{"nested prefixes", []apiserver.RoutingRule{
+			{DevEUIPrefix: common.Ptr("70B3D5"), ProjectIDs: []string{"10"}},
+			{DevEUIPrefix: common.Ptr("70b3"), ProjectIDs: []string{"11"}},
+		}, true},
+		{"disjoint title patterns", []apiserver.RoutingRule{
+			{TitlePattern: common.Ptr("Room *"), ProjectIDs: []string{"10"}},
+			{TitlePattern: common.Ptr("Floor *"), ProjectIDs: []string{"11"}},
+		}, false},
+		{"overlapping title patterns", []apiserver.RoutingRule{
+			{TitlePattern: common.Ptr("Room *"), ProjectIDs: []string{"10"}},
+			{TitlePattern: common.Ptr("* sensor"), ProjectIDs: []string{"11"}},
+		}, true},
+		{"catch-all rule", []apiserver.RoutingRule{
+			{AppID: common.Ptr("BE7A0001"), ProjectIDs: []string{"10"}},
+			{AppID: common.Ptr(""), ProjectIDs: []string{"11"}},
+		}, true},
+		{"unknown project", []apiserver.RoutingRule{
+			{AppID: common.Ptr("BE7A0001"), ProjectIDs: []string{"99"}},
+		}, true},
+		{"no project", []apiserver.RoutingRule{
+			{AppID: common.Ptr("BE7A0001")},
+		}, true},
+		{"invalid prefix", []apiserver.RoutingRule{
+			{DevEUIPrefix: common.Ptr("XYZ"), ProjectIDs: []string{"10"}},
+		}, true},
+	}
+	for _, tt := range tests {
+		config := apiserver.Configuration{ProjectIDs: &[]string{"10", "11"}, RoutingRules: tt.rules}
+		_, err := normalizeRoutingRules(config)
+		if tt.wantErr && !errors.Is(err, ErrBadRequest) {
+			t.Errorf("%s: got %v, want bad request", tt.name, err)
+		}
+		if !tt.wantErr && err != nil {
+			t.Errorf("%s: got %v, want no error", tt.name, err)
+		}
+	}
+}
+
+func TestTitlePatterns(t *testing.T) {
+	matches := []struct {
+		pattern, title string
+		want           bool
+	}{
+		{"room *", "room 42", true},
+		{"room ?", "room 42", false},
+		{"*sensor*", "co2 sensor 3", true},
+		{"a*b*c", "abacbc", true},
+		{"a*b*c", "abacb", false},
+		{"", "", true},
+	}
+	for _, tt := range matches {
+		if got := matchTitlePattern([]rune(tt.pattern), []rune(tt.title)); got != tt.want {
+			t.Errorf("matchTitlePattern(%q, %q) = %v, want %v", tt.pattern, tt.title, got, tt.want)
+		}
+	}
+
+	overlaps := []struct {
+		a, b string
+		want bool
+	}{
+		{"room *", "* sensor", true},
+		{"room *", "floor *", false},
+		{"room ?", "room 42", false},
+		{"room ??", "room 4*", true},
+		{"*a", "*b", false},
+		{"*", "anything", true},
+	}
+	for _, tt := range overlaps {
+		if got := titlePatternsOverlap([]rune(tt.a), []rune(tt.b)); got != tt.want {
+			t.Errorf("titlePatternsOverlap(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
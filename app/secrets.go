@@ -0,0 +1,119 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"loriot-io/appdb"
+	"loriot-io/secret"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// RotateSecretKey re-encrypts the API tokens of all configurations, the device keys in the vault, the loaded device
+// keys of the key batches and the keys of pending import rows with the key defined by SECRET_KEY. Values encrypted
+// with the key defined by SECRET_KEY_PREVIOUS and unencrypted values are read as well. All values are re-encrypted
+// in one transaction.
+func RotateSecretKey(ctx context.Context) (configs int, vaults int, deviceKeys int, importRows int, err error) {
+	if !secret.Configured() {
+		return 0, 0, 0, 0, secret.ErrNotConfigured
+	}
+	tx, err := boil.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, 0, 0, 0, fmt.Errorf("starting transaction: %v", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	dbConfigs, err := appdb.Configurations().All(ctx, tx)
+	if err != nil {
+		return 0, 0, 0, 0, fmt.Errorf("fetching configs: %v", err)
+	}
+	for _, dbConfig := range dbConfigs {
+		token, err := secret.DecryptString(dbConfig.APIToken)
+		if err != nil {
+			return 0, 0, 0, 0, fmt.Errorf("decrypting API token of config %d: %v", dbConfig.ID, err)
+		}
+		if dbConfig.APIToken, err = secret.EncryptString(token); err != nil {
+			return 0, 0, 0, 0, fmt.Errorf("encrypting API token of config %d: %v", dbConfig.ID, err)
+		}
+		if _, err := dbConfig.Update(ctx, tx, boil.Whitelist(appdb.ConfigurationColumns.APIToken)); err != nil {
+			return 0, 0, 0, 0, fmt.Errorf("updating API token of config %d: %v", dbConfig.ID, err)
+		}
+	}
+
+	dbVaults, err := appdb.KeyVaults().All(ctx, tx)
+	if err != nil {
+		return 0, 0, 0, 0, fmt.Errorf("fetching key vault: %v", err)
+	}
+	for _, dbVault := range dbVaults {
+		keys, err := secret.Decrypt(dbVault.Keys)
+		if err != nil {
+			return 0, 0, 0, 0, fmt.Errorf("decrypting keys of device %s: %v", dbVault.DevEui, err)
+		}
+		if dbVault.Keys, err = secret.Encrypt(keys); err != nil {
+			return 0, 0, 0, 0, fmt.Errorf("encrypting keys of device %s: %v", dbVault.DevEui, err)
+		}
+		if _, err := dbVault.Update(ctx, tx, boil.Whitelist(appdb.KeyVaultColumns.Keys)); err != nil {
+			return 0, 0, 0, 0, fmt.Errorf("updating keys of device %s: %v", dbVault.DevEui, err)
+		}
+	}
+
+	dbKeys, err := appdb.DeviceKeys().All(ctx, tx)
+	if err != nil {
+		return 0, 0, 0, 0, fmt.Errorf("fetching device keys: %v", err)
+	}
+	for _, dbKey := range dbKeys {
+		if err := decryptDeviceKey(dbKey); err != nil {
+			return 0, 0, 0, 0, err
+		}
+		if err := encryptDeviceKey(dbKey); err != nil {
+			return 0, 0, 0, 0, err
+		}
+		if _, err := dbKey.Update(ctx, tx, boil.Whitelist(deviceKeySecretColumns...)); err != nil {
+			return 0, 0, 0, 0, fmt.Errorf("updating keys of device %s: %v", dbKey.DevEui, err)
+		}
+	}
+
+	dbRows, err := appdb.JobRows(appdb.JobRowWhere.Request.IsNotNull()).All(ctx, tx)
+	if err != nil {
+		return 0, 0, 0, 0, fmt.Errorf("fetching pending job rows: %v", err)
+	}
+	for _, dbRow := range dbRows {
+		request, err := JobRowDeviceRequest(dbRow)
+		if err != nil {
+			return 0, 0, 0, 0, err
+		}
+		if err := encryptDeviceRequest(&request); err != nil {
+			return 0, 0, 0, 0, fmt.Errorf("row %d of job %d: %w", dbRow.RowNumber, dbRow.JobID, err)
+		}
+		if err := dbRow.Request.Marshal(request); err != nil {
+			return 0, 0, 0, 0, fmt.Errorf("marshalling row %d of job %d: %v", dbRow.RowNumber, dbRow.JobID, err)
+		}
+		if _, err := dbRow.Update(ctx, tx, boil.Whitelist(appdb.JobRowColumns.Request)); err != nil {
+			return 0, 0, 0, 0, fmt.Errorf("updating row %d of job %d: %v", dbRow.RowNumber, dbRow.JobID, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, 0, 0, 0, fmt.Errorf("committing transaction: %v", err)
+	}
+	return len(dbConfigs), len(dbVaults), len(dbKeys), len(dbRows), nil
+}
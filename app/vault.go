@@ -0,0 +1,190 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"loriot-io/apiserver"
+	"loriot-io/appdb"
+	"loriot-io/secret"
+	"strings"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-eliona/frontend"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// StoreDeviceKeys encrypts the keys of the device and stores them in the key vault. Keys already stored and missing in
+// the given keys are kept. Returns the previous vault entry to restore on rollback, or nil if there was none.
+func StoreDeviceKeys(ctx context.Context, keys apiserver.DeviceKeys) (*appdb.KeyVault, error) {
+	devEUI := strings.ToUpper(keys.DevEUI)
+	previous, err := getDbKeyVault(ctx, devEUI)
+	if err != nil {
+		return nil, err
+	}
+	if previous != nil {
+		previousKeys, err := decryptDeviceKeys(previous)
+		if err != nil {
+			return nil, err
+		}
+		keys = mergeDeviceKeys(*previousKeys, keys)
+	}
+	keys.DevEUI = devEUI
+	keys.ModifiedAt = nil
+	plaintext, err := json.Marshal(keys)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling keys of device %s: %v", devEUI, err)
+	}
+	ciphertext, err := secret.Encrypt(plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("encrypting keys of device %s: %w", devEUI, err)
+	}
+	dbVault := appdb.KeyVault{
+		DevEui:     devEUI,
+		Keys:       ciphertext,
+		CreatedAt:  time.Now(),
+		ModifiedAt: null.TimeFrom(time.Now()),
+	}
+	if previous != nil {
+		dbVault.CreatedAt = previous.CreatedAt
+	}
+	if err := dbVault.UpsertG(ctx, true, []string{appdb.KeyVaultColumns.DevEui}, boil.Infer(), boil.Infer()); err != nil {
+		return nil, fmt.Errorf("storing keys of device %s: %v", devEUI, err)
+	}
+	return previous, nil
+}
+
+// RestoreDeviceKeys restores the vault entry of the device replaced by StoreDeviceKeys. Without previous entry the
+// keys of the device are removed from the vault.
+func RestoreDeviceKeys(ctx context.Context, devEUI string, previous *appdb.KeyVault) error {
+	if previous == nil {
+		if _, err := appdb.KeyVaults(appdb.KeyVaultWhere.DevEui.EQ(strings.ToUpper(devEUI))).DeleteAllG(ctx); err != nil {
+			return fmt.Errorf("deleting keys of device %s: %v", devEUI, err)
+		}
+		return nil
+	}
+	if err := previous.UpsertG(ctx, true, []string{appdb.KeyVaultColumns.DevEui}, boil.Infer(), boil.Infer()); err != nil {
+		return fmt.Errorf("restoring keys of device %s: %v", devEUI, err)
+	}
+	return nil
+}
+
+// RevealDeviceKeys decrypts the keys of the device stored in the vault. Each access is recorded with the Eliona user
+// requesting it.
+func RevealDeviceKeys(ctx context.Context, devEUI string) (*apiserver.DeviceKeys, error) {
+	devEUI = strings.ToUpper(devEUI)
+	dbVault, err := getDbKeyVault(ctx, devEUI)
+	if err != nil {
+		return nil, err
+	}
+	if dbVault == nil {
+		return nil, fmt.Errorf("%w: no keys stored for device %s", ErrNotFound, devEUI)
+	}
+	keys, err := decryptDeviceKeys(dbVault)
+	if err != nil {
+		return nil, err
+	}
+
+	dbAccess := appdb.KeyVaultAccess{
+		DevEui:     devEUI,
+		AccessedAt: time.Now(),
+	}
+	if env := frontend.GetEnvironment(ctx); env != nil {
+		dbAccess.UserID = null.StringFrom(env.UserId)
+	}
+	if err := dbAccess.InsertG(ctx, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("recording access to keys of device %s: %v", devEUI, err)
+	}
+	log.Info("app", "Keys of device %s revealed to user %s", devEUI, dbAccess.UserID.String)
+
+	keys.DevEUI = devEUI
+	keys.ModifiedAt = common.Ptr(dbVault.ModifiedAt.Time)
+	if !dbVault.ModifiedAt.Valid {
+		keys.ModifiedAt = common.Ptr(dbVault.CreatedAt)
+	}
+	return keys, nil
+}
+
+// HasDeviceKeys checks if keys of the device are stored in the vault.
+func HasDeviceKeys(ctx context.Context, devEUI string) (bool, error) {
+	exists, err := appdb.KeyVaultExistsG(ctx, strings.ToUpper(devEUI))
+	if err != nil {
+		return false, fmt.Errorf("checking keys of device %s: %v", devEUI, err)
+	}
+	return exists, nil
+}
+
+// IsDevEUIUsed checks if the app already knows a device with the EUI, by its assets, loaded keys or stored keys.
+func IsDevEUIUsed(ctx context.Context, devEUI string) (bool, error) {
+	devEUI = strings.ToUpper(devEUI)
+	exists, err := appdb.Assets(qm.Where("upper("+appdb.AssetColumns.DevEui+") = ?", devEUI)).ExistsG(ctx)
+	if err != nil || exists {
+		return exists, err
+	}
+	exists, err = appdb.DeviceKeyExistsG(ctx, devEUI)
+	if err != nil || exists {
+		return exists, err
+	}
+	return appdb.KeyVaultExistsG(ctx, devEUI)
+}
+
+func getDbKeyVault(ctx context.Context, devEUI string) (*appdb.KeyVault, error) {
+	dbVault, err := appdb.FindKeyVaultG(ctx, devEUI)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching keys of device %s: %v", devEUI, err)
+	}
+	return dbVault, nil
+}
+
+func decryptDeviceKeys(dbVault *appdb.KeyVault) (*apiserver.DeviceKeys, error) {
+	plaintext, err := secret.Decrypt(dbVault.Keys)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting keys of device %s: %w", dbVault.DevEui, err)
+	}
+	var keys apiserver.DeviceKeys
+	if err := json.Unmarshal(plaintext, &keys); err != nil {
+		return nil, fmt.Errorf("unmarshalling keys of device %s: %v", dbVault.DevEui, err)
+	}
+	return &keys, nil
+}
+
+// mergeDeviceKeys overwrites the previous keys with the keys given.
+func mergeDeviceKeys(previous apiserver.DeviceKeys, keys apiserver.DeviceKeys) apiserver.DeviceKeys {
+	merge := func(previous *string, key string) {
+		if key != "" {
+			*previous = key
+		}
+	}
+	merge(&previous.AppKey, keys.AppKey)
+	merge(&previous.NwkKey, keys.NwkKey)
+	merge(&previous.NwkSKey, keys.NwkSKey)
+	merge(&previous.AppSKey, keys.AppSKey)
+	merge(&previous.FNwkSIntKey, keys.FNwkSIntKey)
+	merge(&previous.SNwkSIntKey, keys.SNwkSIntKey)
+	merge(&previous.NwkSEncKey, keys.NwkSEncKey)
+	return previous
+}
@@ -24,57 +24,112 @@ import (
 
 // Asset is an object representing the database table.
 type Asset struct {
-	AssetID          int32      `boil:"asset_id" json:"asset_id" toml:"asset_id" yaml:"asset_id"`
-	ConfigurationID  int64      `boil:"configuration_id" json:"configuration_id" toml:"configuration_id" yaml:"configuration_id"`
-	ProjectID        string     `boil:"project_id" json:"project_id" toml:"project_id" yaml:"project_id"`
-	GlobalAssetID    string     `boil:"global_asset_id" json:"global_asset_id" toml:"global_asset_id" yaml:"global_asset_id"`
-	DevEui           string     `boil:"dev_eui" json:"dev_eui" toml:"dev_eui" yaml:"dev_eui"`
-	AppID            string     `boil:"app_id" json:"app_id" toml:"app_id" yaml:"app_id"`
-	ModifiedAt       null.Time  `boil:"modified_at" json:"modified_at,omitempty" toml:"modified_at" yaml:"modified_at,omitempty"`
-	LatestStatusCode null.Int32 `boil:"latest_status_code" json:"latest_status_code,omitempty" toml:"latest_status_code" yaml:"latest_status_code,omitempty"`
+	AssetID              int32       `boil:"asset_id" json:"asset_id" toml:"asset_id" yaml:"asset_id"`
+	ConfigurationID      int64       `boil:"configuration_id" json:"configuration_id" toml:"configuration_id" yaml:"configuration_id"`
+	ProjectID            string      `boil:"project_id" json:"project_id" toml:"project_id" yaml:"project_id"`
+	GlobalAssetID        string      `boil:"global_asset_id" json:"global_asset_id" toml:"global_asset_id" yaml:"global_asset_id"`
+	DevEui               string      `boil:"dev_eui" json:"dev_eui" toml:"dev_eui" yaml:"dev_eui"`
+	AppID                string      `boil:"app_id" json:"app_id" toml:"app_id" yaml:"app_id"`
+	ModifiedAt           null.Time   `boil:"modified_at" json:"modified_at,omitempty" toml:"modified_at" yaml:"modified_at,omitempty"`
+	LatestStatusCode     null.Int32  `boil:"latest_status_code" json:"latest_status_code,omitempty" toml:"latest_status_code" yaml:"latest_status_code,omitempty"`
+	AssetType            null.String `boil:"asset_type" json:"asset_type,omitempty" toml:"asset_type" yaml:"asset_type,omitempty"`
+	Decoder              null.String `boil:"decoder" json:"decoder,omitempty" toml:"decoder" yaml:"decoder,omitempty"`
+	LastDecodingError    null.String `boil:"last_decoding_error" json:"last_decoding_error,omitempty" toml:"last_decoding_error" yaml:"last_decoding_error,omitempty"`
+	LastDecodingErrorAt  null.Time   `boil:"last_decoding_error_at" json:"last_decoding_error_at,omitempty" toml:"last_decoding_error_at" yaml:"last_decoding_error_at,omitempty"`
+	ReportingInterval    null.Int32  `boil:"reporting_interval" json:"reporting_interval,omitempty" toml:"reporting_interval" yaml:"reporting_interval,omitempty"`
+	LastUplinkAt         null.Time   `boil:"last_uplink_at" json:"last_uplink_at,omitempty" toml:"last_uplink_at" yaml:"last_uplink_at,omitempty"`
+	ConnectionState      null.String `boil:"connection_state" json:"connection_state,omitempty" toml:"connection_state" yaml:"connection_state,omitempty"`
+	ConnectionStateSince null.Time   `boil:"connection_state_since" json:"connection_state_since,omitempty" toml:"connection_state_since" yaml:"connection_state_since,omitempty"`
+	AlarmRuleID          null.Int32  `boil:"alarm_rule_id" json:"alarm_rule_id,omitempty" toml:"alarm_rule_id" yaml:"alarm_rule_id,omitempty"`
+	AssetName            null.String `boil:"asset_name" json:"asset_name,omitempty" toml:"asset_name" yaml:"asset_name,omitempty"`
+	AssetDescription     null.String `boil:"asset_description" json:"asset_description,omitempty" toml:"asset_description" yaml:"asset_description,omitempty"`
 
 	R *assetR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L assetL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var AssetColumns = struct {
-	AssetID          string
-	ConfigurationID  string
-	ProjectID        string
-	GlobalAssetID    string
-	DevEui           string
-	AppID            string
-	ModifiedAt       string
-	LatestStatusCode string
+	AssetID              string
+	ConfigurationID      string
+	ProjectID            string
+	GlobalAssetID        string
+	DevEui               string
+	AppID                string
+	ModifiedAt           string
+	LatestStatusCode     string
+	AssetType            string
+	Decoder              string
+	LastDecodingError    string
+	LastDecodingErrorAt  string
+	ReportingInterval    string
+	LastUplinkAt         string
+	ConnectionState      string
+	ConnectionStateSince string
+	AlarmRuleID          string
+	AssetName            string
+	AssetDescription     string
 }{
-	AssetID:          "asset_id",
-	ConfigurationID:  "configuration_id",
-	ProjectID:        "project_id",
-	GlobalAssetID:    "global_asset_id",
-	DevEui:           "dev_eui",
-	AppID:            "app_id",
-	ModifiedAt:       "modified_at",
-	LatestStatusCode: "latest_status_code",
+	AssetID:              "asset_id",
+	ConfigurationID:      "configuration_id",
+	ProjectID:            "project_id",
+	GlobalAssetID:        "global_asset_id",
+	DevEui:               "dev_eui",
+	AppID:                "app_id",
+	ModifiedAt:           "modified_at",
+	LatestStatusCode:     "latest_status_code",
+	AssetType:            "asset_type",
+	Decoder:              "decoder",
+	LastDecodingError:    "last_decoding_error",
+	LastDecodingErrorAt:  "last_decoding_error_at",
+	ReportingInterval:    "reporting_interval",
+	LastUplinkAt:         "last_uplink_at",
+	ConnectionState:      "connection_state",
+	ConnectionStateSince: "connection_state_since",
+	AlarmRuleID:          "alarm_rule_id",
+	AssetName:            "asset_name",
+	AssetDescription:     "asset_description",
 }
 
 var AssetTableColumns = struct {
-	AssetID          string
-	ConfigurationID  string
-	ProjectID        string
-	GlobalAssetID    string
-	DevEui           string
-	AppID            string
-	ModifiedAt       string
-	LatestStatusCode string
+	AssetID              string
+	ConfigurationID      string
+	ProjectID            string
+	GlobalAssetID        string
+	DevEui               string
+	AppID                string
+	ModifiedAt           string
+	LatestStatusCode     string
+	AssetType            string
+	Decoder              string
+	LastDecodingError    string
+	LastDecodingErrorAt  string
+	ReportingInterval    string
+	LastUplinkAt         string
+	ConnectionState      string
+	ConnectionStateSince string
+	AlarmRuleID          string
+	AssetName            string
+	AssetDescription     string
 }{
-	AssetID:          "asset.asset_id",
-	ConfigurationID:  "asset.configuration_id",
-	ProjectID:        "asset.project_id",
-	GlobalAssetID:    "asset.global_asset_id",
-	DevEui:           "asset.dev_eui",
-	AppID:            "asset.app_id",
-	ModifiedAt:       "asset.modified_at",
-	LatestStatusCode: "asset.latest_status_code",
+	AssetID:              "asset.asset_id",
+	ConfigurationID:      "asset.configuration_id",
+	ProjectID:            "asset.project_id",
+	GlobalAssetID:        "asset.global_asset_id",
+	DevEui:               "asset.dev_eui",
+	AppID:                "asset.app_id",
+	ModifiedAt:           "asset.modified_at",
+	LatestStatusCode:     "asset.latest_status_code",
+	AssetType:            "asset.asset_type",
+	Decoder:              "asset.decoder",
+	LastDecodingError:    "asset.last_decoding_error",
+	LastDecodingErrorAt:  "asset.last_decoding_error_at",
+	ReportingInterval:    "asset.reporting_interval",
+	LastUplinkAt:         "asset.last_uplink_at",
+	ConnectionState:      "asset.connection_state",
+	ConnectionStateSince: "asset.connection_state_since",
+	AlarmRuleID:          "asset.alarm_rule_id",
+	AssetName:            "asset.asset_name",
+	AssetDescription:     "asset.asset_description",
 }
 
 // Generated where
@@ -214,24 +269,96 @@ func (w whereHelpernull_Int32) NIN(slice []int32) qm.QueryMod {
 func (w whereHelpernull_Int32) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
 func (w whereHelpernull_Int32) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
+type whereHelpernull_String struct{ field string }
+
+func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_String) LIKE(x null.String) qm.QueryMod {
+	return qm.Where(w.field+" LIKE ?", x)
+}
+func (w whereHelpernull_String) NLIKE(x null.String) qm.QueryMod {
+	return qm.Where(w.field+" NOT LIKE ?", x)
+}
+func (w whereHelpernull_String) ILIKE(x null.String) qm.QueryMod {
+	return qm.Where(w.field+" ILIKE ?", x)
+}
+func (w whereHelpernull_String) NILIKE(x null.String) qm.QueryMod {
+	return qm.Where(w.field+" NOT ILIKE ?", x)
+}
+func (w whereHelpernull_String) IN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_String) NIN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
 var AssetWhere = struct {
-	AssetID          whereHelperint32
-	ConfigurationID  whereHelperint64
-	ProjectID        whereHelperstring
-	GlobalAssetID    whereHelperstring
-	DevEui           whereHelperstring
-	AppID            whereHelperstring
-	ModifiedAt       whereHelpernull_Time
-	LatestStatusCode whereHelpernull_Int32
+	AssetID              whereHelperint32
+	ConfigurationID      whereHelperint64
+	ProjectID            whereHelperstring
+	GlobalAssetID        whereHelperstring
+	DevEui               whereHelperstring
+	AppID                whereHelperstring
+	ModifiedAt           whereHelpernull_Time
+	LatestStatusCode     whereHelpernull_Int32
+	AssetType            whereHelpernull_String
+	Decoder              whereHelpernull_String
+	LastDecodingError    whereHelpernull_String
+	LastDecodingErrorAt  whereHelpernull_Time
+	ReportingInterval    whereHelpernull_Int32
+	LastUplinkAt         whereHelpernull_Time
+	ConnectionState      whereHelpernull_String
+	ConnectionStateSince whereHelpernull_Time
+	AlarmRuleID          whereHelpernull_Int32
+	AssetName            whereHelpernull_String
+	AssetDescription     whereHelpernull_String
 }{
-	AssetID:          whereHelperint32{field: "\"loriot_io\".\"asset\".\"asset_id\""},
-	ConfigurationID:  whereHelperint64{field: "\"loriot_io\".\"asset\".\"configuration_id\""},
-	ProjectID:        whereHelperstring{field: "\"loriot_io\".\"asset\".\"project_id\""},
-	GlobalAssetID:    whereHelperstring{field: "\"loriot_io\".\"asset\".\"global_asset_id\""},
-	DevEui:           whereHelperstring{field: "\"loriot_io\".\"asset\".\"dev_eui\""},
-	AppID:            whereHelperstring{field: "\"loriot_io\".\"asset\".\"app_id\""},
-	ModifiedAt:       whereHelpernull_Time{field: "\"loriot_io\".\"asset\".\"modified_at\""},
-	LatestStatusCode: whereHelpernull_Int32{field: "\"loriot_io\".\"asset\".\"latest_status_code\""},
+	AssetID:              whereHelperint32{field: "\"loriot_io\".\"asset\".\"asset_id\""},
+	ConfigurationID:      whereHelperint64{field: "\"loriot_io\".\"asset\".\"configuration_id\""},
+	ProjectID:            whereHelperstring{field: "\"loriot_io\".\"asset\".\"project_id\""},
+	GlobalAssetID:        whereHelperstring{field: "\"loriot_io\".\"asset\".\"global_asset_id\""},
+	DevEui:               whereHelperstring{field: "\"loriot_io\".\"asset\".\"dev_eui\""},
+	AppID:                whereHelperstring{field: "\"loriot_io\".\"asset\".\"app_id\""},
+	ModifiedAt:           whereHelpernull_Time{field: "\"loriot_io\".\"asset\".\"modified_at\""},
+	LatestStatusCode:     whereHelpernull_Int32{field: "\"loriot_io\".\"asset\".\"latest_status_code\""},
+	AssetType:            whereHelpernull_String{field: "\"loriot_io\".\"asset\".\"asset_type\""},
+	Decoder:              whereHelpernull_String{field: "\"loriot_io\".\"asset\".\"decoder\""},
+	LastDecodingError:    whereHelpernull_String{field: "\"loriot_io\".\"asset\".\"last_decoding_error\""},
+	LastDecodingErrorAt:  whereHelpernull_Time{field: "\"loriot_io\".\"asset\".\"last_decoding_error_at\""},
+	ReportingInterval:    whereHelpernull_Int32{field: "\"loriot_io\".\"asset\".\"reporting_interval\""},
+	LastUplinkAt:         whereHelpernull_Time{field: "\"loriot_io\".\"asset\".\"last_uplink_at\""},
+	ConnectionState:      whereHelpernull_String{field: "\"loriot_io\".\"asset\".\"connection_state\""},
+	ConnectionStateSince: whereHelpernull_Time{field: "\"loriot_io\".\"asset\".\"connection_state_since\""},
+	AlarmRuleID:          whereHelpernull_Int32{field: "\"loriot_io\".\"asset\".\"alarm_rule_id\""},
+	AssetName:            whereHelpernull_String{field: "\"loriot_io\".\"asset\".\"asset_name\""},
+	AssetDescription:     whereHelpernull_String{field: "\"loriot_io\".\"asset\".\"asset_description\""},
 }
 
 // AssetRels is where relationship names are stored.
@@ -262,9 +389,9 @@ func (r *assetR) GetConfiguration() *Configuration {
 type assetL struct{}
 
 var (
-	assetAllColumns            = []string{"asset_id", "configuration_id", "project_id", "global_asset_id", "dev_eui", "app_id", "modified_at", "latest_status_code"}
+	assetAllColumns            = []string{"asset_id", "configuration_id", "project_id", "global_asset_id", "dev_eui", "app_id", "modified_at", "latest_status_code", "asset_type", "decoder", "last_decoding_error", "last_decoding_error_at", "reporting_interval", "last_uplink_at", "connection_state", "connection_state_since", "alarm_rule_id", "asset_name", "asset_description"}
 	assetColumnsWithoutDefault = []string{"asset_id", "project_id", "global_asset_id", "dev_eui", "app_id"}
-	assetColumnsWithDefault    = []string{"configuration_id", "modified_at", "latest_status_code"}
+	assetColumnsWithDefault    = []string{"configuration_id", "modified_at", "latest_status_code", "asset_type", "decoder", "last_decoding_error", "last_decoding_error_at", "reporting_interval", "last_uplink_at", "connection_state", "connection_state_since", "alarm_rule_id", "asset_name", "asset_description"}
 	assetPrimaryKeyColumns     = []string{"asset_id"}
 	assetGeneratedColumns      = []string{}
 )
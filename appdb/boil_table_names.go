@@ -4,9 +4,31 @@
 package appdb
 
 var TableNames = struct {
-	Asset         string
-	Configuration string
+	Asset          string
+	Codec          string
+	Configuration  string
+	DeviceKey      string
+	Downlink       string
+	Gateway        string
+	IdempotencyKey string
+	Job            string
+	JobRow         string
+	KeyBatch       string
+	KeyVault       string
+	KeyVaultAccess string
+	Outbox         string
 }{
-	Asset:         "asset",
-	Configuration: "configuration",
+	Asset:          "asset",
+	Codec:          "codec",
+	Configuration:  "configuration",
+	DeviceKey:      "device_key",
+	Downlink:       "downlink",
+	Gateway:        "gateway",
+	IdempotencyKey: "idempotency_key",
+	Job:            "job",
+	JobRow:         "job_row",
+	KeyBatch:       "key_batch",
+	KeyVault:       "key_vault",
+	KeyVaultAccess: "key_vault_access",
+	Outbox:         "outbox",
 }
@@ -0,0 +1,1054 @@
+// Code generated by SQLBoiler 4.16.1 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package appdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// Codec is an object representing the database table.
+type Codec struct {
+	ID          int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	AssetType   string      `boil:"asset_type" json:"asset_type" toml:"asset_type" yaml:"asset_type"`
+	Script      string      `boil:"script" json:"script" toml:"script" yaml:"script"`
+	Timeout     int32       `boil:"timeout" json:"timeout" toml:"timeout" yaml:"timeout"`
+	MemoryLimit int32       `boil:"memory_limit" json:"memory_limit" toml:"memory_limit" yaml:"memory_limit"`
+	UserID      null.String `boil:"user_id" json:"user_id,omitempty" toml:"user_id" yaml:"user_id,omitempty"`
+	ModifiedAt  null.Time   `boil:"modified_at" json:"modified_at,omitempty" toml:"modified_at" yaml:"modified_at,omitempty"`
+
+	R *codecR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L codecL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var CodecColumns = struct {
+	ID          string
+	AssetType   string
+	Script      string
+	Timeout     string
+	MemoryLimit string
+	UserID      string
+	ModifiedAt  string
+}{
+	ID:          "id",
+	AssetType:   "asset_type",
+	Script:      "script",
+	Timeout:     "timeout",
+	MemoryLimit: "memory_limit",
+	UserID:      "user_id",
+	ModifiedAt:  "modified_at",
+}
+
+var CodecTableColumns = struct {
+	ID          string
+	AssetType   string
+	Script      string
+	Timeout     string
+	MemoryLimit string
+	UserID      string
+	ModifiedAt  string
+}{
+	ID:          "codec.id",
+	AssetType:   "codec.asset_type",
+	Script:      "codec.script",
+	Timeout:     "codec.timeout",
+	MemoryLimit: "codec.memory_limit",
+	UserID:      "codec.user_id",
+	ModifiedAt:  "codec.modified_at",
+}
+
+// Generated where
+
+var CodecWhere = struct {
+	ID          whereHelperint64
+	AssetType   whereHelperstring
+	Script      whereHelperstring
+	Timeout     whereHelperint32
+	MemoryLimit whereHelperint32
+	UserID      whereHelpernull_String
+	ModifiedAt  whereHelpernull_Time
+}{
+	ID:          whereHelperint64{field: "\"loriot_io\".\"codec\".\"id\""},
+	AssetType:   whereHelperstring{field: "\"loriot_io\".\"codec\".\"asset_type\""},
+	Script:      whereHelperstring{field: "\"loriot_io\".\"codec\".\"script\""},
+	Timeout:     whereHelperint32{field: "\"loriot_io\".\"codec\".\"timeout\""},
+	MemoryLimit: whereHelperint32{field: "\"loriot_io\".\"codec\".\"memory_limit\""},
+	UserID:      whereHelpernull_String{field: "\"loriot_io\".\"codec\".\"user_id\""},
+	ModifiedAt:  whereHelpernull_Time{field: "\"loriot_io\".\"codec\".\"modified_at\""},
+}
+
+// CodecRels is where relationship names are stored.
+var CodecRels = struct {
+}{}
+
+// codecR is where relationships are stored.
+type codecR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*codecR) NewStruct() *codecR {
+	return &codecR{}
+}
+
+// codecL is where Load methods for each relationship are stored.
+type codecL struct{}
+
+var (
+	codecAllColumns            = []string{"id", "asset_type", "script", "timeout", "memory_limit", "user_id", "modified_at"}
+	codecColumnsWithoutDefault = []string{"asset_type", "script"}
+	codecColumnsWithDefault    = []string{"id", "timeout", "memory_limit", "user_id", "modified_at"}
+	codecPrimaryKeyColumns     = []string{"id"}
+	codecGeneratedColumns      = []string{}
+)
+
+type (
+	// CodecSlice is an alias for a slice of pointers to Codec.
+	// This should almost always be used instead of []Codec.
+	CodecSlice []*Codec
+	// CodecHook is the signature for custom Codec hook methods
+	CodecHook func(context.Context, boil.ContextExecutor, *Codec) error
+
+	codecQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	codecType                 = reflect.TypeOf(&Codec{})
+	codecMapping              = queries.MakeStructMapping(codecType)
+	codecPrimaryKeyMapping, _ = queries.BindMapping(codecType, codecMapping, codecPrimaryKeyColumns)
+	codecInsertCacheMut       sync.RWMutex
+	codecInsertCache          = make(map[string]insertCache)
+	codecUpdateCacheMut       sync.RWMutex
+	codecUpdateCache          = make(map[string]updateCache)
+	codecUpsertCacheMut       sync.RWMutex
+	codecUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var codecAfterSelectMu sync.Mutex
+var codecAfterSelectHooks []CodecHook
+
+var codecBeforeInsertMu sync.Mutex
+var codecBeforeInsertHooks []CodecHook
+var codecAfterInsertMu sync.Mutex
+var codecAfterInsertHooks []CodecHook
+
+var codecBeforeUpdateMu sync.Mutex
+var codecBeforeUpdateHooks []CodecHook
+var codecAfterUpdateMu sync.Mutex
+var codecAfterUpdateHooks []CodecHook
+
+var codecBeforeDeleteMu sync.Mutex
+var codecBeforeDeleteHooks []CodecHook
+var codecAfterDeleteMu sync.Mutex
+var codecAfterDeleteHooks []CodecHook
+
+var codecBeforeUpsertMu sync.Mutex
+var codecBeforeUpsertHooks []CodecHook
+var codecAfterUpsertMu sync.Mutex
+var codecAfterUpsertHooks []CodecHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *Codec) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range codecAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *Codec) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range codecBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *Codec) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range codecAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *Codec) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range codecBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *Codec) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range codecAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *Codec) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range codecBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *Codec) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range codecAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *Codec) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range codecBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *Codec) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range codecAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddCodecHook registers your hook function for all future operations.
+func AddCodecHook(hookPoint boil.HookPoint, codecHook CodecHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		codecAfterSelectMu.Lock()
+		codecAfterSelectHooks = append(codecAfterSelectHooks, codecHook)
+		codecAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		codecBeforeInsertMu.Lock()
+		codecBeforeInsertHooks = append(codecBeforeInsertHooks, codecHook)
+		codecBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		codecAfterInsertMu.Lock()
+		codecAfterInsertHooks = append(codecAfterInsertHooks, codecHook)
+		codecAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		codecBeforeUpdateMu.Lock()
+		codecBeforeUpdateHooks = append(codecBeforeUpdateHooks, codecHook)
+		codecBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		codecAfterUpdateMu.Lock()
+		codecAfterUpdateHooks = append(codecAfterUpdateHooks, codecHook)
+		codecAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		codecBeforeDeleteMu.Lock()
+		codecBeforeDeleteHooks = append(codecBeforeDeleteHooks, codecHook)
+		codecBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		codecAfterDeleteMu.Lock()
+		codecAfterDeleteHooks = append(codecAfterDeleteHooks, codecHook)
+		codecAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		codecBeforeUpsertMu.Lock()
+		codecBeforeUpsertHooks = append(codecBeforeUpsertHooks, codecHook)
+		codecBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		codecAfterUpsertMu.Lock()
+		codecAfterUpsertHooks = append(codecAfterUpsertHooks, codecHook)
+		codecAfterUpsertMu.Unlock()
+	}
+}
+
+// OneG returns a single codec record from the query using the global executor.
+func (q codecQuery) OneG(ctx context.Context) (*Codec, error) {
+	return q.One(ctx, boil.GetContextDB())
+}
+
+// One returns a single codec record from the query.
+func (q codecQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Codec, error) {
+	o := &Codec{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "appdb: failed to execute a one query for codec")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// AllG returns all Codec records from the query using the global executor.
+func (q codecQuery) AllG(ctx context.Context) (CodecSlice, error) {
+	return q.All(ctx, boil.GetContextDB())
+}
+
+// All returns all Codec records from the query.
+func (q codecQuery) All(ctx context.Context, exec boil.ContextExecutor) (CodecSlice, error) {
+	var o []*Codec
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "appdb: failed to assign all query results to Codec slice")
+	}
+
+	if len(codecAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// CountG returns the count of all Codec records in the query using the global executor
+func (q codecQuery) CountG(ctx context.Context) (int64, error) {
+	return q.Count(ctx, boil.GetContextDB())
+}
+
+// Count returns the count of all Codec records in the query.
+func (q codecQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to count codec rows")
+	}
+
+	return count, nil
+}
+
+// ExistsG checks if the row exists in the table using the global executor.
+func (q codecQuery) ExistsG(ctx context.Context) (bool, error) {
+	return q.Exists(ctx, boil.GetContextDB())
+}
+
+// Exists checks if the row exists in the table.
+func (q codecQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "appdb: failed to check if codec exists")
+	}
+
+	return count > 0, nil
+}
+
+// Codecs retrieves all the records using an executor.
+func Codecs(mods ...qm.QueryMod) codecQuery {
+	mods = append(mods, qm.From("\"loriot_io\".\"codec\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"loriot_io\".\"codec\".*"})
+	}
+
+	return codecQuery{q}
+}
+
+// FindCodecG retrieves a single record by ID.
+func FindCodecG(ctx context.Context, iD int64, selectCols ...string) (*Codec, error) {
+	return FindCodec(ctx, boil.GetContextDB(), iD, selectCols...)
+}
+
+// FindCodec retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindCodec(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*Codec, error) {
+	codecObj := &Codec{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"loriot_io\".\"codec\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, codecObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "appdb: unable to select from codec")
+	}
+
+	if err = codecObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return codecObj, err
+	}
+
+	return codecObj, nil
+}
+
+// InsertG a single record. See Insert for whitelist behavior description.
+func (o *Codec) InsertG(ctx context.Context, columns boil.Columns) error {
+	return o.Insert(ctx, boil.GetContextDB(), columns)
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Codec) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("appdb: no codec provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(codecColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	codecInsertCacheMut.RLock()
+	cache, cached := codecInsertCache[key]
+	codecInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			codecAllColumns,
+			codecColumnsWithDefault,
+			codecColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(codecType, codecMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(codecType, codecMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"loriot_io\".\"codec\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"loriot_io\".\"codec\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to insert into codec")
+	}
+
+	if !cached {
+		codecInsertCacheMut.Lock()
+		codecInsertCache[key] = cache
+		codecInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// UpdateG a single Codec record using the global executor.
+// See Update for more documentation.
+func (o *Codec) UpdateG(ctx context.Context, columns boil.Columns) (int64, error) {
+	return o.Update(ctx, boil.GetContextDB(), columns)
+}
+
+// Update uses an executor to update the Codec.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Codec) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	codecUpdateCacheMut.RLock()
+	cache, cached := codecUpdateCache[key]
+	codecUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			codecAllColumns,
+			codecPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("appdb: unable to update codec, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"loriot_io\".\"codec\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, codecPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(codecType, codecMapping, append(wl, codecPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update codec row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by update for codec")
+	}
+
+	if !cached {
+		codecUpdateCacheMut.Lock()
+		codecUpdateCache[key] = cache
+		codecUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (q codecQuery) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return q.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q codecQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update all for codec")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to retrieve rows affected for codec")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (o CodecSlice) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return o.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o CodecSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("appdb: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), codecPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"loriot_io\".\"codec\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, codecPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update all in codec slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to retrieve rows affected all in update all codec")
+	}
+	return rowsAff, nil
+}
+
+// UpsertG attempts an insert, and does an update or ignore on conflict.
+func (o *Codec) UpsertG(ctx context.Context, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	return o.Upsert(ctx, boil.GetContextDB(), updateOnConflict, conflictColumns, updateColumns, insertColumns, opts...)
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Codec) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("appdb: no codec provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(codecColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	codecUpsertCacheMut.RLock()
+	cache, cached := codecUpsertCache[key]
+	codecUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			codecAllColumns,
+			codecColumnsWithDefault,
+			codecColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			codecAllColumns,
+			codecPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("appdb: unable to upsert codec, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(codecAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(codecPrimaryKeyColumns) == 0 {
+				return errors.New("appdb: unable to upsert codec, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(codecPrimaryKeyColumns))
+			copy(conflict, codecPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"loriot_io\".\"codec\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(codecType, codecMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(codecType, codecMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to upsert codec")
+	}
+
+	if !cached {
+		codecUpsertCacheMut.Lock()
+		codecUpsertCache[key] = cache
+		codecUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// DeleteG deletes a single Codec record.
+// DeleteG will match against the primary key column to find the record to delete.
+func (o *Codec) DeleteG(ctx context.Context) (int64, error) {
+	return o.Delete(ctx, boil.GetContextDB())
+}
+
+// Delete deletes a single Codec record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Codec) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("appdb: no Codec provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), codecPrimaryKeyMapping)
+	sql := "DELETE FROM \"loriot_io\".\"codec\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete from codec")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by delete for codec")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+func (q codecQuery) DeleteAllG(ctx context.Context) (int64, error) {
+	return q.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all matching rows.
+func (q codecQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("appdb: no codecQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete all from codec")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by deleteall for codec")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAllG deletes all rows in the slice.
+func (o CodecSlice) DeleteAllG(ctx context.Context) (int64, error) {
+	return o.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o CodecSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(codecBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), codecPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"loriot_io\".\"codec\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, codecPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete all from codec slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by deleteall for codec")
+	}
+
+	if len(codecAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// ReloadG refetches the object from the database using the primary keys.
+func (o *Codec) ReloadG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("appdb: no Codec provided for reload")
+	}
+
+	return o.Reload(ctx, boil.GetContextDB())
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Codec) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindCodec(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAllG refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *CodecSlice) ReloadAllG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("appdb: empty CodecSlice provided for reload all")
+	}
+
+	return o.ReloadAll(ctx, boil.GetContextDB())
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *CodecSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := CodecSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), codecPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"loriot_io\".\"codec\".* FROM \"loriot_io\".\"codec\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, codecPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to reload all in CodecSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// CodecExistsG checks if the Codec row exists.
+func CodecExistsG(ctx context.Context, iD int64) (bool, error) {
+	return CodecExists(ctx, boil.GetContextDB(), iD)
+}
+
+// CodecExists checks if the Codec row exists.
+func CodecExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"loriot_io\".\"codec\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "appdb: unable to check if codec exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the Codec row exists.
+func (o *Codec) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return CodecExists(ctx, exec, o.ID)
+}
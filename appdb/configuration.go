@@ -25,57 +25,97 @@ import (
 
 // Configuration is an object representing the database table.
 type Configuration struct {
-	ID              int64             `boil:"id" json:"id" toml:"id" yaml:"id"`
-	APIBaseURL      string            `boil:"api_base_url" json:"api_base_url" toml:"api_base_url" yaml:"api_base_url"`
-	APIToken        string            `boil:"api_token" json:"api_token" toml:"api_token" yaml:"api_token"`
-	RefreshInterval int32             `boil:"refresh_interval" json:"refresh_interval" toml:"refresh_interval" yaml:"refresh_interval"`
-	RequestTimeout  int32             `boil:"request_timeout" json:"request_timeout" toml:"request_timeout" yaml:"request_timeout"`
-	Enable          null.Bool         `boil:"enable" json:"enable,omitempty" toml:"enable" yaml:"enable,omitempty"`
-	ProjectIds      types.StringArray `boil:"project_ids" json:"project_ids,omitempty" toml:"project_ids" yaml:"project_ids,omitempty"`
-	UserID          null.String       `boil:"user_id" json:"user_id,omitempty" toml:"user_id" yaml:"user_id,omitempty"`
+	ID                      int64             `boil:"id" json:"id" toml:"id" yaml:"id"`
+	APIBaseURL              string            `boil:"api_base_url" json:"api_base_url" toml:"api_base_url" yaml:"api_base_url"`
+	APIToken                string            `boil:"api_token" json:"api_token" toml:"api_token" yaml:"api_token"`
+	RefreshInterval         int32             `boil:"refresh_interval" json:"refresh_interval" toml:"refresh_interval" yaml:"refresh_interval"`
+	RequestTimeout          int32             `boil:"request_timeout" json:"request_timeout" toml:"request_timeout" yaml:"request_timeout"`
+	Enable                  null.Bool         `boil:"enable" json:"enable,omitempty" toml:"enable" yaml:"enable,omitempty"`
+	ProjectIds              types.StringArray `boil:"project_ids" json:"project_ids,omitempty" toml:"project_ids" yaml:"project_ids,omitempty"`
+	UserID                  null.String       `boil:"user_id" json:"user_id,omitempty" toml:"user_id" yaml:"user_id,omitempty"`
+	DefaultAssetTypes       null.JSON         `boil:"default_asset_types" json:"default_asset_types,omitempty" toml:"default_asset_types" yaml:"default_asset_types,omitempty"`
+	GatewayOfflineThreshold int32             `boil:"gateway_offline_threshold" json:"gateway_offline_threshold" toml:"gateway_offline_threshold" yaml:"gateway_offline_threshold"`
+	ReportingIntervals      null.JSON         `boil:"reporting_intervals" json:"reporting_intervals,omitempty" toml:"reporting_intervals" yaml:"reporting_intervals,omitempty"`
+	DeviceOfflineIntervals  int32             `boil:"device_offline_intervals" json:"device_offline_intervals" toml:"device_offline_intervals" yaml:"device_offline_intervals"`
+	ReconcilePolicy         string            `boil:"reconcile_policy" json:"reconcile_policy" toml:"reconcile_policy" yaml:"reconcile_policy"`
+	DevEuiBlock             null.String       `boil:"dev_eui_block" json:"dev_eui_block,omitempty" toml:"dev_eui_block" yaml:"dev_eui_block,omitempty"`
+	Version                 int32             `boil:"version" json:"version" toml:"version" yaml:"version"`
+	RoutingRules            null.JSON         `boil:"routing_rules" json:"routing_rules,omitempty" toml:"routing_rules" yaml:"routing_rules,omitempty"`
 
 	R *configurationR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L configurationL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var ConfigurationColumns = struct {
-	ID              string
-	APIBaseURL      string
-	APIToken        string
-	RefreshInterval string
-	RequestTimeout  string
-	Enable          string
-	ProjectIds      string
-	UserID          string
+	ID                      string
+	APIBaseURL              string
+	APIToken                string
+	RefreshInterval         string
+	RequestTimeout          string
+	Enable                  string
+	ProjectIds              string
+	UserID                  string
+	DefaultAssetTypes       string
+	GatewayOfflineThreshold string
+	ReportingIntervals      string
+	DeviceOfflineIntervals  string
+	ReconcilePolicy         string
+	DevEuiBlock             string
+	Version                 string
+	RoutingRules            string
 }{
-	ID:              "id",
-	APIBaseURL:      "api_base_url",
-	APIToken:        "api_token",
-	RefreshInterval: "refresh_interval",
-	RequestTimeout:  "request_timeout",
-	Enable:          "enable",
-	ProjectIds:      "project_ids",
-	UserID:          "user_id",
+	ID:                      "id",
+	APIBaseURL:              "api_base_url",
+	APIToken:                "api_token",
+	RefreshInterval:         "refresh_interval",
+	RequestTimeout:          "request_timeout",
+	Enable:                  "enable",
+	ProjectIds:              "project_ids",
+	UserID:                  "user_id",
+	DefaultAssetTypes:       "default_asset_types",
+	GatewayOfflineThreshold: "gateway_offline_threshold",
+	ReportingIntervals:      "reporting_intervals",
+	DeviceOfflineIntervals:  "device_offline_intervals",
+	ReconcilePolicy:         "reconcile_policy",
+	DevEuiBlock:             "dev_eui_block",
+	Version:                 "version",
+	RoutingRules:            "routing_rules",
 }
 
 var ConfigurationTableColumns = struct {
-	ID              string
-	APIBaseURL      string
-	APIToken        string
-	RefreshInterval string
-	RequestTimeout  string
-	Enable          string
-	ProjectIds      string
-	UserID          string
+	ID                      string
+	APIBaseURL              string
+	APIToken                string
+	RefreshInterval         string
+	RequestTimeout          string
+	Enable                  string
+	ProjectIds              string
+	UserID                  string
+	DefaultAssetTypes       string
+	GatewayOfflineThreshold string
+	ReportingIntervals      string
+	DeviceOfflineIntervals  string
+	ReconcilePolicy         string
+	DevEuiBlock             string
+	Version                 string
+	RoutingRules            string
 }{
-	ID:              "configuration.id",
-	APIBaseURL:      "configuration.api_base_url",
-	APIToken:        "configuration.api_token",
-	RefreshInterval: "configuration.refresh_interval",
-	RequestTimeout:  "configuration.request_timeout",
-	Enable:          "configuration.enable",
-	ProjectIds:      "configuration.project_ids",
-	UserID:          "configuration.user_id",
+	ID:                      "configuration.id",
+	APIBaseURL:              "configuration.api_base_url",
+	APIToken:                "configuration.api_token",
+	RefreshInterval:         "configuration.refresh_interval",
+	RequestTimeout:          "configuration.request_timeout",
+	Enable:                  "configuration.enable",
+	ProjectIds:              "configuration.project_ids",
+	UserID:                  "configuration.user_id",
+	DefaultAssetTypes:       "configuration.default_asset_types",
+	GatewayOfflineThreshold: "configuration.gateway_offline_threshold",
+	ReportingIntervals:      "configuration.reporting_intervals",
+	DeviceOfflineIntervals:  "configuration.device_offline_intervals",
+	ReconcilePolicy:         "configuration.reconcile_policy",
+	DevEuiBlock:             "configuration.dev_eui_block",
+	Version:                 "configuration.version",
+	RoutingRules:            "configuration.routing_rules",
 }
 
 // Generated where
@@ -130,86 +170,85 @@ func (w whereHelpertypes_StringArray) IsNotNull() qm.QueryMod {
 	return qmhelper.WhereIsNotNull(w.field)
 }
 
-type whereHelpernull_String struct{ field string }
+type whereHelpernull_JSON struct{ field string }
 
-func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
+func (w whereHelpernull_JSON) EQ(x null.JSON) qm.QueryMod {
 	return qmhelper.WhereNullEQ(w.field, false, x)
 }
-func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
+func (w whereHelpernull_JSON) NEQ(x null.JSON) qm.QueryMod {
 	return qmhelper.WhereNullEQ(w.field, true, x)
 }
-func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
+func (w whereHelpernull_JSON) LT(x null.JSON) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LT, x)
 }
-func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
+func (w whereHelpernull_JSON) LTE(x null.JSON) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LTE, x)
 }
-func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
+func (w whereHelpernull_JSON) GT(x null.JSON) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GT, x)
 }
-func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
+func (w whereHelpernull_JSON) GTE(x null.JSON) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
-func (w whereHelpernull_String) LIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" LIKE ?", x)
-}
-func (w whereHelpernull_String) NLIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" NOT LIKE ?", x)
-}
-func (w whereHelpernull_String) ILIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" ILIKE ?", x)
-}
-func (w whereHelpernull_String) NILIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" NOT ILIKE ?", x)
-}
-func (w whereHelpernull_String) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelpernull_String) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
 
-func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+func (w whereHelpernull_JSON) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_JSON) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
 var ConfigurationWhere = struct {
-	ID              whereHelperint64
-	APIBaseURL      whereHelperstring
-	APIToken        whereHelperstring
-	RefreshInterval whereHelperint32
-	RequestTimeout  whereHelperint32
-	Enable          whereHelpernull_Bool
-	ProjectIds      whereHelpertypes_StringArray
-	UserID          whereHelpernull_String
+	ID                      whereHelperint64
+	APIBaseURL              whereHelperstring
+	APIToken                whereHelperstring
+	RefreshInterval         whereHelperint32
+	RequestTimeout          whereHelperint32
+	Enable                  whereHelpernull_Bool
+	ProjectIds              whereHelpertypes_StringArray
+	UserID                  whereHelpernull_String
+	DefaultAssetTypes       whereHelpernull_JSON
+	GatewayOfflineThreshold whereHelperint32
+	ReportingIntervals      whereHelpernull_JSON
+	DeviceOfflineIntervals  whereHelperint32
+	ReconcilePolicy         whereHelperstring
+	DevEuiBlock             whereHelpernull_String
+	Version                 whereHelperint32
+	RoutingRules            whereHelpernull_JSON
 }{
-	ID:              whereHelperint64{field: "\"loriot_io\".\"configuration\".\"id\""},
-	APIBaseURL:      whereHelperstring{field: "\"loriot_io\".\"configuration\".\"api_base_url\""},
-	APIToken:        whereHelperstring{field: "\"loriot_io\".\"configuration\".\"api_token\""},
-	RefreshInterval: whereHelperint32{field: "\"loriot_io\".\"configuration\".\"refresh_interval\""},
-	RequestTimeout:  whereHelperint32{field: "\"loriot_io\".\"configuration\".\"request_timeout\""},
-	Enable:          whereHelpernull_Bool{field: "\"loriot_io\".\"configuration\".\"enable\""},
-	ProjectIds:      whereHelpertypes_StringArray{field: "\"loriot_io\".\"configuration\".\"project_ids\""},
-	UserID:          whereHelpernull_String{field: "\"loriot_io\".\"configuration\".\"user_id\""},
+	ID:                      whereHelperint64{field: "\"loriot_io\".\"configuration\".\"id\""},
+	APIBaseURL:              whereHelperstring{field: "\"loriot_io\".\"configuration\".\"api_base_url\""},
+	APIToken:                whereHelperstring{field: "\"loriot_io\".\"configuration\".\"api_token\""},
+	RefreshInterval:         whereHelperint32{field: "\"loriot_io\".\"configuration\".\"refresh_interval\""},
+	RequestTimeout:          whereHelperint32{field: "\"loriot_io\".\"configuration\".\"request_timeout\""},
+	Enable:                  whereHelpernull_Bool{field: "\"loriot_io\".\"configuration\".\"enable\""},
+	ProjectIds:              whereHelpertypes_StringArray{field: "\"loriot_io\".\"configuration\".\"project_ids\""},
+	UserID:                  whereHelpernull_String{field: "\"loriot_io\".\"configuration\".\"user_id\""},
+	DefaultAssetTypes:       whereHelpernull_JSON{field: "\"loriot_io\".\"configuration\".\"default_asset_types\""},
+	GatewayOfflineThreshold: whereHelperint32{field: "\"loriot_io\".\"configuration\".\"gateway_offline_threshold\""},
+	ReportingIntervals:      whereHelpernull_JSON{field: "\"loriot_io\".\"configuration\".\"reporting_intervals\""},
+	DeviceOfflineIntervals:  whereHelperint32{field: "\"loriot_io\".\"configuration\".\"device_offline_intervals\""},
+	ReconcilePolicy:         whereHelperstring{field: "\"loriot_io\".\"configuration\".\"reconcile_policy\""},
+	DevEuiBlock:             whereHelpernull_String{field: "\"loriot_io\".\"configuration\".\"dev_eui_block\""},
+	Version:                 whereHelperint32{field: "\"loriot_io\".\"configuration\".\"version\""},
+	RoutingRules:            whereHelpernull_JSON{field: "\"loriot_io\".\"configuration\".\"routing_rules\""},
 }
 
 // ConfigurationRels is where relationship names are stored.
 var ConfigurationRels = struct {
-	Assets string
+	Assets    string
+	Downlinks string
+	Gateways  string
+	Outboxes  string
 }{
-	Assets: "Assets",
+	Assets:    "Assets",
+	Downlinks: "Downlinks",
+	Gateways:  "Gateways",
+	Outboxes:  "Outboxes",
 }
 
 // configurationR is where relationships are stored.
 type configurationR struct {
-	Assets AssetSlice `boil:"Assets" json:"Assets" toml:"Assets" yaml:"Assets"`
+	Assets    AssetSlice    `boil:"Assets" json:"Assets" toml:"Assets" yaml:"Assets"`
+	Downlinks DownlinkSlice `boil:"Downlinks" json:"Downlinks" toml:"Downlinks" yaml:"Downlinks"`
+	Gateways  GatewaySlice  `boil:"Gateways" json:"Gateways" toml:"Gateways" yaml:"Gateways"`
+	Outboxes  OutboxSlice   `boil:"Outboxes" json:"Outboxes" toml:"Outboxes" yaml:"Outboxes"`
 }
 
 // NewStruct creates a new relationship struct
@@ -224,13 +263,34 @@ func (r *configurationR) GetAssets() AssetSlice {
 	return r.Assets
 }
 
+func (r *configurationR) GetDownlinks() DownlinkSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Downlinks
+}
+
+func (r *configurationR) GetGateways() GatewaySlice {
+	if r == nil {
+		return nil
+	}
+	return r.Gateways
+}
+
+func (r *configurationR) GetOutboxes() OutboxSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Outboxes
+}
+
 // configurationL is where Load methods for each relationship are stored.
 type configurationL struct{}
 
 var (
-	configurationAllColumns            = []string{"id", "api_base_url", "api_token", "refresh_interval", "request_timeout", "enable", "project_ids", "user_id"}
+	configurationAllColumns            = []string{"id", "api_base_url", "api_token", "refresh_interval", "request_timeout", "enable", "project_ids", "user_id", "default_asset_types", "gateway_offline_threshold", "reporting_intervals", "device_offline_intervals", "reconcile_policy", "dev_eui_block", "version", "routing_rules"}
 	configurationColumnsWithoutDefault = []string{"api_base_url", "api_token"}
-	configurationColumnsWithDefault    = []string{"id", "refresh_interval", "request_timeout", "enable", "project_ids", "user_id"}
+	configurationColumnsWithDefault    = []string{"id", "refresh_interval", "request_timeout", "enable", "project_ids", "user_id", "default_asset_types", "gateway_offline_threshold", "reporting_intervals", "device_offline_intervals", "reconcile_policy", "dev_eui_block", "version", "routing_rules"}
 	configurationPrimaryKeyColumns     = []string{"id"}
 	configurationGeneratedColumns      = []string{}
 )
@@ -574,6 +634,48 @@ func (o *Configuration) Assets(mods ...qm.QueryMod) assetQuery {
 	return Assets(queryMods...)
 }
 
+// Downlinks retrieves all the downlink's Downlinks with an executor.
+func (o *Configuration) Downlinks(mods ...qm.QueryMod) downlinkQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"loriot_io\".\"downlink\".\"configuration_id\"=?", o.ID),
+	)
+
+	return Downlinks(queryMods...)
+}
+
+// Gateways retrieves all the gateway's Gateways with an executor.
+func (o *Configuration) Gateways(mods ...qm.QueryMod) gatewayQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"loriot_io\".\"gateway\".\"configuration_id\"=?", o.ID),
+	)
+
+	return Gateways(queryMods...)
+}
+
+// Outboxes retrieves all the outbox's Outboxes with an executor.
+func (o *Configuration) Outboxes(mods ...qm.QueryMod) outboxQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"loriot_io\".\"outbox\".\"configuration_id\"=?", o.ID),
+	)
+
+	return Outboxes(queryMods...)
+}
+
 // LoadAssets allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (configurationL) LoadAssets(ctx context.Context, e boil.ContextExecutor, singular bool, maybeConfiguration interface{}, mods queries.Applicator) error {
@@ -687,6 +789,345 @@ func (configurationL) LoadAssets(ctx context.Context, e boil.ContextExecutor, si
 	return nil
 }
 
+// LoadDownlinks allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (configurationL) LoadDownlinks(ctx context.Context, e boil.ContextExecutor, singular bool, maybeConfiguration interface{}, mods queries.Applicator) error {
+	var slice []*Configuration
+	var object *Configuration
+
+	if singular {
+		var ok bool
+		object, ok = maybeConfiguration.(*Configuration)
+		if !ok {
+			object = new(Configuration)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeConfiguration)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeConfiguration))
+			}
+		}
+	} else {
+		s, ok := maybeConfiguration.(*[]*Configuration)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeConfiguration)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeConfiguration))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &configurationR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &configurationR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`loriot_io.downlink`),
+		qm.WhereIn(`loriot_io.downlink.configuration_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load downlink")
+	}
+
+	var resultSlice []*Downlink
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice downlink")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on downlink")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for downlink")
+	}
+
+	if len(downlinkAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Downlinks = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &downlinkR{}
+			}
+			foreign.R.Configuration = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.ConfigurationID {
+				local.R.Downlinks = append(local.R.Downlinks, foreign)
+				if foreign.R == nil {
+					foreign.R = &downlinkR{}
+				}
+				foreign.R.Configuration = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadGateways allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (configurationL) LoadGateways(ctx context.Context, e boil.ContextExecutor, singular bool, maybeConfiguration interface{}, mods queries.Applicator) error {
+	var slice []*Configuration
+	var object *Configuration
+
+	if singular {
+		var ok bool
+		object, ok = maybeConfiguration.(*Configuration)
+		if !ok {
+			object = new(Configuration)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeConfiguration)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeConfiguration))
+			}
+		}
+	} else {
+		s, ok := maybeConfiguration.(*[]*Configuration)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeConfiguration)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeConfiguration))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &configurationR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &configurationR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`loriot_io.gateway`),
+		qm.WhereIn(`loriot_io.gateway.configuration_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load gateway")
+	}
+
+	var resultSlice []*Gateway
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice gateway")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on gateway")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for gateway")
+	}
+
+	if len(gatewayAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Gateways = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &gatewayR{}
+			}
+			foreign.R.Configuration = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.ConfigurationID {
+				local.R.Gateways = append(local.R.Gateways, foreign)
+				if foreign.R == nil {
+					foreign.R = &gatewayR{}
+				}
+				foreign.R.Configuration = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadOutboxes allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (configurationL) LoadOutboxes(ctx context.Context, e boil.ContextExecutor, singular bool, maybeConfiguration interface{}, mods queries.Applicator) error {
+	var slice []*Configuration
+	var object *Configuration
+
+	if singular {
+		var ok bool
+		object, ok = maybeConfiguration.(*Configuration)
+		if !ok {
+			object = new(Configuration)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeConfiguration)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeConfiguration))
+			}
+		}
+	} else {
+		s, ok := maybeConfiguration.(*[]*Configuration)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeConfiguration)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeConfiguration))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &configurationR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &configurationR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`loriot_io.outbox`),
+		qm.WhereIn(`loriot_io.outbox.configuration_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load outbox")
+	}
+
+	var resultSlice []*Outbox
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice outbox")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on outbox")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for outbox")
+	}
+
+	if len(outboxAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Outboxes = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &outboxR{}
+			}
+			foreign.R.Configuration = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.ConfigurationID {
+				local.R.Outboxes = append(local.R.Outboxes, foreign)
+				if foreign.R == nil {
+					foreign.R = &outboxR{}
+				}
+				foreign.R.Configuration = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // AddAssetsG adds the given related objects to the existing relationships
 // of the configuration, optionally inserting them as new records.
 // Appends related to o.R.Assets.
@@ -749,6 +1190,192 @@ func (o *Configuration) AddAssets(ctx context.Context, exec boil.ContextExecutor
 	return nil
 }
 
+// AddDownlinksG adds the given related objects to the existing relationships
+// of the configuration, optionally inserting them as new records.
+// Appends related to o.R.Downlinks.
+// Sets related.R.Configuration appropriately.
+// Uses the global database handle.
+func (o *Configuration) AddDownlinksG(ctx context.Context, insert bool, related ...*Downlink) error {
+	return o.AddDownlinks(ctx, boil.GetContextDB(), insert, related...)
+}
+
+// AddDownlinks adds the given related objects to the existing relationships
+// of the configuration, optionally inserting them as new records.
+// Appends related to o.R.Downlinks.
+// Sets related.R.Configuration appropriately.
+func (o *Configuration) AddDownlinks(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Downlink) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.ConfigurationID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"loriot_io\".\"downlink\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"configuration_id"}),
+				strmangle.WhereClause("\"", "\"", 2, downlinkPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.ConfigurationID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &configurationR{
+			Downlinks: related,
+		}
+	} else {
+		o.R.Downlinks = append(o.R.Downlinks, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &downlinkR{
+				Configuration: o,
+			}
+		} else {
+			rel.R.Configuration = o
+		}
+	}
+	return nil
+}
+
+// AddGatewaysG adds the given related objects to the existing relationships
+// of the configuration, optionally inserting them as new records.
+// Appends related to o.R.Gateways.
+// Sets related.R.Configuration appropriately.
+// Uses the global database handle.
+func (o *Configuration) AddGatewaysG(ctx context.Context, insert bool, related ...*Gateway) error {
+	return o.AddGateways(ctx, boil.GetContextDB(), insert, related...)
+}
+
+// AddGateways adds the given related objects to the existing relationships
+// of the configuration, optionally inserting them as new records.
+// Appends related to o.R.Gateways.
+// Sets related.R.Configuration appropriately.
+func (o *Configuration) AddGateways(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Gateway) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.ConfigurationID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"loriot_io\".\"gateway\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"configuration_id"}),
+				strmangle.WhereClause("\"", "\"", 2, gatewayPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.AssetID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.ConfigurationID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &configurationR{
+			Gateways: related,
+		}
+	} else {
+		o.R.Gateways = append(o.R.Gateways, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &gatewayR{
+				Configuration: o,
+			}
+		} else {
+			rel.R.Configuration = o
+		}
+	}
+	return nil
+}
+
+// AddOutboxesG adds the given related objects to the existing relationships
+// of the configuration, optionally inserting them as new records.
+// Appends related to o.R.Outboxes.
+// Sets related.R.Configuration appropriately.
+// Uses the global database handle.
+func (o *Configuration) AddOutboxesG(ctx context.Context, insert bool, related ...*Outbox) error {
+	return o.AddOutboxes(ctx, boil.GetContextDB(), insert, related...)
+}
+
+// AddOutboxes adds the given related objects to the existing relationships
+// of the configuration, optionally inserting them as new records.
+// Appends related to o.R.Outboxes.
+// Sets related.R.Configuration appropriately.
+func (o *Configuration) AddOutboxes(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Outbox) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.ConfigurationID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"loriot_io\".\"outbox\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"configuration_id"}),
+				strmangle.WhereClause("\"", "\"", 2, outboxPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.ConfigurationID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &configurationR{
+			Outboxes: related,
+		}
+	} else {
+		o.R.Outboxes = append(o.R.Outboxes, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &outboxR{
+				Configuration: o,
+			}
+		} else {
+			rel.R.Configuration = o
+		}
+	}
+	return nil
+}
+
 // Configurations retrieves all the records using an executor.
 func Configurations(mods ...qm.QueryMod) configurationQuery {
 	mods = append(mods, qm.From("\"loriot_io\".\"configuration\""))
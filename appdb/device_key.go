@@ -0,0 +1,1251 @@
+// Code generated by SQLBoiler 4.16.1 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package appdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// DeviceKey is an object representing the database table.
+type DeviceKey struct {
+	DevEui        string      `boil:"dev_eui" json:"dev_eui" toml:"dev_eui" yaml:"dev_eui"`
+	KeyBatchID    int64       `boil:"key_batch_id" json:"key_batch_id" toml:"key_batch_id" yaml:"key_batch_id"`
+	JoinEui       null.String `boil:"join_eui" json:"join_eui,omitempty" toml:"join_eui" yaml:"join_eui,omitempty"`
+	AppKey        string      `boil:"app_key" json:"app_key" toml:"app_key" yaml:"app_key"`
+	NWKKey        null.String `boil:"nwk_key" json:"nwk_key,omitempty" toml:"nwk_key" yaml:"nwk_key,omitempty"`
+	OwnerToken    null.String `boil:"owner_token" json:"owner_token,omitempty" toml:"owner_token" yaml:"owner_token,omitempty"`
+	ProvisionedAt null.Time   `boil:"provisioned_at" json:"provisioned_at,omitempty" toml:"provisioned_at" yaml:"provisioned_at,omitempty"`
+
+	R *deviceKeyR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L deviceKeyL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var DeviceKeyColumns = struct {
+	DevEui        string
+	KeyBatchID    string
+	JoinEui       string
+	AppKey        string
+	NWKKey        string
+	OwnerToken    string
+	ProvisionedAt string
+}{
+	DevEui:        "dev_eui",
+	KeyBatchID:    "key_batch_id",
+	JoinEui:       "join_eui",
+	AppKey:        "app_key",
+	NWKKey:        "nwk_key",
+	OwnerToken:    "owner_token",
+	ProvisionedAt: "provisioned_at",
+}
+
+var DeviceKeyTableColumns = struct {
+	DevEui        string
+	KeyBatchID    string
+	JoinEui       string
+	AppKey        string
+	NWKKey        string
+	OwnerToken    string
+	ProvisionedAt string
+}{
+	DevEui:        "device_key.dev_eui",
+	KeyBatchID:    "device_key.key_batch_id",
+	JoinEui:       "device_key.join_eui",
+	AppKey:        "device_key.app_key",
+	NWKKey:        "device_key.nwk_key",
+	OwnerToken:    "device_key.owner_token",
+	ProvisionedAt: "device_key.provisioned_at",
+}
+
+// Generated where
+
+var DeviceKeyWhere = struct {
+	DevEui        whereHelperstring
+	KeyBatchID    whereHelperint64
+	JoinEui       whereHelpernull_String
+	AppKey        whereHelperstring
+	NWKKey        whereHelpernull_String
+	OwnerToken    whereHelpernull_String
+	ProvisionedAt whereHelpernull_Time
+}{
+	DevEui:        whereHelperstring{field: "\"loriot_io\".\"device_key\".\"dev_eui\""},
+	KeyBatchID:    whereHelperint64{field: "\"loriot_io\".\"device_key\".\"key_batch_id\""},
+	JoinEui:       whereHelpernull_String{field: "\"loriot_io\".\"device_key\".\"join_eui\""},
+	AppKey:        whereHelperstring{field: "\"loriot_io\".\"device_key\".\"app_key\""},
+	NWKKey:        whereHelpernull_String{field: "\"loriot_io\".\"device_key\".\"nwk_key\""},
+	OwnerToken:    whereHelpernull_String{field: "\"loriot_io\".\"device_key\".\"owner_token\""},
+	ProvisionedAt: whereHelpernull_Time{field: "\"loriot_io\".\"device_key\".\"provisioned_at\""},
+}
+
+// DeviceKeyRels is where relationship names are stored.
+var DeviceKeyRels = struct {
+	KeyBatch string
+}{
+	KeyBatch: "KeyBatch",
+}
+
+// deviceKeyR is where relationships are stored.
+type deviceKeyR struct {
+	KeyBatch *KeyBatch `boil:"KeyBatch" json:"KeyBatch" toml:"KeyBatch" yaml:"KeyBatch"`
+}
+
+// NewStruct creates a new relationship struct
+func (*deviceKeyR) NewStruct() *deviceKeyR {
+	return &deviceKeyR{}
+}
+
+func (r *deviceKeyR) GetKeyBatch() *KeyBatch {
+	if r == nil {
+		return nil
+	}
+	return r.KeyBatch
+}
+
+// deviceKeyL is where Load methods for each relationship are stored.
+type deviceKeyL struct{}
+
+var (
+	deviceKeyAllColumns            = []string{"dev_eui", "key_batch_id", "join_eui", "app_key", "nwk_key", "owner_token", "provisioned_at"}
+	deviceKeyColumnsWithoutDefault = []string{"dev_eui", "key_batch_id", "app_key"}
+	deviceKeyColumnsWithDefault    = []string{"join_eui", "nwk_key", "owner_token", "provisioned_at"}
+	deviceKeyPrimaryKeyColumns     = []string{"dev_eui"}
+	deviceKeyGeneratedColumns      = []string{}
+)
+
+type (
+	// DeviceKeySlice is an alias for a slice of pointers to DeviceKey.
+	// This should almost always be used instead of []DeviceKey.
+	DeviceKeySlice []*DeviceKey
+	// DeviceKeyHook is the signature for custom DeviceKey hook methods
+	DeviceKeyHook func(context.Context, boil.ContextExecutor, *DeviceKey) error
+
+	deviceKeyQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	deviceKeyType                 = reflect.TypeOf(&DeviceKey{})
+	deviceKeyMapping              = queries.MakeStructMapping(deviceKeyType)
+	deviceKeyPrimaryKeyMapping, _ = queries.BindMapping(deviceKeyType, deviceKeyMapping, deviceKeyPrimaryKeyColumns)
+	deviceKeyInsertCacheMut       sync.RWMutex
+	deviceKeyInsertCache          = make(map[string]insertCache)
+	deviceKeyUpdateCacheMut       sync.RWMutex
+	deviceKeyUpdateCache          = make(map[string]updateCache)
+	deviceKeyUpsertCacheMut       sync.RWMutex
+	deviceKeyUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var deviceKeyAfterSelectMu sync.Mutex
+var deviceKeyAfterSelectHooks []DeviceKeyHook
+
+var deviceKeyBeforeInsertMu sync.Mutex
+var deviceKeyBeforeInsertHooks []DeviceKeyHook
+var deviceKeyAfterInsertMu sync.Mutex
+var deviceKeyAfterInsertHooks []DeviceKeyHook
+
+var deviceKeyBeforeUpdateMu sync.Mutex
+var deviceKeyBeforeUpdateHooks []DeviceKeyHook
+var deviceKeyAfterUpdateMu sync.Mutex
+var deviceKeyAfterUpdateHooks []DeviceKeyHook
+
+var deviceKeyBeforeDeleteMu sync.Mutex
+var deviceKeyBeforeDeleteHooks []DeviceKeyHook
+var deviceKeyAfterDeleteMu sync.Mutex
+var deviceKeyAfterDeleteHooks []DeviceKeyHook
+
+var deviceKeyBeforeUpsertMu sync.Mutex
+var deviceKeyBeforeUpsertHooks []DeviceKeyHook
+var deviceKeyAfterUpsertMu sync.Mutex
+var deviceKeyAfterUpsertHooks []DeviceKeyHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *DeviceKey) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range deviceKeyAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *DeviceKey) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range deviceKeyBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *DeviceKey) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range deviceKeyAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *DeviceKey) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range deviceKeyBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *DeviceKey) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range deviceKeyAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *DeviceKey) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range deviceKeyBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *DeviceKey) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range deviceKeyAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *DeviceKey) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range deviceKeyBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *DeviceKey) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range deviceKeyAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddDeviceKeyHook registers your hook function for all future operations.
+func AddDeviceKeyHook(hookPoint boil.HookPoint, deviceKeyHook DeviceKeyHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		deviceKeyAfterSelectMu.Lock()
+		deviceKeyAfterSelectHooks = append(deviceKeyAfterSelectHooks, deviceKeyHook)
+		deviceKeyAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		deviceKeyBeforeInsertMu.Lock()
+		deviceKeyBeforeInsertHooks = append(deviceKeyBeforeInsertHooks, deviceKeyHook)
+		deviceKeyBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		deviceKeyAfterInsertMu.Lock()
+		deviceKeyAfterInsertHooks = append(deviceKeyAfterInsertHooks, deviceKeyHook)
+		deviceKeyAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		deviceKeyBeforeUpdateMu.Lock()
+		deviceKeyBeforeUpdateHooks = append(deviceKeyBeforeUpdateHooks, deviceKeyHook)
+		deviceKeyBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		deviceKeyAfterUpdateMu.Lock()
+		deviceKeyAfterUpdateHooks = append(deviceKeyAfterUpdateHooks, deviceKeyHook)
+		deviceKeyAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		deviceKeyBeforeDeleteMu.Lock()
+		deviceKeyBeforeDeleteHooks = append(deviceKeyBeforeDeleteHooks, deviceKeyHook)
+		deviceKeyBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		deviceKeyAfterDeleteMu.Lock()
+		deviceKeyAfterDeleteHooks = append(deviceKeyAfterDeleteHooks, deviceKeyHook)
+		deviceKeyAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		deviceKeyBeforeUpsertMu.Lock()
+		deviceKeyBeforeUpsertHooks = append(deviceKeyBeforeUpsertHooks, deviceKeyHook)
+		deviceKeyBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		deviceKeyAfterUpsertMu.Lock()
+		deviceKeyAfterUpsertHooks = append(deviceKeyAfterUpsertHooks, deviceKeyHook)
+		deviceKeyAfterUpsertMu.Unlock()
+	}
+}
+
+// OneG returns a single deviceKey record from the query using the global executor.
+func (q deviceKeyQuery) OneG(ctx context.Context) (*DeviceKey, error) {
+	return q.One(ctx, boil.GetContextDB())
+}
+
+// One returns a single deviceKey record from the query.
+func (q deviceKeyQuery) One(ctx context.Context, exec boil.ContextExecutor) (*DeviceKey, error) {
+	o := &DeviceKey{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "appdb: failed to execute a one query for device_key")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// AllG returns all DeviceKey records from the query using the global executor.
+func (q deviceKeyQuery) AllG(ctx context.Context) (DeviceKeySlice, error) {
+	return q.All(ctx, boil.GetContextDB())
+}
+
+// All returns all DeviceKey records from the query.
+func (q deviceKeyQuery) All(ctx context.Context, exec boil.ContextExecutor) (DeviceKeySlice, error) {
+	var o []*DeviceKey
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "appdb: failed to assign all query results to DeviceKey slice")
+	}
+
+	if len(deviceKeyAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// CountG returns the count of all DeviceKey records in the query using the global executor
+func (q deviceKeyQuery) CountG(ctx context.Context) (int64, error) {
+	return q.Count(ctx, boil.GetContextDB())
+}
+
+// Count returns the count of all DeviceKey records in the query.
+func (q deviceKeyQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to count device_key rows")
+	}
+
+	return count, nil
+}
+
+// ExistsG checks if the row exists in the table using the global executor.
+func (q deviceKeyQuery) ExistsG(ctx context.Context) (bool, error) {
+	return q.Exists(ctx, boil.GetContextDB())
+}
+
+// Exists checks if the row exists in the table.
+func (q deviceKeyQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "appdb: failed to check if device_key exists")
+	}
+
+	return count > 0, nil
+}
+
+// KeyBatch pointed to by the foreign key.
+func (o *DeviceKey) KeyBatch(mods ...qm.QueryMod) keyBatchQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.KeyBatchID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return KeyBatches(queryMods...)
+}
+
+// LoadKeyBatch allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (deviceKeyL) LoadKeyBatch(ctx context.Context, e boil.ContextExecutor, singular bool, maybeDeviceKey interface{}, mods queries.Applicator) error {
+	var slice []*DeviceKey
+	var object *DeviceKey
+
+	if singular {
+		var ok bool
+		object, ok = maybeDeviceKey.(*DeviceKey)
+		if !ok {
+			object = new(DeviceKey)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeDeviceKey)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeDeviceKey))
+			}
+		}
+	} else {
+		s, ok := maybeDeviceKey.(*[]*DeviceKey)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeDeviceKey)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeDeviceKey))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &deviceKeyR{}
+		}
+		args[object.KeyBatchID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &deviceKeyR{}
+			}
+
+			args[obj.KeyBatchID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`loriot_io.key_batch`),
+		qm.WhereIn(`loriot_io.key_batch.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load KeyBatch")
+	}
+
+	var resultSlice []*KeyBatch
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice KeyBatch")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for key_batch")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for key_batch")
+	}
+
+	if len(keyBatchAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.KeyBatch = foreign
+		if foreign.R == nil {
+			foreign.R = &keyBatchR{}
+		}
+		foreign.R.DeviceKeys = append(foreign.R.DeviceKeys, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.KeyBatchID == foreign.ID {
+				local.R.KeyBatch = foreign
+				if foreign.R == nil {
+					foreign.R = &keyBatchR{}
+				}
+				foreign.R.DeviceKeys = append(foreign.R.DeviceKeys, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetKeyBatchG of the deviceKey to the related item.
+// Sets o.R.KeyBatch to related.
+// Adds o to related.R.DeviceKeys.
+// Uses the global database handle.
+func (o *DeviceKey) SetKeyBatchG(ctx context.Context, insert bool, related *KeyBatch) error {
+	return o.SetKeyBatch(ctx, boil.GetContextDB(), insert, related)
+}
+
+// SetKeyBatch of the deviceKey to the related item.
+// Sets o.R.KeyBatch to related.
+// Adds o to related.R.DeviceKeys.
+func (o *DeviceKey) SetKeyBatch(ctx context.Context, exec boil.ContextExecutor, insert bool, related *KeyBatch) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"loriot_io\".\"device_key\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"key_batch_id"}),
+		strmangle.WhereClause("\"", "\"", 2, deviceKeyPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.DevEui}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.KeyBatchID = related.ID
+	if o.R == nil {
+		o.R = &deviceKeyR{
+			KeyBatch: related,
+		}
+	} else {
+		o.R.KeyBatch = related
+	}
+
+	if related.R == nil {
+		related.R = &keyBatchR{
+			DeviceKeys: DeviceKeySlice{o},
+		}
+	} else {
+		related.R.DeviceKeys = append(related.R.DeviceKeys, o)
+	}
+
+	return nil
+}
+
+// DeviceKeys retrieves all the records using an executor.
+func DeviceKeys(mods ...qm.QueryMod) deviceKeyQuery {
+	mods = append(mods, qm.From("\"loriot_io\".\"device_key\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"loriot_io\".\"device_key\".*"})
+	}
+
+	return deviceKeyQuery{q}
+}
+
+// FindDeviceKeyG retrieves a single record by ID.
+func FindDeviceKeyG(ctx context.Context, devEui string, selectCols ...string) (*DeviceKey, error) {
+	return FindDeviceKey(ctx, boil.GetContextDB(), devEui, selectCols...)
+}
+
+// FindDeviceKey retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindDeviceKey(ctx context.Context, exec boil.ContextExecutor, devEui string, selectCols ...string) (*DeviceKey, error) {
+	deviceKeyObj := &DeviceKey{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"loriot_io\".\"device_key\" where \"dev_eui\"=$1", sel,
+	)
+
+	q := queries.Raw(query, devEui)
+
+	err := q.Bind(ctx, exec, deviceKeyObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "appdb: unable to select from device_key")
+	}
+
+	if err = deviceKeyObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return deviceKeyObj, err
+	}
+
+	return deviceKeyObj, nil
+}
+
+// InsertG a single record. See Insert for whitelist behavior description.
+func (o *DeviceKey) InsertG(ctx context.Context, columns boil.Columns) error {
+	return o.Insert(ctx, boil.GetContextDB(), columns)
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *DeviceKey) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("appdb: no device_key provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(deviceKeyColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	deviceKeyInsertCacheMut.RLock()
+	cache, cached := deviceKeyInsertCache[key]
+	deviceKeyInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			deviceKeyAllColumns,
+			deviceKeyColumnsWithDefault,
+			deviceKeyColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(deviceKeyType, deviceKeyMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(deviceKeyType, deviceKeyMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"loriot_io\".\"device_key\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"loriot_io\".\"device_key\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to insert into device_key")
+	}
+
+	if !cached {
+		deviceKeyInsertCacheMut.Lock()
+		deviceKeyInsertCache[key] = cache
+		deviceKeyInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// UpdateG a single DeviceKey record using the global executor.
+// See Update for more documentation.
+func (o *DeviceKey) UpdateG(ctx context.Context, columns boil.Columns) (int64, error) {
+	return o.Update(ctx, boil.GetContextDB(), columns)
+}
+
+// Update uses an executor to update the DeviceKey.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *DeviceKey) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	deviceKeyUpdateCacheMut.RLock()
+	cache, cached := deviceKeyUpdateCache[key]
+	deviceKeyUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			deviceKeyAllColumns,
+			deviceKeyPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("appdb: unable to update device_key, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"loriot_io\".\"device_key\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, deviceKeyPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(deviceKeyType, deviceKeyMapping, append(wl, deviceKeyPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update device_key row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by update for device_key")
+	}
+
+	if !cached {
+		deviceKeyUpdateCacheMut.Lock()
+		deviceKeyUpdateCache[key] = cache
+		deviceKeyUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (q deviceKeyQuery) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return q.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q deviceKeyQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update all for device_key")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to retrieve rows affected for device_key")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (o DeviceKeySlice) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return o.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o DeviceKeySlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("appdb: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), deviceKeyPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"loriot_io\".\"device_key\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, deviceKeyPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update all in deviceKey slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to retrieve rows affected all in update all deviceKey")
+	}
+	return rowsAff, nil
+}
+
+// UpsertG attempts an insert, and does an update or ignore on conflict.
+func (o *DeviceKey) UpsertG(ctx context.Context, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	return o.Upsert(ctx, boil.GetContextDB(), updateOnConflict, conflictColumns, updateColumns, insertColumns, opts...)
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *DeviceKey) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("appdb: no device_key provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(deviceKeyColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	deviceKeyUpsertCacheMut.RLock()
+	cache, cached := deviceKeyUpsertCache[key]
+	deviceKeyUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			deviceKeyAllColumns,
+			deviceKeyColumnsWithDefault,
+			deviceKeyColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			deviceKeyAllColumns,
+			deviceKeyPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("appdb: unable to upsert device_key, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(deviceKeyAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(deviceKeyPrimaryKeyColumns) == 0 {
+				return errors.New("appdb: unable to upsert device_key, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(deviceKeyPrimaryKeyColumns))
+			copy(conflict, deviceKeyPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"loriot_io\".\"device_key\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(deviceKeyType, deviceKeyMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(deviceKeyType, deviceKeyMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to upsert device_key")
+	}
+
+	if !cached {
+		deviceKeyUpsertCacheMut.Lock()
+		deviceKeyUpsertCache[key] = cache
+		deviceKeyUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// DeleteG deletes a single DeviceKey record.
+// DeleteG will match against the primary key column to find the record to delete.
+func (o *DeviceKey) DeleteG(ctx context.Context) (int64, error) {
+	return o.Delete(ctx, boil.GetContextDB())
+}
+
+// Delete deletes a single DeviceKey record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *DeviceKey) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("appdb: no DeviceKey provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), deviceKeyPrimaryKeyMapping)
+	sql := "DELETE FROM \"loriot_io\".\"device_key\" WHERE \"dev_eui\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete from device_key")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by delete for device_key")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+func (q deviceKeyQuery) DeleteAllG(ctx context.Context) (int64, error) {
+	return q.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all matching rows.
+func (q deviceKeyQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("appdb: no deviceKeyQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete all from device_key")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by deleteall for device_key")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAllG deletes all rows in the slice.
+func (o DeviceKeySlice) DeleteAllG(ctx context.Context) (int64, error) {
+	return o.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o DeviceKeySlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(deviceKeyBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), deviceKeyPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"loriot_io\".\"device_key\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, deviceKeyPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete all from deviceKey slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by deleteall for device_key")
+	}
+
+	if len(deviceKeyAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// ReloadG refetches the object from the database using the primary keys.
+func (o *DeviceKey) ReloadG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("appdb: no DeviceKey provided for reload")
+	}
+
+	return o.Reload(ctx, boil.GetContextDB())
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *DeviceKey) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindDeviceKey(ctx, exec, o.DevEui)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAllG refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *DeviceKeySlice) ReloadAllG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("appdb: empty DeviceKeySlice provided for reload all")
+	}
+
+	return o.ReloadAll(ctx, boil.GetContextDB())
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *DeviceKeySlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := DeviceKeySlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), deviceKeyPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"loriot_io\".\"device_key\".* FROM \"loriot_io\".\"device_key\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, deviceKeyPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to reload all in DeviceKeySlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// DeviceKeyExistsG checks if the DeviceKey row exists.
+func DeviceKeyExistsG(ctx context.Context, devEui string) (bool, error) {
+	return DeviceKeyExists(ctx, boil.GetContextDB(), devEui)
+}
+
+// DeviceKeyExists checks if the DeviceKey row exists.
+func DeviceKeyExists(ctx context.Context, exec boil.ContextExecutor, devEui string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"loriot_io\".\"device_key\" where \"dev_eui\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, devEui)
+	}
+	row := exec.QueryRowContext(ctx, sql, devEui)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "appdb: unable to check if device_key exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the DeviceKey row exists.
+func (o *DeviceKey) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return DeviceKeyExists(ctx, exec, o.DevEui)
+}
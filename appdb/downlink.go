@@ -0,0 +1,1330 @@
+// Code generated by SQLBoiler 4.16.1 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package appdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// Downlink is an object representing the database table.
+type Downlink struct {
+	ID              int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	ConfigurationID int64       `boil:"configuration_id" json:"configuration_id" toml:"configuration_id" yaml:"configuration_id"`
+	AssetID         null.Int32  `boil:"asset_id" json:"asset_id,omitempty" toml:"asset_id" yaml:"asset_id,omitempty"`
+	DevEui          string      `boil:"dev_eui" json:"dev_eui" toml:"dev_eui" yaml:"dev_eui"`
+	Port            int32       `boil:"port" json:"port" toml:"port" yaml:"port"`
+	Confirmed       bool        `boil:"confirmed" json:"confirmed" toml:"confirmed" yaml:"confirmed"`
+	Payload         string      `boil:"payload" json:"payload" toml:"payload" yaml:"payload"`
+	Status          string      `boil:"status" json:"status" toml:"status" yaml:"status"`
+	Error           null.String `boil:"error" json:"error,omitempty" toml:"error" yaml:"error,omitempty"`
+	CreatedAt       time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	ModifiedAt      null.Time   `boil:"modified_at" json:"modified_at,omitempty" toml:"modified_at" yaml:"modified_at,omitempty"`
+	UserID          null.String `boil:"user_id" json:"user_id,omitempty" toml:"user_id" yaml:"user_id,omitempty"`
+
+	R *downlinkR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L downlinkL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var DownlinkColumns = struct {
+	ID              string
+	ConfigurationID string
+	AssetID         string
+	DevEui          string
+	Port            string
+	Confirmed       string
+	Payload         string
+	Status          string
+	Error           string
+	CreatedAt       string
+	ModifiedAt      string
+	UserID          string
+}{
+	ID:              "id",
+	ConfigurationID: "configuration_id",
+	AssetID:         "asset_id",
+	DevEui:          "dev_eui",
+	Port:            "port",
+	Confirmed:       "confirmed",
+	Payload:         "payload",
+	Status:          "status",
+	Error:           "error",
+	CreatedAt:       "created_at",
+	ModifiedAt:      "modified_at",
+	UserID:          "user_id",
+}
+
+var DownlinkTableColumns = struct {
+	ID              string
+	ConfigurationID string
+	AssetID         string
+	DevEui          string
+	Port            string
+	Confirmed       string
+	Payload         string
+	Status          string
+	Error           string
+	CreatedAt       string
+	ModifiedAt      string
+	UserID          string
+}{
+	ID:              "downlink.id",
+	ConfigurationID: "downlink.configuration_id",
+	AssetID:         "downlink.asset_id",
+	DevEui:          "downlink.dev_eui",
+	Port:            "downlink.port",
+	Confirmed:       "downlink.confirmed",
+	Payload:         "downlink.payload",
+	Status:          "downlink.status",
+	Error:           "downlink.error",
+	CreatedAt:       "downlink.created_at",
+	ModifiedAt:      "downlink.modified_at",
+	UserID:          "downlink.user_id",
+}
+
+// Generated where
+
+type whereHelperbool struct{ field string }
+
+func (w whereHelperbool) EQ(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperbool) NEQ(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperbool) LT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperbool) LTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+
+type whereHelpertime_Time struct{ field string }
+
+func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.EQ, x)
+}
+func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+var DownlinkWhere = struct {
+	ID              whereHelperint64
+	ConfigurationID whereHelperint64
+	AssetID         whereHelpernull_Int32
+	DevEui          whereHelperstring
+	Port            whereHelperint32
+	Confirmed       whereHelperbool
+	Payload         whereHelperstring
+	Status          whereHelperstring
+	Error           whereHelpernull_String
+	CreatedAt       whereHelpertime_Time
+	ModifiedAt      whereHelpernull_Time
+	UserID          whereHelpernull_String
+}{
+	ID:              whereHelperint64{field: "\"loriot_io\".\"downlink\".\"id\""},
+	ConfigurationID: whereHelperint64{field: "\"loriot_io\".\"downlink\".\"configuration_id\""},
+	AssetID:         whereHelpernull_Int32{field: "\"loriot_io\".\"downlink\".\"asset_id\""},
+	DevEui:          whereHelperstring{field: "\"loriot_io\".\"downlink\".\"dev_eui\""},
+	Port:            whereHelperint32{field: "\"loriot_io\".\"downlink\".\"port\""},
+	Confirmed:       whereHelperbool{field: "\"loriot_io\".\"downlink\".\"confirmed\""},
+	Payload:         whereHelperstring{field: "\"loriot_io\".\"downlink\".\"payload\""},
+	Status:          whereHelperstring{field: "\"loriot_io\".\"downlink\".\"status\""},
+	Error:           whereHelpernull_String{field: "\"loriot_io\".\"downlink\".\"error\""},
+	CreatedAt:       whereHelpertime_Time{field: "\"loriot_io\".\"downlink\".\"created_at\""},
+	ModifiedAt:      whereHelpernull_Time{field: "\"loriot_io\".\"downlink\".\"modified_at\""},
+	UserID:          whereHelpernull_String{field: "\"loriot_io\".\"downlink\".\"user_id\""},
+}
+
+// DownlinkRels is where relationship names are stored.
+var DownlinkRels = struct {
+	Configuration string
+}{
+	Configuration: "Configuration",
+}
+
+// downlinkR is where relationships are stored.
+type downlinkR struct {
+	Configuration *Configuration `boil:"Configuration" json:"Configuration" toml:"Configuration" yaml:"Configuration"`
+}
+
+// NewStruct creates a new relationship struct
+func (*downlinkR) NewStruct() *downlinkR {
+	return &downlinkR{}
+}
+
+func (r *downlinkR) GetConfiguration() *Configuration {
+	if r == nil {
+		return nil
+	}
+	return r.Configuration
+}
+
+// downlinkL is where Load methods for each relationship are stored.
+type downlinkL struct{}
+
+var (
+	downlinkAllColumns            = []string{"id", "configuration_id", "asset_id", "dev_eui", "port", "confirmed", "payload", "status", "error", "created_at", "modified_at", "user_id"}
+	downlinkColumnsWithoutDefault = []string{"configuration_id", "dev_eui", "port", "payload", "status"}
+	downlinkColumnsWithDefault    = []string{"id", "asset_id", "confirmed", "error", "created_at", "modified_at", "user_id"}
+	downlinkPrimaryKeyColumns     = []string{"id"}
+	downlinkGeneratedColumns      = []string{}
+)
+
+type (
+	// DownlinkSlice is an alias for a slice of pointers to Downlink.
+	// This should almost always be used instead of []Downlink.
+	DownlinkSlice []*Downlink
+	// DownlinkHook is the signature for custom Downlink hook methods
+	DownlinkHook func(context.Context, boil.ContextExecutor, *Downlink) error
+
+	downlinkQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	downlinkType                 = reflect.TypeOf(&Downlink{})
+	downlinkMapping              = queries.MakeStructMapping(downlinkType)
+	downlinkPrimaryKeyMapping, _ = queries.BindMapping(downlinkType, downlinkMapping, downlinkPrimaryKeyColumns)
+	downlinkInsertCacheMut       sync.RWMutex
+	downlinkInsertCache          = make(map[string]insertCache)
+	downlinkUpdateCacheMut       sync.RWMutex
+	downlinkUpdateCache          = make(map[string]updateCache)
+	downlinkUpsertCacheMut       sync.RWMutex
+	downlinkUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var downlinkAfterSelectMu sync.Mutex
+var downlinkAfterSelectHooks []DownlinkHook
+
+var downlinkBeforeInsertMu sync.Mutex
+var downlinkBeforeInsertHooks []DownlinkHook
+var downlinkAfterInsertMu sync.Mutex
+var downlinkAfterInsertHooks []DownlinkHook
+
+var downlinkBeforeUpdateMu sync.Mutex
+var downlinkBeforeUpdateHooks []DownlinkHook
+var downlinkAfterUpdateMu sync.Mutex
+var downlinkAfterUpdateHooks []DownlinkHook
+
+var downlinkBeforeDeleteMu sync.Mutex
+var downlinkBeforeDeleteHooks []DownlinkHook
+var downlinkAfterDeleteMu sync.Mutex
+var downlinkAfterDeleteHooks []DownlinkHook
+
+var downlinkBeforeUpsertMu sync.Mutex
+var downlinkBeforeUpsertHooks []DownlinkHook
+var downlinkAfterUpsertMu sync.Mutex
+var downlinkAfterUpsertHooks []DownlinkHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *Downlink) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range downlinkAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *Downlink) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range downlinkBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *Downlink) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range downlinkAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *Downlink) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range downlinkBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *Downlink) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range downlinkAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *Downlink) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range downlinkBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *Downlink) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range downlinkAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *Downlink) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range downlinkBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *Downlink) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range downlinkAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddDownlinkHook registers your hook function for all future operations.
+func AddDownlinkHook(hookPoint boil.HookPoint, downlinkHook DownlinkHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		downlinkAfterSelectMu.Lock()
+		downlinkAfterSelectHooks = append(downlinkAfterSelectHooks, downlinkHook)
+		downlinkAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		downlinkBeforeInsertMu.Lock()
+		downlinkBeforeInsertHooks = append(downlinkBeforeInsertHooks, downlinkHook)
+		downlinkBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		downlinkAfterInsertMu.Lock()
+		downlinkAfterInsertHooks = append(downlinkAfterInsertHooks, downlinkHook)
+		downlinkAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		downlinkBeforeUpdateMu.Lock()
+		downlinkBeforeUpdateHooks = append(downlinkBeforeUpdateHooks, downlinkHook)
+		downlinkBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		downlinkAfterUpdateMu.Lock()
+		downlinkAfterUpdateHooks = append(downlinkAfterUpdateHooks, downlinkHook)
+		downlinkAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		downlinkBeforeDeleteMu.Lock()
+		downlinkBeforeDeleteHooks = append(downlinkBeforeDeleteHooks, downlinkHook)
+		downlinkBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		downlinkAfterDeleteMu.Lock()
+		downlinkAfterDeleteHooks = append(downlinkAfterDeleteHooks, downlinkHook)
+		downlinkAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		downlinkBeforeUpsertMu.Lock()
+		downlinkBeforeUpsertHooks = append(downlinkBeforeUpsertHooks, downlinkHook)
+		downlinkBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		downlinkAfterUpsertMu.Lock()
+		downlinkAfterUpsertHooks = append(downlinkAfterUpsertHooks, downlinkHook)
+		downlinkAfterUpsertMu.Unlock()
+	}
+}
+
+// OneG returns a single downlink record from the query using the global executor.
+func (q downlinkQuery) OneG(ctx context.Context) (*Downlink, error) {
+	return q.One(ctx, boil.GetContextDB())
+}
+
+// One returns a single downlink record from the query.
+func (q downlinkQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Downlink, error) {
+	o := &Downlink{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "appdb: failed to execute a one query for downlink")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// AllG returns all Downlink records from the query using the global executor.
+func (q downlinkQuery) AllG(ctx context.Context) (DownlinkSlice, error) {
+	return q.All(ctx, boil.GetContextDB())
+}
+
+// All returns all Downlink records from the query.
+func (q downlinkQuery) All(ctx context.Context, exec boil.ContextExecutor) (DownlinkSlice, error) {
+	var o []*Downlink
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "appdb: failed to assign all query results to Downlink slice")
+	}
+
+	if len(downlinkAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// CountG returns the count of all Downlink records in the query using the global executor
+func (q downlinkQuery) CountG(ctx context.Context) (int64, error) {
+	return q.Count(ctx, boil.GetContextDB())
+}
+
+// Count returns the count of all Downlink records in the query.
+func (q downlinkQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to count downlink rows")
+	}
+
+	return count, nil
+}
+
+// ExistsG checks if the row exists in the table using the global executor.
+func (q downlinkQuery) ExistsG(ctx context.Context) (bool, error) {
+	return q.Exists(ctx, boil.GetContextDB())
+}
+
+// Exists checks if the row exists in the table.
+func (q downlinkQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "appdb: failed to check if downlink exists")
+	}
+
+	return count > 0, nil
+}
+
+// Configuration pointed to by the foreign key.
+func (o *Downlink) Configuration(mods ...qm.QueryMod) configurationQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.ConfigurationID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Configurations(queryMods...)
+}
+
+// LoadConfiguration allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (downlinkL) LoadConfiguration(ctx context.Context, e boil.ContextExecutor, singular bool, maybeDownlink interface{}, mods queries.Applicator) error {
+	var slice []*Downlink
+	var object *Downlink
+
+	if singular {
+		var ok bool
+		object, ok = maybeDownlink.(*Downlink)
+		if !ok {
+			object = new(Downlink)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeDownlink)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeDownlink))
+			}
+		}
+	} else {
+		s, ok := maybeDownlink.(*[]*Downlink)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeDownlink)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeDownlink))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &downlinkR{}
+		}
+		args[object.ConfigurationID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &downlinkR{}
+			}
+
+			args[obj.ConfigurationID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`loriot_io.configuration`),
+		qm.WhereIn(`loriot_io.configuration.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Configuration")
+	}
+
+	var resultSlice []*Configuration
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Configuration")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for configuration")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for configuration")
+	}
+
+	if len(configurationAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Configuration = foreign
+		if foreign.R == nil {
+			foreign.R = &configurationR{}
+		}
+		foreign.R.Downlinks = append(foreign.R.Downlinks, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ConfigurationID == foreign.ID {
+				local.R.Configuration = foreign
+				if foreign.R == nil {
+					foreign.R = &configurationR{}
+				}
+				foreign.R.Downlinks = append(foreign.R.Downlinks, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetConfigurationG of the downlink to the related item.
+// Sets o.R.Configuration to related.
+// Adds o to related.R.Downlinks.
+// Uses the global database handle.
+func (o *Downlink) SetConfigurationG(ctx context.Context, insert bool, related *Configuration) error {
+	return o.SetConfiguration(ctx, boil.GetContextDB(), insert, related)
+}
+
+// SetConfiguration of the downlink to the related item.
+// Sets o.R.Configuration to related.
+// Adds o to related.R.Downlinks.
+func (o *Downlink) SetConfiguration(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Configuration) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"loriot_io\".\"downlink\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"configuration_id"}),
+		strmangle.WhereClause("\"", "\"", 2, downlinkPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.ConfigurationID = related.ID
+	if o.R == nil {
+		o.R = &downlinkR{
+			Configuration: related,
+		}
+	} else {
+		o.R.Configuration = related
+	}
+
+	if related.R == nil {
+		related.R = &configurationR{
+			Downlinks: DownlinkSlice{o},
+		}
+	} else {
+		related.R.Downlinks = append(related.R.Downlinks, o)
+	}
+
+	return nil
+}
+
+// Downlinks retrieves all the records using an executor.
+func Downlinks(mods ...qm.QueryMod) downlinkQuery {
+	mods = append(mods, qm.From("\"loriot_io\".\"downlink\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"loriot_io\".\"downlink\".*"})
+	}
+
+	return downlinkQuery{q}
+}
+
+// FindDownlinkG retrieves a single record by ID.
+func FindDownlinkG(ctx context.Context, iD int64, selectCols ...string) (*Downlink, error) {
+	return FindDownlink(ctx, boil.GetContextDB(), iD, selectCols...)
+}
+
+// FindDownlink retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindDownlink(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*Downlink, error) {
+	downlinkObj := &Downlink{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"loriot_io\".\"downlink\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, downlinkObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "appdb: unable to select from downlink")
+	}
+
+	if err = downlinkObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return downlinkObj, err
+	}
+
+	return downlinkObj, nil
+}
+
+// InsertG a single record. See Insert for whitelist behavior description.
+func (o *Downlink) InsertG(ctx context.Context, columns boil.Columns) error {
+	return o.Insert(ctx, boil.GetContextDB(), columns)
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Downlink) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("appdb: no downlink provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(downlinkColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	downlinkInsertCacheMut.RLock()
+	cache, cached := downlinkInsertCache[key]
+	downlinkInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			downlinkAllColumns,
+			downlinkColumnsWithDefault,
+			downlinkColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(downlinkType, downlinkMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(downlinkType, downlinkMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"loriot_io\".\"downlink\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"loriot_io\".\"downlink\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to insert into downlink")
+	}
+
+	if !cached {
+		downlinkInsertCacheMut.Lock()
+		downlinkInsertCache[key] = cache
+		downlinkInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// UpdateG a single Downlink record using the global executor.
+// See Update for more documentation.
+func (o *Downlink) UpdateG(ctx context.Context, columns boil.Columns) (int64, error) {
+	return o.Update(ctx, boil.GetContextDB(), columns)
+}
+
+// Update uses an executor to update the Downlink.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Downlink) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	downlinkUpdateCacheMut.RLock()
+	cache, cached := downlinkUpdateCache[key]
+	downlinkUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			downlinkAllColumns,
+			downlinkPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("appdb: unable to update downlink, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"loriot_io\".\"downlink\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, downlinkPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(downlinkType, downlinkMapping, append(wl, downlinkPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update downlink row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by update for downlink")
+	}
+
+	if !cached {
+		downlinkUpdateCacheMut.Lock()
+		downlinkUpdateCache[key] = cache
+		downlinkUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (q downlinkQuery) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return q.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q downlinkQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update all for downlink")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to retrieve rows affected for downlink")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (o DownlinkSlice) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return o.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o DownlinkSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("appdb: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), downlinkPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"loriot_io\".\"downlink\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, downlinkPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update all in downlink slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to retrieve rows affected all in update all downlink")
+	}
+	return rowsAff, nil
+}
+
+// UpsertG attempts an insert, and does an update or ignore on conflict.
+func (o *Downlink) UpsertG(ctx context.Context, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	return o.Upsert(ctx, boil.GetContextDB(), updateOnConflict, conflictColumns, updateColumns, insertColumns, opts...)
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Downlink) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("appdb: no downlink provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(downlinkColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	downlinkUpsertCacheMut.RLock()
+	cache, cached := downlinkUpsertCache[key]
+	downlinkUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			downlinkAllColumns,
+			downlinkColumnsWithDefault,
+			downlinkColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			downlinkAllColumns,
+			downlinkPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("appdb: unable to upsert downlink, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(downlinkAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(downlinkPrimaryKeyColumns) == 0 {
+				return errors.New("appdb: unable to upsert downlink, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(downlinkPrimaryKeyColumns))
+			copy(conflict, downlinkPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"loriot_io\".\"downlink\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(downlinkType, downlinkMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(downlinkType, downlinkMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to upsert downlink")
+	}
+
+	if !cached {
+		downlinkUpsertCacheMut.Lock()
+		downlinkUpsertCache[key] = cache
+		downlinkUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// DeleteG deletes a single Downlink record.
+// DeleteG will match against the primary key column to find the record to delete.
+func (o *Downlink) DeleteG(ctx context.Context) (int64, error) {
+	return o.Delete(ctx, boil.GetContextDB())
+}
+
+// Delete deletes a single Downlink record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Downlink) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("appdb: no Downlink provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), downlinkPrimaryKeyMapping)
+	sql := "DELETE FROM \"loriot_io\".\"downlink\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete from downlink")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by delete for downlink")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+func (q downlinkQuery) DeleteAllG(ctx context.Context) (int64, error) {
+	return q.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all matching rows.
+func (q downlinkQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("appdb: no downlinkQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete all from downlink")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by deleteall for downlink")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAllG deletes all rows in the slice.
+func (o DownlinkSlice) DeleteAllG(ctx context.Context) (int64, error) {
+	return o.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o DownlinkSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(downlinkBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), downlinkPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"loriot_io\".\"downlink\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, downlinkPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete all from downlink slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by deleteall for downlink")
+	}
+
+	if len(downlinkAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// ReloadG refetches the object from the database using the primary keys.
+func (o *Downlink) ReloadG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("appdb: no Downlink provided for reload")
+	}
+
+	return o.Reload(ctx, boil.GetContextDB())
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Downlink) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindDownlink(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAllG refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *DownlinkSlice) ReloadAllG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("appdb: empty DownlinkSlice provided for reload all")
+	}
+
+	return o.ReloadAll(ctx, boil.GetContextDB())
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *DownlinkSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := DownlinkSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), downlinkPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"loriot_io\".\"downlink\".* FROM \"loriot_io\".\"downlink\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, downlinkPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to reload all in DownlinkSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// DownlinkExistsG checks if the Downlink row exists.
+func DownlinkExistsG(ctx context.Context, iD int64) (bool, error) {
+	return DownlinkExists(ctx, boil.GetContextDB(), iD)
+}
+
+// DownlinkExists checks if the Downlink row exists.
+func DownlinkExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"loriot_io\".\"downlink\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "appdb: unable to check if downlink exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the Downlink row exists.
+func (o *Downlink) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return DownlinkExists(ctx, exec, o.ID)
+}
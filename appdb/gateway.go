@@ -0,0 +1,1279 @@
+// Code generated by SQLBoiler 4.16.1 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package appdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// Gateway is an object representing the database table.
+type Gateway struct {
+	AssetID           int32      `boil:"asset_id" json:"asset_id" toml:"asset_id" yaml:"asset_id"`
+	ConfigurationID   int64      `boil:"configuration_id" json:"configuration_id" toml:"configuration_id" yaml:"configuration_id"`
+	ProjectID         string     `boil:"project_id" json:"project_id" toml:"project_id" yaml:"project_id"`
+	GlobalAssetID     string     `boil:"global_asset_id" json:"global_asset_id" toml:"global_asset_id" yaml:"global_asset_id"`
+	NetworkID         string     `boil:"network_id" json:"network_id" toml:"network_id" yaml:"network_id"`
+	GatewayID         string     `boil:"gateway_id" json:"gateway_id" toml:"gateway_id" yaml:"gateway_id"`
+	Eui               string     `boil:"eui" json:"eui" toml:"eui" yaml:"eui"`
+	ModifiedAt        null.Time  `boil:"modified_at" json:"modified_at,omitempty" toml:"modified_at" yaml:"modified_at,omitempty"`
+	DisconnectedSince null.Time  `boil:"disconnected_since" json:"disconnected_since,omitempty" toml:"disconnected_since" yaml:"disconnected_since,omitempty"`
+	Offline           bool       `boil:"offline" json:"offline" toml:"offline" yaml:"offline"`
+	AlarmRuleID       null.Int32 `boil:"alarm_rule_id" json:"alarm_rule_id,omitempty" toml:"alarm_rule_id" yaml:"alarm_rule_id,omitempty"`
+
+	R *gatewayR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L gatewayL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var GatewayColumns = struct {
+	AssetID           string
+	ConfigurationID   string
+	ProjectID         string
+	GlobalAssetID     string
+	NetworkID         string
+	GatewayID         string
+	Eui               string
+	ModifiedAt        string
+	DisconnectedSince string
+	Offline           string
+	AlarmRuleID       string
+}{
+	AssetID:           "asset_id",
+	ConfigurationID:   "configuration_id",
+	ProjectID:         "project_id",
+	GlobalAssetID:     "global_asset_id",
+	NetworkID:         "network_id",
+	GatewayID:         "gateway_id",
+	Eui:               "eui",
+	ModifiedAt:        "modified_at",
+	DisconnectedSince: "disconnected_since",
+	Offline:           "offline",
+	AlarmRuleID:       "alarm_rule_id",
+}
+
+var GatewayTableColumns = struct {
+	AssetID           string
+	ConfigurationID   string
+	ProjectID         string
+	GlobalAssetID     string
+	NetworkID         string
+	GatewayID         string
+	Eui               string
+	ModifiedAt        string
+	DisconnectedSince string
+	Offline           string
+	AlarmRuleID       string
+}{
+	AssetID:           "gateway.asset_id",
+	ConfigurationID:   "gateway.configuration_id",
+	ProjectID:         "gateway.project_id",
+	GlobalAssetID:     "gateway.global_asset_id",
+	NetworkID:         "gateway.network_id",
+	GatewayID:         "gateway.gateway_id",
+	Eui:               "gateway.eui",
+	ModifiedAt:        "gateway.modified_at",
+	DisconnectedSince: "gateway.disconnected_since",
+	Offline:           "gateway.offline",
+	AlarmRuleID:       "gateway.alarm_rule_id",
+}
+
+// Generated where
+
+var GatewayWhere = struct {
+	AssetID           whereHelperint32
+	ConfigurationID   whereHelperint64
+	ProjectID         whereHelperstring
+	GlobalAssetID     whereHelperstring
+	NetworkID         whereHelperstring
+	GatewayID         whereHelperstring
+	Eui               whereHelperstring
+	ModifiedAt        whereHelpernull_Time
+	DisconnectedSince whereHelpernull_Time
+	Offline           whereHelperbool
+	AlarmRuleID       whereHelpernull_Int32
+}{
+	AssetID:           whereHelperint32{field: "\"loriot_io\".\"gateway\".\"asset_id\""},
+	ConfigurationID:   whereHelperint64{field: "\"loriot_io\".\"gateway\".\"configuration_id\""},
+	ProjectID:         whereHelperstring{field: "\"loriot_io\".\"gateway\".\"project_id\""},
+	GlobalAssetID:     whereHelperstring{field: "\"loriot_io\".\"gateway\".\"global_asset_id\""},
+	NetworkID:         whereHelperstring{field: "\"loriot_io\".\"gateway\".\"network_id\""},
+	GatewayID:         whereHelperstring{field: "\"loriot_io\".\"gateway\".\"gateway_id\""},
+	Eui:               whereHelperstring{field: "\"loriot_io\".\"gateway\".\"eui\""},
+	ModifiedAt:        whereHelpernull_Time{field: "\"loriot_io\".\"gateway\".\"modified_at\""},
+	DisconnectedSince: whereHelpernull_Time{field: "\"loriot_io\".\"gateway\".\"disconnected_since\""},
+	Offline:           whereHelperbool{field: "\"loriot_io\".\"gateway\".\"offline\""},
+	AlarmRuleID:       whereHelpernull_Int32{field: "\"loriot_io\".\"gateway\".\"alarm_rule_id\""},
+}
+
+// GatewayRels is where relationship names are stored.
+var GatewayRels = struct {
+	Configuration string
+}{
+	Configuration: "Configuration",
+}
+
+// gatewayR is where relationships are stored.
+type gatewayR struct {
+	Configuration *Configuration `boil:"Configuration" json:"Configuration" toml:"Configuration" yaml:"Configuration"`
+}
+
+// NewStruct creates a new relationship struct
+func (*gatewayR) NewStruct() *gatewayR {
+	return &gatewayR{}
+}
+
+func (r *gatewayR) GetConfiguration() *Configuration {
+	if r == nil {
+		return nil
+	}
+	return r.Configuration
+}
+
+// gatewayL is where Load methods for each relationship are stored.
+type gatewayL struct{}
+
+var (
+	gatewayAllColumns            = []string{"asset_id", "configuration_id", "project_id", "global_asset_id", "network_id", "gateway_id", "eui", "modified_at", "disconnected_since", "offline", "alarm_rule_id"}
+	gatewayColumnsWithoutDefault = []string{"asset_id", "configuration_id", "project_id", "global_asset_id", "network_id", "gateway_id", "eui"}
+	gatewayColumnsWithDefault    = []string{"modified_at", "disconnected_since", "offline", "alarm_rule_id"}
+	gatewayPrimaryKeyColumns     = []string{"asset_id"}
+	gatewayGeneratedColumns      = []string{}
+)
+
+type (
+	// GatewaySlice is an alias for a slice of pointers to Gateway.
+	// This should almost always be used instead of []Gateway.
+	GatewaySlice []*Gateway
+	// GatewayHook is the signature for custom Gateway hook methods
+	GatewayHook func(context.Context, boil.ContextExecutor, *Gateway) error
+
+	gatewayQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	gatewayType                 = reflect.TypeOf(&Gateway{})
+	gatewayMapping              = queries.MakeStructMapping(gatewayType)
+	gatewayPrimaryKeyMapping, _ = queries.BindMapping(gatewayType, gatewayMapping, gatewayPrimaryKeyColumns)
+	gatewayInsertCacheMut       sync.RWMutex
+	gatewayInsertCache          = make(map[string]insertCache)
+	gatewayUpdateCacheMut       sync.RWMutex
+	gatewayUpdateCache          = make(map[string]updateCache)
+	gatewayUpsertCacheMut       sync.RWMutex
+	gatewayUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var gatewayAfterSelectMu sync.Mutex
+var gatewayAfterSelectHooks []GatewayHook
+
+var gatewayBeforeInsertMu sync.Mutex
+var gatewayBeforeInsertHooks []GatewayHook
+var gatewayAfterInsertMu sync.Mutex
+var gatewayAfterInsertHooks []GatewayHook
+
+var gatewayBeforeUpdateMu sync.Mutex
+var gatewayBeforeUpdateHooks []GatewayHook
+var gatewayAfterUpdateMu sync.Mutex
+var gatewayAfterUpdateHooks []GatewayHook
+
+var gatewayBeforeDeleteMu sync.Mutex
+var gatewayBeforeDeleteHooks []GatewayHook
+var gatewayAfterDeleteMu sync.Mutex
+var gatewayAfterDeleteHooks []GatewayHook
+
+var gatewayBeforeUpsertMu sync.Mutex
+var gatewayBeforeUpsertHooks []GatewayHook
+var gatewayAfterUpsertMu sync.Mutex
+var gatewayAfterUpsertHooks []GatewayHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *Gateway) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range gatewayAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *Gateway) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range gatewayBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *Gateway) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range gatewayAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *Gateway) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range gatewayBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *Gateway) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range gatewayAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *Gateway) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range gatewayBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *Gateway) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range gatewayAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *Gateway) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range gatewayBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *Gateway) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range gatewayAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddGatewayHook registers your hook function for all future operations.
+func AddGatewayHook(hookPoint boil.HookPoint, gatewayHook GatewayHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		gatewayAfterSelectMu.Lock()
+		gatewayAfterSelectHooks = append(gatewayAfterSelectHooks, gatewayHook)
+		gatewayAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		gatewayBeforeInsertMu.Lock()
+		gatewayBeforeInsertHooks = append(gatewayBeforeInsertHooks, gatewayHook)
+		gatewayBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		gatewayAfterInsertMu.Lock()
+		gatewayAfterInsertHooks = append(gatewayAfterInsertHooks, gatewayHook)
+		gatewayAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		gatewayBeforeUpdateMu.Lock()
+		gatewayBeforeUpdateHooks = append(gatewayBeforeUpdateHooks, gatewayHook)
+		gatewayBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		gatewayAfterUpdateMu.Lock()
+		gatewayAfterUpdateHooks = append(gatewayAfterUpdateHooks, gatewayHook)
+		gatewayAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		gatewayBeforeDeleteMu.Lock()
+		gatewayBeforeDeleteHooks = append(gatewayBeforeDeleteHooks, gatewayHook)
+		gatewayBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		gatewayAfterDeleteMu.Lock()
+		gatewayAfterDeleteHooks = append(gatewayAfterDeleteHooks, gatewayHook)
+		gatewayAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		gatewayBeforeUpsertMu.Lock()
+		gatewayBeforeUpsertHooks = append(gatewayBeforeUpsertHooks, gatewayHook)
+		gatewayBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		gatewayAfterUpsertMu.Lock()
+		gatewayAfterUpsertHooks = append(gatewayAfterUpsertHooks, gatewayHook)
+		gatewayAfterUpsertMu.Unlock()
+	}
+}
+
+// OneG returns a single gateway record from the query using the global executor.
+func (q gatewayQuery) OneG(ctx context.Context) (*Gateway, error) {
+	return q.One(ctx, boil.GetContextDB())
+}
+
+// One returns a single gateway record from the query.
+func (q gatewayQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Gateway, error) {
+	o := &Gateway{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "appdb: failed to execute a one query for gateway")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// AllG returns all Gateway records from the query using the global executor.
+func (q gatewayQuery) AllG(ctx context.Context) (GatewaySlice, error) {
+	return q.All(ctx, boil.GetContextDB())
+}
+
+// All returns all Gateway records from the query.
+func (q gatewayQuery) All(ctx context.Context, exec boil.ContextExecutor) (GatewaySlice, error) {
+	var o []*Gateway
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "appdb: failed to assign all query results to Gateway slice")
+	}
+
+	if len(gatewayAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// CountG returns the count of all Gateway records in the query using the global executor
+func (q gatewayQuery) CountG(ctx context.Context) (int64, error) {
+	return q.Count(ctx, boil.GetContextDB())
+}
+
+// Count returns the count of all Gateway records in the query.
+func (q gatewayQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to count gateway rows")
+	}
+
+	return count, nil
+}
+
+// ExistsG checks if the row exists in the table using the global executor.
+func (q gatewayQuery) ExistsG(ctx context.Context) (bool, error) {
+	return q.Exists(ctx, boil.GetContextDB())
+}
+
+// Exists checks if the row exists in the table.
+func (q gatewayQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "appdb: failed to check if gateway exists")
+	}
+
+	return count > 0, nil
+}
+
+// Configuration pointed to by the foreign key.
+func (o *Gateway) Configuration(mods ...qm.QueryMod) configurationQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.ConfigurationID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Configurations(queryMods...)
+}
+
+// LoadConfiguration allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (gatewayL) LoadConfiguration(ctx context.Context, e boil.ContextExecutor, singular bool, maybeGateway interface{}, mods queries.Applicator) error {
+	var slice []*Gateway
+	var object *Gateway
+
+	if singular {
+		var ok bool
+		object, ok = maybeGateway.(*Gateway)
+		if !ok {
+			object = new(Gateway)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeGateway)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeGateway))
+			}
+		}
+	} else {
+		s, ok := maybeGateway.(*[]*Gateway)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeGateway)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeGateway))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &gatewayR{}
+		}
+		args[object.ConfigurationID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &gatewayR{}
+			}
+
+			args[obj.ConfigurationID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`loriot_io.configuration`),
+		qm.WhereIn(`loriot_io.configuration.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Configuration")
+	}
+
+	var resultSlice []*Configuration
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Configuration")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for configuration")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for configuration")
+	}
+
+	if len(configurationAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Configuration = foreign
+		if foreign.R == nil {
+			foreign.R = &configurationR{}
+		}
+		foreign.R.Gateways = append(foreign.R.Gateways, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ConfigurationID == foreign.ID {
+				local.R.Configuration = foreign
+				if foreign.R == nil {
+					foreign.R = &configurationR{}
+				}
+				foreign.R.Gateways = append(foreign.R.Gateways, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetConfigurationG of the gateway to the related item.
+// Sets o.R.Configuration to related.
+// Adds o to related.R.Gateways.
+// Uses the global database handle.
+func (o *Gateway) SetConfigurationG(ctx context.Context, insert bool, related *Configuration) error {
+	return o.SetConfiguration(ctx, boil.GetContextDB(), insert, related)
+}
+
+// SetConfiguration of the gateway to the related item.
+// Sets o.R.Configuration to related.
+// Adds o to related.R.Gateways.
+func (o *Gateway) SetConfiguration(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Configuration) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"loriot_io\".\"gateway\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"configuration_id"}),
+		strmangle.WhereClause("\"", "\"", 2, gatewayPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.AssetID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.ConfigurationID = related.ID
+	if o.R == nil {
+		o.R = &gatewayR{
+			Configuration: related,
+		}
+	} else {
+		o.R.Configuration = related
+	}
+
+	if related.R == nil {
+		related.R = &configurationR{
+			Gateways: GatewaySlice{o},
+		}
+	} else {
+		related.R.Gateways = append(related.R.Gateways, o)
+	}
+
+	return nil
+}
+
+// Gateways retrieves all the records using an executor.
+func Gateways(mods ...qm.QueryMod) gatewayQuery {
+	mods = append(mods, qm.From("\"loriot_io\".\"gateway\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"loriot_io\".\"gateway\".*"})
+	}
+
+	return gatewayQuery{q}
+}
+
+// FindGatewayG retrieves a single record by ID.
+func FindGatewayG(ctx context.Context, assetID int32, selectCols ...string) (*Gateway, error) {
+	return FindGateway(ctx, boil.GetContextDB(), assetID, selectCols...)
+}
+
+// FindGateway retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindGateway(ctx context.Context, exec boil.ContextExecutor, assetID int32, selectCols ...string) (*Gateway, error) {
+	gatewayObj := &Gateway{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"loriot_io\".\"gateway\" where \"asset_id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, assetID)
+
+	err := q.Bind(ctx, exec, gatewayObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "appdb: unable to select from gateway")
+	}
+
+	if err = gatewayObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return gatewayObj, err
+	}
+
+	return gatewayObj, nil
+}
+
+// InsertG a single record. See Insert for whitelist behavior description.
+func (o *Gateway) InsertG(ctx context.Context, columns boil.Columns) error {
+	return o.Insert(ctx, boil.GetContextDB(), columns)
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Gateway) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("appdb: no gateway provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(gatewayColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	gatewayInsertCacheMut.RLock()
+	cache, cached := gatewayInsertCache[key]
+	gatewayInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			gatewayAllColumns,
+			gatewayColumnsWithDefault,
+			gatewayColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(gatewayType, gatewayMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(gatewayType, gatewayMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"loriot_io\".\"gateway\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"loriot_io\".\"gateway\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to insert into gateway")
+	}
+
+	if !cached {
+		gatewayInsertCacheMut.Lock()
+		gatewayInsertCache[key] = cache
+		gatewayInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// UpdateG a single Gateway record using the global executor.
+// See Update for more documentation.
+func (o *Gateway) UpdateG(ctx context.Context, columns boil.Columns) (int64, error) {
+	return o.Update(ctx, boil.GetContextDB(), columns)
+}
+
+// Update uses an executor to update the Gateway.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Gateway) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	gatewayUpdateCacheMut.RLock()
+	cache, cached := gatewayUpdateCache[key]
+	gatewayUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			gatewayAllColumns,
+			gatewayPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("appdb: unable to update gateway, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"loriot_io\".\"gateway\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, gatewayPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(gatewayType, gatewayMapping, append(wl, gatewayPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update gateway row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by update for gateway")
+	}
+
+	if !cached {
+		gatewayUpdateCacheMut.Lock()
+		gatewayUpdateCache[key] = cache
+		gatewayUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (q gatewayQuery) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return q.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q gatewayQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update all for gateway")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to retrieve rows affected for gateway")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (o GatewaySlice) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return o.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o GatewaySlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("appdb: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), gatewayPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"loriot_io\".\"gateway\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, gatewayPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update all in gateway slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to retrieve rows affected all in update all gateway")
+	}
+	return rowsAff, nil
+}
+
+// UpsertG attempts an insert, and does an update or ignore on conflict.
+func (o *Gateway) UpsertG(ctx context.Context, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	return o.Upsert(ctx, boil.GetContextDB(), updateOnConflict, conflictColumns, updateColumns, insertColumns, opts...)
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Gateway) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("appdb: no gateway provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(gatewayColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	gatewayUpsertCacheMut.RLock()
+	cache, cached := gatewayUpsertCache[key]
+	gatewayUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			gatewayAllColumns,
+			gatewayColumnsWithDefault,
+			gatewayColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			gatewayAllColumns,
+			gatewayPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("appdb: unable to upsert gateway, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(gatewayAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(gatewayPrimaryKeyColumns) == 0 {
+				return errors.New("appdb: unable to upsert gateway, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(gatewayPrimaryKeyColumns))
+			copy(conflict, gatewayPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"loriot_io\".\"gateway\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(gatewayType, gatewayMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(gatewayType, gatewayMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to upsert gateway")
+	}
+
+	if !cached {
+		gatewayUpsertCacheMut.Lock()
+		gatewayUpsertCache[key] = cache
+		gatewayUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// DeleteG deletes a single Gateway record.
+// DeleteG will match against the primary key column to find the record to delete.
+func (o *Gateway) DeleteG(ctx context.Context) (int64, error) {
+	return o.Delete(ctx, boil.GetContextDB())
+}
+
+// Delete deletes a single Gateway record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Gateway) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("appdb: no Gateway provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), gatewayPrimaryKeyMapping)
+	sql := "DELETE FROM \"loriot_io\".\"gateway\" WHERE \"asset_id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete from gateway")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by delete for gateway")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+func (q gatewayQuery) DeleteAllG(ctx context.Context) (int64, error) {
+	return q.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all matching rows.
+func (q gatewayQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("appdb: no gatewayQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete all from gateway")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by deleteall for gateway")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAllG deletes all rows in the slice.
+func (o GatewaySlice) DeleteAllG(ctx context.Context) (int64, error) {
+	return o.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o GatewaySlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(gatewayBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), gatewayPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"loriot_io\".\"gateway\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, gatewayPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete all from gateway slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by deleteall for gateway")
+	}
+
+	if len(gatewayAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// ReloadG refetches the object from the database using the primary keys.
+func (o *Gateway) ReloadG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("appdb: no Gateway provided for reload")
+	}
+
+	return o.Reload(ctx, boil.GetContextDB())
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Gateway) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindGateway(ctx, exec, o.AssetID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAllG refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *GatewaySlice) ReloadAllG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("appdb: empty GatewaySlice provided for reload all")
+	}
+
+	return o.ReloadAll(ctx, boil.GetContextDB())
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *GatewaySlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := GatewaySlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), gatewayPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"loriot_io\".\"gateway\".* FROM \"loriot_io\".\"gateway\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, gatewayPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to reload all in GatewaySlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// GatewayExistsG checks if the Gateway row exists.
+func GatewayExistsG(ctx context.Context, assetID int32) (bool, error) {
+	return GatewayExists(ctx, boil.GetContextDB(), assetID)
+}
+
+// GatewayExists checks if the Gateway row exists.
+func GatewayExists(ctx context.Context, exec boil.ContextExecutor, assetID int32) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"loriot_io\".\"gateway\" where \"asset_id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, assetID)
+	}
+	row := exec.QueryRowContext(ctx, sql, assetID)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "appdb: unable to check if gateway exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the Gateway row exists.
+func (o *Gateway) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return GatewayExists(ctx, exec, o.AssetID)
+}
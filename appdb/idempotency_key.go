@@ -0,0 +1,1054 @@
+// Code generated by SQLBoiler 4.16.1 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package appdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// IdempotencyKey is an object representing the database table.
+type IdempotencyKey struct {
+	Key         string    `boil:"key" json:"key" toml:"key" yaml:"key"`
+	RequestHash string    `boil:"request_hash" json:"request_hash" toml:"request_hash" yaml:"request_hash"`
+	State       string    `boil:"state" json:"state" toml:"state" yaml:"state"`
+	Response    null.JSON `boil:"response" json:"response,omitempty" toml:"response" yaml:"response,omitempty"`
+	CreatedAt   time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+
+	R *idempotencyKeyR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L idempotencyKeyL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var IdempotencyKeyColumns = struct {
+	Key         string
+	RequestHash string
+	State       string
+	Response    string
+	CreatedAt   string
+}{
+	Key:         "key",
+	RequestHash: "request_hash",
+	State:       "state",
+	Response:    "response",
+	CreatedAt:   "created_at",
+}
+
+var IdempotencyKeyTableColumns = struct {
+	Key         string
+	RequestHash string
+	State       string
+	Response    string
+	CreatedAt   string
+}{
+	Key:         "idempotency_key.key",
+	RequestHash: "idempotency_key.request_hash",
+	State:       "idempotency_key.state",
+	Response:    "idempotency_key.response",
+	CreatedAt:   "idempotency_key.created_at",
+}
+
+// Generated where
+
+var IdempotencyKeyWhere = struct {
+	Key         whereHelperstring
+	RequestHash whereHelperstring
+	State       whereHelperstring
+	Response    whereHelpernull_JSON
+	CreatedAt   whereHelpertime_Time
+}{
+	Key:         whereHelperstring{field: "\"loriot_io\".\"idempotency_key\".\"key\""},
+	RequestHash: whereHelperstring{field: "\"loriot_io\".\"idempotency_key\".\"request_hash\""},
+	State:       whereHelperstring{field: "\"loriot_io\".\"idempotency_key\".\"state\""},
+	Response:    whereHelpernull_JSON{field: "\"loriot_io\".\"idempotency_key\".\"response\""},
+	CreatedAt:   whereHelpertime_Time{field: "\"loriot_io\".\"idempotency_key\".\"created_at\""},
+}
+
+// IdempotencyKeyRels is where relationship names are stored.
+var IdempotencyKeyRels = struct {
+}{}
+
+// idempotencyKeyR is where relationships are stored.
+type idempotencyKeyR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*idempotencyKeyR) NewStruct() *idempotencyKeyR {
+	return &idempotencyKeyR{}
+}
+
+// idempotencyKeyL is where Load methods for each relationship are stored.
+type idempotencyKeyL struct{}
+
+var (
+	idempotencyKeyAllColumns            = []string{"key", "request_hash", "state", "response", "created_at"}
+	idempotencyKeyColumnsWithoutDefault = []string{"key", "request_hash"}
+	idempotencyKeyColumnsWithDefault    = []string{"state", "response", "created_at"}
+	idempotencyKeyPrimaryKeyColumns     = []string{"key"}
+	idempotencyKeyGeneratedColumns      = []string{}
+)
+
+type (
+	// IdempotencyKeySlice is an alias for a slice of pointers to IdempotencyKey.
+	// This should almost always be used instead of []IdempotencyKey.
+	IdempotencyKeySlice []*IdempotencyKey
+	// IdempotencyKeyHook is the signature for custom IdempotencyKey hook methods
+	IdempotencyKeyHook func(context.Context, boil.ContextExecutor, *IdempotencyKey) error
+
+	idempotencyKeyQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	idempotencyKeyType                 = reflect.TypeOf(&IdempotencyKey{})
+	idempotencyKeyMapping              = queries.MakeStructMapping(idempotencyKeyType)
+	idempotencyKeyPrimaryKeyMapping, _ = queries.BindMapping(idempotencyKeyType, idempotencyKeyMapping, idempotencyKeyPrimaryKeyColumns)
+	idempotencyKeyInsertCacheMut       sync.RWMutex
+	idempotencyKeyInsertCache          = make(map[string]insertCache)
+	idempotencyKeyUpdateCacheMut       sync.RWMutex
+	idempotencyKeyUpdateCache          = make(map[string]updateCache)
+	idempotencyKeyUpsertCacheMut       sync.RWMutex
+	idempotencyKeyUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var idempotencyKeyAfterSelectMu sync.Mutex
+var idempotencyKeyAfterSelectHooks []IdempotencyKeyHook
+
+var idempotencyKeyBeforeInsertMu sync.Mutex
+var idempotencyKeyBeforeInsertHooks []IdempotencyKeyHook
+var idempotencyKeyAfterInsertMu sync.Mutex
+var idempotencyKeyAfterInsertHooks []IdempotencyKeyHook
+
+var idempotencyKeyBeforeUpdateMu sync.Mutex
+var idempotencyKeyBeforeUpdateHooks []IdempotencyKeyHook
+var idempotencyKeyAfterUpdateMu sync.Mutex
+var idempotencyKeyAfterUpdateHooks []IdempotencyKeyHook
+
+var idempotencyKeyBeforeDeleteMu sync.Mutex
+var idempotencyKeyBeforeDeleteHooks []IdempotencyKeyHook
+var idempotencyKeyAfterDeleteMu sync.Mutex
+var idempotencyKeyAfterDeleteHooks []IdempotencyKeyHook
+
+var idempotencyKeyBeforeUpsertMu sync.Mutex
+var idempotencyKeyBeforeUpsertHooks []IdempotencyKeyHook
+var idempotencyKeyAfterUpsertMu sync.Mutex
+var idempotencyKeyAfterUpsertHooks []IdempotencyKeyHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *IdempotencyKey) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range idempotencyKeyAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *IdempotencyKey) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range idempotencyKeyBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *IdempotencyKey) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range idempotencyKeyAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *IdempotencyKey) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range idempotencyKeyBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *IdempotencyKey) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range idempotencyKeyAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *IdempotencyKey) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range idempotencyKeyBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *IdempotencyKey) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range idempotencyKeyAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *IdempotencyKey) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range idempotencyKeyBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *IdempotencyKey) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range idempotencyKeyAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddIdempotencyKeyHook registers your hook function for all future operations.
+func AddIdempotencyKeyHook(hookPoint boil.HookPoint, idempotencyKeyHook IdempotencyKeyHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		idempotencyKeyAfterSelectMu.Lock()
+		idempotencyKeyAfterSelectHooks = append(idempotencyKeyAfterSelectHooks, idempotencyKeyHook)
+		idempotencyKeyAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		idempotencyKeyBeforeInsertMu.Lock()
+		idempotencyKeyBeforeInsertHooks = append(idempotencyKeyBeforeInsertHooks, idempotencyKeyHook)
+		idempotencyKeyBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		idempotencyKeyAfterInsertMu.Lock()
+		idempotencyKeyAfterInsertHooks = append(idempotencyKeyAfterInsertHooks, idempotencyKeyHook)
+		idempotencyKeyAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		idempotencyKeyBeforeUpdateMu.Lock()
+		idempotencyKeyBeforeUpdateHooks = append(idempotencyKeyBeforeUpdateHooks, idempotencyKeyHook)
+		idempotencyKeyBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		idempotencyKeyAfterUpdateMu.Lock()
+		idempotencyKeyAfterUpdateHooks = append(idempotencyKeyAfterUpdateHooks, idempotencyKeyHook)
+		idempotencyKeyAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		idempotencyKeyBeforeDeleteMu.Lock()
+		idempotencyKeyBeforeDeleteHooks = append(idempotencyKeyBeforeDeleteHooks, idempotencyKeyHook)
+		idempotencyKeyBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		idempotencyKeyAfterDeleteMu.Lock()
+		idempotencyKeyAfterDeleteHooks = append(idempotencyKeyAfterDeleteHooks, idempotencyKeyHook)
+		idempotencyKeyAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		idempotencyKeyBeforeUpsertMu.Lock()
+		idempotencyKeyBeforeUpsertHooks = append(idempotencyKeyBeforeUpsertHooks, idempotencyKeyHook)
+		idempotencyKeyBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		idempotencyKeyAfterUpsertMu.Lock()
+		idempotencyKeyAfterUpsertHooks = append(idempotencyKeyAfterUpsertHooks, idempotencyKeyHook)
+		idempotencyKeyAfterUpsertMu.Unlock()
+	}
+}
+
+// OneG returns a single idempotencyKey record from the query using the global executor.
+func (q idempotencyKeyQuery) OneG(ctx context.Context) (*IdempotencyKey, error) {
+	return q.One(ctx, boil.GetContextDB())
+}
+
+// One returns a single idempotencyKey record from the query.
+func (q idempotencyKeyQuery) One(ctx context.Context, exec boil.ContextExecutor) (*IdempotencyKey, error) {
+	o := &IdempotencyKey{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "appdb: failed to execute a one query for idempotency_key")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// AllG returns all IdempotencyKey records from the query using the global executor.
+func (q idempotencyKeyQuery) AllG(ctx context.Context) (IdempotencyKeySlice, error) {
+	return q.All(ctx, boil.GetContextDB())
+}
+
+// All returns all IdempotencyKey records from the query.
+func (q idempotencyKeyQuery) All(ctx context.Context, exec boil.ContextExecutor) (IdempotencyKeySlice, error) {
+	var o []*IdempotencyKey
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "appdb: failed to assign all query results to IdempotencyKey slice")
+	}
+
+	if len(idempotencyKeyAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// CountG returns the count of all IdempotencyKey records in the query using the global executor
+func (q idempotencyKeyQuery) CountG(ctx context.Context) (int64, error) {
+	return q.Count(ctx, boil.GetContextDB())
+}
+
+// Count returns the count of all IdempotencyKey records in the query.
+func (q idempotencyKeyQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to count idempotency_key rows")
+	}
+
+	return count, nil
+}
+
+// ExistsG checks if the row exists in the table using the global executor.
+func (q idempotencyKeyQuery) ExistsG(ctx context.Context) (bool, error) {
+	return q.Exists(ctx, boil.GetContextDB())
+}
+
+// Exists checks if the row exists in the table.
+func (q idempotencyKeyQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "appdb: failed to check if idempotency_key exists")
+	}
+
+	return count > 0, nil
+}
+
+// IdempotencyKeys retrieves all the records using an executor.
+func IdempotencyKeys(mods ...qm.QueryMod) idempotencyKeyQuery {
+	mods = append(mods, qm.From("\"loriot_io\".\"idempotency_key\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"loriot_io\".\"idempotency_key\".*"})
+	}
+
+	return idempotencyKeyQuery{q}
+}
+
+// FindIdempotencyKeyG retrieves a single record by ID.
+func FindIdempotencyKeyG(ctx context.Context, key string, selectCols ...string) (*IdempotencyKey, error) {
+	return FindIdempotencyKey(ctx, boil.GetContextDB(), key, selectCols...)
+}
+
+// FindIdempotencyKey retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindIdempotencyKey(ctx context.Context, exec boil.ContextExecutor, key string, selectCols ...string) (*IdempotencyKey, error) {
+	idempotencyKeyObj := &IdempotencyKey{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"loriot_io\".\"idempotency_key\" where \"key\"=$1", sel,
+	)
+
+	q := queries.Raw(query, key)
+
+	err := q.Bind(ctx, exec, idempotencyKeyObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "appdb: unable to select from idempotency_key")
+	}
+
+	if err = idempotencyKeyObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return idempotencyKeyObj, err
+	}
+
+	return idempotencyKeyObj, nil
+}
+
+// InsertG a single record. See Insert for whitelist behavior description.
+func (o *IdempotencyKey) InsertG(ctx context.Context, columns boil.Columns) error {
+	return o.Insert(ctx, boil.GetContextDB(), columns)
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *IdempotencyKey) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("appdb: no idempotency_key provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(idempotencyKeyColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	idempotencyKeyInsertCacheMut.RLock()
+	cache, cached := idempotencyKeyInsertCache[key]
+	idempotencyKeyInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			idempotencyKeyAllColumns,
+			idempotencyKeyColumnsWithDefault,
+			idempotencyKeyColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(idempotencyKeyType, idempotencyKeyMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(idempotencyKeyType, idempotencyKeyMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"loriot_io\".\"idempotency_key\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"loriot_io\".\"idempotency_key\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to insert into idempotency_key")
+	}
+
+	if !cached {
+		idempotencyKeyInsertCacheMut.Lock()
+		idempotencyKeyInsertCache[key] = cache
+		idempotencyKeyInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// UpdateG a single IdempotencyKey record using the global executor.
+// See Update for more documentation.
+func (o *IdempotencyKey) UpdateG(ctx context.Context, columns boil.Columns) (int64, error) {
+	return o.Update(ctx, boil.GetContextDB(), columns)
+}
+
+// Update uses an executor to update the IdempotencyKey.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *IdempotencyKey) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	idempotencyKeyUpdateCacheMut.RLock()
+	cache, cached := idempotencyKeyUpdateCache[key]
+	idempotencyKeyUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			idempotencyKeyAllColumns,
+			idempotencyKeyPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("appdb: unable to update idempotency_key, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"loriot_io\".\"idempotency_key\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, idempotencyKeyPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(idempotencyKeyType, idempotencyKeyMapping, append(wl, idempotencyKeyPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update idempotency_key row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by update for idempotency_key")
+	}
+
+	if !cached {
+		idempotencyKeyUpdateCacheMut.Lock()
+		idempotencyKeyUpdateCache[key] = cache
+		idempotencyKeyUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (q idempotencyKeyQuery) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return q.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q idempotencyKeyQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update all for idempotency_key")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to retrieve rows affected for idempotency_key")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (o IdempotencyKeySlice) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return o.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o IdempotencyKeySlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("appdb: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), idempotencyKeyPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"loriot_io\".\"idempotency_key\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, idempotencyKeyPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update all in idempotencyKey slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to retrieve rows affected all in update all idempotencyKey")
+	}
+	return rowsAff, nil
+}
+
+// UpsertG attempts an insert, and does an update or ignore on conflict.
+func (o *IdempotencyKey) UpsertG(ctx context.Context, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	return o.Upsert(ctx, boil.GetContextDB(), updateOnConflict, conflictColumns, updateColumns, insertColumns, opts...)
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *IdempotencyKey) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("appdb: no idempotency_key provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(idempotencyKeyColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	idempotencyKeyUpsertCacheMut.RLock()
+	cache, cached := idempotencyKeyUpsertCache[key]
+	idempotencyKeyUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			idempotencyKeyAllColumns,
+			idempotencyKeyColumnsWithDefault,
+			idempotencyKeyColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			idempotencyKeyAllColumns,
+			idempotencyKeyPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("appdb: unable to upsert idempotency_key, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(idempotencyKeyAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(idempotencyKeyPrimaryKeyColumns) == 0 {
+				return errors.New("appdb: unable to upsert idempotency_key, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(idempotencyKeyPrimaryKeyColumns))
+			copy(conflict, idempotencyKeyPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"loriot_io\".\"idempotency_key\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(idempotencyKeyType, idempotencyKeyMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(idempotencyKeyType, idempotencyKeyMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to upsert idempotency_key")
+	}
+
+	if !cached {
+		idempotencyKeyUpsertCacheMut.Lock()
+		idempotencyKeyUpsertCache[key] = cache
+		idempotencyKeyUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// DeleteG deletes a single IdempotencyKey record.
+// DeleteG will match against the primary key column to find the record to delete.
+func (o *IdempotencyKey) DeleteG(ctx context.Context) (int64, error) {
+	return o.Delete(ctx, boil.GetContextDB())
+}
+
+// Delete deletes a single IdempotencyKey record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *IdempotencyKey) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("appdb: no IdempotencyKey provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), idempotencyKeyPrimaryKeyMapping)
+	sql := "DELETE FROM \"loriot_io\".\"idempotency_key\" WHERE \"key\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete from idempotency_key")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by delete for idempotency_key")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+func (q idempotencyKeyQuery) DeleteAllG(ctx context.Context) (int64, error) {
+	return q.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all matching rows.
+func (q idempotencyKeyQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("appdb: no idempotencyKeyQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete all from idempotency_key")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by deleteall for idempotency_key")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAllG deletes all rows in the slice.
+func (o IdempotencyKeySlice) DeleteAllG(ctx context.Context) (int64, error) {
+	return o.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o IdempotencyKeySlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(idempotencyKeyBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), idempotencyKeyPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"loriot_io\".\"idempotency_key\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, idempotencyKeyPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete all from idempotencyKey slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by deleteall for idempotency_key")
+	}
+
+	if len(idempotencyKeyAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// ReloadG refetches the object from the database using the primary keys.
+func (o *IdempotencyKey) ReloadG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("appdb: no IdempotencyKey provided for reload")
+	}
+
+	return o.Reload(ctx, boil.GetContextDB())
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *IdempotencyKey) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindIdempotencyKey(ctx, exec, o.Key)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAllG refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *IdempotencyKeySlice) ReloadAllG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("appdb: empty IdempotencyKeySlice provided for reload all")
+	}
+
+	return o.ReloadAll(ctx, boil.GetContextDB())
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *IdempotencyKeySlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := IdempotencyKeySlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), idempotencyKeyPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"loriot_io\".\"idempotency_key\".* FROM \"loriot_io\".\"idempotency_key\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, idempotencyKeyPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to reload all in IdempotencyKeySlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// IdempotencyKeyExistsG checks if the IdempotencyKey row exists.
+func IdempotencyKeyExistsG(ctx context.Context, key string) (bool, error) {
+	return IdempotencyKeyExists(ctx, boil.GetContextDB(), key)
+}
+
+// IdempotencyKeyExists checks if the IdempotencyKey row exists.
+func IdempotencyKeyExists(ctx context.Context, exec boil.ContextExecutor, key string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"loriot_io\".\"idempotency_key\" where \"key\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, key)
+	}
+	row := exec.QueryRowContext(ctx, sql, key)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "appdb: unable to check if idempotency_key exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the IdempotencyKey row exists.
+func (o *IdempotencyKey) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return IdempotencyKeyExists(ctx, exec, o.Key)
+}
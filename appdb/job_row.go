@@ -0,0 +1,1283 @@
+// Code generated by SQLBoiler 4.16.1 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package appdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// JobRow is an object representing the database table.
+type JobRow struct {
+	JobID      int64            `boil:"job_id" json:"job_id" toml:"job_id" yaml:"job_id"`
+	RowNumber  int32            `boil:"row_number" json:"row_number" toml:"row_number" yaml:"row_number"`
+	DevEui     string           `boil:"dev_eui" json:"dev_eui" toml:"dev_eui" yaml:"dev_eui"`
+	Request    null.JSON        `boil:"request" json:"request,omitempty" toml:"request" yaml:"request,omitempty"`
+	Status     string           `boil:"status" json:"status" toml:"status" yaml:"status"`
+	Error      null.String      `boil:"error" json:"error,omitempty" toml:"error" yaml:"error,omitempty"`
+	AssetIds   types.Int64Array `boil:"asset_ids" json:"asset_ids,omitempty" toml:"asset_ids" yaml:"asset_ids,omitempty"`
+	ModifiedAt null.Time        `boil:"modified_at" json:"modified_at,omitempty" toml:"modified_at" yaml:"modified_at,omitempty"`
+
+	R *jobRowR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L jobRowL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var JobRowColumns = struct {
+	JobID      string
+	RowNumber  string
+	DevEui     string
+	Request    string
+	Status     string
+	Error      string
+	AssetIds   string
+	ModifiedAt string
+}{
+	JobID:      "job_id",
+	RowNumber:  "row_number",
+	DevEui:     "dev_eui",
+	Request:    "request",
+	Status:     "status",
+	Error:      "error",
+	AssetIds:   "asset_ids",
+	ModifiedAt: "modified_at",
+}
+
+var JobRowTableColumns = struct {
+	JobID      string
+	RowNumber  string
+	DevEui     string
+	Request    string
+	Status     string
+	Error      string
+	AssetIds   string
+	ModifiedAt string
+}{
+	JobID:      "job_row.job_id",
+	RowNumber:  "job_row.row_number",
+	DevEui:     "job_row.dev_eui",
+	Request:    "job_row.request",
+	Status:     "job_row.status",
+	Error:      "job_row.error",
+	AssetIds:   "job_row.asset_ids",
+	ModifiedAt: "job_row.modified_at",
+}
+
+// Generated where
+
+type whereHelpertypes_Int64Array struct{ field string }
+
+func (w whereHelpertypes_Int64Array) EQ(x types.Int64Array) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpertypes_Int64Array) NEQ(x types.Int64Array) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpertypes_Int64Array) LT(x types.Int64Array) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertypes_Int64Array) LTE(x types.Int64Array) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertypes_Int64Array) GT(x types.Int64Array) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertypes_Int64Array) GTE(x types.Int64Array) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpertypes_Int64Array) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpertypes_Int64Array) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+var JobRowWhere = struct {
+	JobID      whereHelperint64
+	RowNumber  whereHelperint32
+	DevEui     whereHelperstring
+	Request    whereHelpernull_JSON
+	Status     whereHelperstring
+	Error      whereHelpernull_String
+	AssetIds   whereHelpertypes_Int64Array
+	ModifiedAt whereHelpernull_Time
+}{
+	JobID:      whereHelperint64{field: "\"loriot_io\".\"job_row\".\"job_id\""},
+	RowNumber:  whereHelperint32{field: "\"loriot_io\".\"job_row\".\"row_number\""},
+	DevEui:     whereHelperstring{field: "\"loriot_io\".\"job_row\".\"dev_eui\""},
+	Request:    whereHelpernull_JSON{field: "\"loriot_io\".\"job_row\".\"request\""},
+	Status:     whereHelperstring{field: "\"loriot_io\".\"job_row\".\"status\""},
+	Error:      whereHelpernull_String{field: "\"loriot_io\".\"job_row\".\"error\""},
+	AssetIds:   whereHelpertypes_Int64Array{field: "\"loriot_io\".\"job_row\".\"asset_ids\""},
+	ModifiedAt: whereHelpernull_Time{field: "\"loriot_io\".\"job_row\".\"modified_at\""},
+}
+
+// JobRowRels is where relationship names are stored.
+var JobRowRels = struct {
+	Job string
+}{
+	Job: "Job",
+}
+
+// jobRowR is where relationships are stored.
+type jobRowR struct {
+	Job *Job `boil:"Job" json:"Job" toml:"Job" yaml:"Job"`
+}
+
+// NewStruct creates a new relationship struct
+func (*jobRowR) NewStruct() *jobRowR {
+	return &jobRowR{}
+}
+
+func (r *jobRowR) GetJob() *Job {
+	if r == nil {
+		return nil
+	}
+	return r.Job
+}
+
+// jobRowL is where Load methods for each relationship are stored.
+type jobRowL struct{}
+
+var (
+	jobRowAllColumns            = []string{"job_id", "row_number", "dev_eui", "request", "status", "error", "asset_ids", "modified_at"}
+	jobRowColumnsWithoutDefault = []string{"job_id", "row_number", "dev_eui", "status"}
+	jobRowColumnsWithDefault    = []string{"request", "error", "asset_ids", "modified_at"}
+	jobRowPrimaryKeyColumns     = []string{"job_id", "row_number"}
+	jobRowGeneratedColumns      = []string{}
+)
+
+type (
+	// JobRowSlice is an alias for a slice of pointers to JobRow.
+	// This should almost always be used instead of []JobRow.
+	JobRowSlice []*JobRow
+	// JobRowHook is the signature for custom JobRow hook methods
+	JobRowHook func(context.Context, boil.ContextExecutor, *JobRow) error
+
+	jobRowQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	jobRowType                 = reflect.TypeOf(&JobRow{})
+	jobRowMapping              = queries.MakeStructMapping(jobRowType)
+	jobRowPrimaryKeyMapping, _ = queries.BindMapping(jobRowType, jobRowMapping, jobRowPrimaryKeyColumns)
+	jobRowInsertCacheMut       sync.RWMutex
+	jobRowInsertCache          = make(map[string]insertCache)
+	jobRowUpdateCacheMut       sync.RWMutex
+	jobRowUpdateCache          = make(map[string]updateCache)
+	jobRowUpsertCacheMut       sync.RWMutex
+	jobRowUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var jobRowAfterSelectMu sync.Mutex
+var jobRowAfterSelectHooks []JobRowHook
+
+var jobRowBeforeInsertMu sync.Mutex
+var jobRowBeforeInsertHooks []JobRowHook
+var jobRowAfterInsertMu sync.Mutex
+var jobRowAfterInsertHooks []JobRowHook
+
+var jobRowBeforeUpdateMu sync.Mutex
+var jobRowBeforeUpdateHooks []JobRowHook
+var jobRowAfterUpdateMu sync.Mutex
+var jobRowAfterUpdateHooks []JobRowHook
+
+var jobRowBeforeDeleteMu sync.Mutex
+var jobRowBeforeDeleteHooks []JobRowHook
+var jobRowAfterDeleteMu sync.Mutex
+var jobRowAfterDeleteHooks []JobRowHook
+
+var jobRowBeforeUpsertMu sync.Mutex
+var jobRowBeforeUpsertHooks []JobRowHook
+var jobRowAfterUpsertMu sync.Mutex
+var jobRowAfterUpsertHooks []JobRowHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *JobRow) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range jobRowAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *JobRow) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range jobRowBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *JobRow) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range jobRowAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *JobRow) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range jobRowBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *JobRow) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range jobRowAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *JobRow) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range jobRowBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *JobRow) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range jobRowAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *JobRow) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range jobRowBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *JobRow) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range jobRowAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddJobRowHook registers your hook function for all future operations.
+func AddJobRowHook(hookPoint boil.HookPoint, jobRowHook JobRowHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		jobRowAfterSelectMu.Lock()
+		jobRowAfterSelectHooks = append(jobRowAfterSelectHooks, jobRowHook)
+		jobRowAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		jobRowBeforeInsertMu.Lock()
+		jobRowBeforeInsertHooks = append(jobRowBeforeInsertHooks, jobRowHook)
+		jobRowBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		jobRowAfterInsertMu.Lock()
+		jobRowAfterInsertHooks = append(jobRowAfterInsertHooks, jobRowHook)
+		jobRowAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		jobRowBeforeUpdateMu.Lock()
+		jobRowBeforeUpdateHooks = append(jobRowBeforeUpdateHooks, jobRowHook)
+		jobRowBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		jobRowAfterUpdateMu.Lock()
+		jobRowAfterUpdateHooks = append(jobRowAfterUpdateHooks, jobRowHook)
+		jobRowAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		jobRowBeforeDeleteMu.Lock()
+		jobRowBeforeDeleteHooks = append(jobRowBeforeDeleteHooks, jobRowHook)
+		jobRowBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		jobRowAfterDeleteMu.Lock()
+		jobRowAfterDeleteHooks = append(jobRowAfterDeleteHooks, jobRowHook)
+		jobRowAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		jobRowBeforeUpsertMu.Lock()
+		jobRowBeforeUpsertHooks = append(jobRowBeforeUpsertHooks, jobRowHook)
+		jobRowBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		jobRowAfterUpsertMu.Lock()
+		jobRowAfterUpsertHooks = append(jobRowAfterUpsertHooks, jobRowHook)
+		jobRowAfterUpsertMu.Unlock()
+	}
+}
+
+// OneG returns a single jobRow record from the query using the global executor.
+func (q jobRowQuery) OneG(ctx context.Context) (*JobRow, error) {
+	return q.One(ctx, boil.GetContextDB())
+}
+
+// One returns a single jobRow record from the query.
+func (q jobRowQuery) One(ctx context.Context, exec boil.ContextExecutor) (*JobRow, error) {
+	o := &JobRow{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "appdb: failed to execute a one query for job_row")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// AllG returns all JobRow records from the query using the global executor.
+func (q jobRowQuery) AllG(ctx context.Context) (JobRowSlice, error) {
+	return q.All(ctx, boil.GetContextDB())
+}
+
+// All returns all JobRow records from the query.
+func (q jobRowQuery) All(ctx context.Context, exec boil.ContextExecutor) (JobRowSlice, error) {
+	var o []*JobRow
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "appdb: failed to assign all query results to JobRow slice")
+	}
+
+	if len(jobRowAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// CountG returns the count of all JobRow records in the query using the global executor
+func (q jobRowQuery) CountG(ctx context.Context) (int64, error) {
+	return q.Count(ctx, boil.GetContextDB())
+}
+
+// Count returns the count of all JobRow records in the query.
+func (q jobRowQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to count job_row rows")
+	}
+
+	return count, nil
+}
+
+// ExistsG checks if the row exists in the table using the global executor.
+func (q jobRowQuery) ExistsG(ctx context.Context) (bool, error) {
+	return q.Exists(ctx, boil.GetContextDB())
+}
+
+// Exists checks if the row exists in the table.
+func (q jobRowQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "appdb: failed to check if job_row exists")
+	}
+
+	return count > 0, nil
+}
+
+// Job pointed to by the foreign key.
+func (o *JobRow) Job(mods ...qm.QueryMod) jobQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.JobID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Jobs(queryMods...)
+}
+
+// LoadJob allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (jobRowL) LoadJob(ctx context.Context, e boil.ContextExecutor, singular bool, maybeJobRow interface{}, mods queries.Applicator) error {
+	var slice []*JobRow
+	var object *JobRow
+
+	if singular {
+		var ok bool
+		object, ok = maybeJobRow.(*JobRow)
+		if !ok {
+			object = new(JobRow)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeJobRow)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeJobRow))
+			}
+		}
+	} else {
+		s, ok := maybeJobRow.(*[]*JobRow)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeJobRow)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeJobRow))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &jobRowR{}
+		}
+		args[object.JobID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &jobRowR{}
+			}
+
+			args[obj.JobID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`loriot_io.job`),
+		qm.WhereIn(`loriot_io.job.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Job")
+	}
+
+	var resultSlice []*Job
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Job")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for job")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for job")
+	}
+
+	if len(jobAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Job = foreign
+		if foreign.R == nil {
+			foreign.R = &jobR{}
+		}
+		foreign.R.JobRows = append(foreign.R.JobRows, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.JobID == foreign.ID {
+				local.R.Job = foreign
+				if foreign.R == nil {
+					foreign.R = &jobR{}
+				}
+				foreign.R.JobRows = append(foreign.R.JobRows, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetJobG of the jobRow to the related item.
+// Sets o.R.Job to related.
+// Adds o to related.R.JobRows.
+// Uses the global database handle.
+func (o *JobRow) SetJobG(ctx context.Context, insert bool, related *Job) error {
+	return o.SetJob(ctx, boil.GetContextDB(), insert, related)
+}
+
+// SetJob of the jobRow to the related item.
+// Sets o.R.Job to related.
+// Adds o to related.R.JobRows.
+func (o *JobRow) SetJob(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Job) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"loriot_io\".\"job_row\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"job_id"}),
+		strmangle.WhereClause("\"", "\"", 2, jobRowPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.JobID, o.RowNumber}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.JobID = related.ID
+	if o.R == nil {
+		o.R = &jobRowR{
+			Job: related,
+		}
+	} else {
+		o.R.Job = related
+	}
+
+	if related.R == nil {
+		related.R = &jobR{
+			JobRows: JobRowSlice{o},
+		}
+	} else {
+		related.R.JobRows = append(related.R.JobRows, o)
+	}
+
+	return nil
+}
+
+// JobRows retrieves all the records using an executor.
+func JobRows(mods ...qm.QueryMod) jobRowQuery {
+	mods = append(mods, qm.From("\"loriot_io\".\"job_row\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"loriot_io\".\"job_row\".*"})
+	}
+
+	return jobRowQuery{q}
+}
+
+// FindJobRowG retrieves a single record by ID.
+func FindJobRowG(ctx context.Context, jobID int64, rowNumber int32, selectCols ...string) (*JobRow, error) {
+	return FindJobRow(ctx, boil.GetContextDB(), jobID, rowNumber, selectCols...)
+}
+
+// FindJobRow retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindJobRow(ctx context.Context, exec boil.ContextExecutor, jobID int64, rowNumber int32, selectCols ...string) (*JobRow, error) {
+	jobRowObj := &JobRow{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"loriot_io\".\"job_row\" where \"job_id\"=$1 AND \"row_number\"=$2", sel,
+	)
+
+	q := queries.Raw(query, jobID, rowNumber)
+
+	err := q.Bind(ctx, exec, jobRowObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "appdb: unable to select from job_row")
+	}
+
+	if err = jobRowObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return jobRowObj, err
+	}
+
+	return jobRowObj, nil
+}
+
+// InsertG a single record. See Insert for whitelist behavior description.
+func (o *JobRow) InsertG(ctx context.Context, columns boil.Columns) error {
+	return o.Insert(ctx, boil.GetContextDB(), columns)
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *JobRow) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("appdb: no job_row provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(jobRowColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	jobRowInsertCacheMut.RLock()
+	cache, cached := jobRowInsertCache[key]
+	jobRowInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			jobRowAllColumns,
+			jobRowColumnsWithDefault,
+			jobRowColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(jobRowType, jobRowMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(jobRowType, jobRowMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"loriot_io\".\"job_row\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"loriot_io\".\"job_row\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to insert into job_row")
+	}
+
+	if !cached {
+		jobRowInsertCacheMut.Lock()
+		jobRowInsertCache[key] = cache
+		jobRowInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// UpdateG a single JobRow record using the global executor.
+// See Update for more documentation.
+func (o *JobRow) UpdateG(ctx context.Context, columns boil.Columns) (int64, error) {
+	return o.Update(ctx, boil.GetContextDB(), columns)
+}
+
+// Update uses an executor to update the JobRow.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *JobRow) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	jobRowUpdateCacheMut.RLock()
+	cache, cached := jobRowUpdateCache[key]
+	jobRowUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			jobRowAllColumns,
+			jobRowPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("appdb: unable to update job_row, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"loriot_io\".\"job_row\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, jobRowPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(jobRowType, jobRowMapping, append(wl, jobRowPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update job_row row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by update for job_row")
+	}
+
+	if !cached {
+		jobRowUpdateCacheMut.Lock()
+		jobRowUpdateCache[key] = cache
+		jobRowUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (q jobRowQuery) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return q.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q jobRowQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update all for job_row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to retrieve rows affected for job_row")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (o JobRowSlice) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return o.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o JobRowSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("appdb: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), jobRowPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"loriot_io\".\"job_row\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, jobRowPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update all in jobRow slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to retrieve rows affected all in update all jobRow")
+	}
+	return rowsAff, nil
+}
+
+// UpsertG attempts an insert, and does an update or ignore on conflict.
+func (o *JobRow) UpsertG(ctx context.Context, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	return o.Upsert(ctx, boil.GetContextDB(), updateOnConflict, conflictColumns, updateColumns, insertColumns, opts...)
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *JobRow) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("appdb: no job_row provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(jobRowColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	jobRowUpsertCacheMut.RLock()
+	cache, cached := jobRowUpsertCache[key]
+	jobRowUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			jobRowAllColumns,
+			jobRowColumnsWithDefault,
+			jobRowColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			jobRowAllColumns,
+			jobRowPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("appdb: unable to upsert job_row, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(jobRowAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(jobRowPrimaryKeyColumns) == 0 {
+				return errors.New("appdb: unable to upsert job_row, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(jobRowPrimaryKeyColumns))
+			copy(conflict, jobRowPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"loriot_io\".\"job_row\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(jobRowType, jobRowMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(jobRowType, jobRowMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to upsert job_row")
+	}
+
+	if !cached {
+		jobRowUpsertCacheMut.Lock()
+		jobRowUpsertCache[key] = cache
+		jobRowUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// DeleteG deletes a single JobRow record.
+// DeleteG will match against the primary key column to find the record to delete.
+func (o *JobRow) DeleteG(ctx context.Context) (int64, error) {
+	return o.Delete(ctx, boil.GetContextDB())
+}
+
+// Delete deletes a single JobRow record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *JobRow) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("appdb: no JobRow provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), jobRowPrimaryKeyMapping)
+	sql := "DELETE FROM \"loriot_io\".\"job_row\" WHERE \"job_id\"=$1 AND \"row_number\"=$2"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete from job_row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by delete for job_row")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+func (q jobRowQuery) DeleteAllG(ctx context.Context) (int64, error) {
+	return q.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all matching rows.
+func (q jobRowQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("appdb: no jobRowQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete all from job_row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by deleteall for job_row")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAllG deletes all rows in the slice.
+func (o JobRowSlice) DeleteAllG(ctx context.Context) (int64, error) {
+	return o.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o JobRowSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(jobRowBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), jobRowPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"loriot_io\".\"job_row\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, jobRowPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete all from jobRow slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by deleteall for job_row")
+	}
+
+	if len(jobRowAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// ReloadG refetches the object from the database using the primary keys.
+func (o *JobRow) ReloadG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("appdb: no JobRow provided for reload")
+	}
+
+	return o.Reload(ctx, boil.GetContextDB())
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *JobRow) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindJobRow(ctx, exec, o.JobID, o.RowNumber)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAllG refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *JobRowSlice) ReloadAllG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("appdb: empty JobRowSlice provided for reload all")
+	}
+
+	return o.ReloadAll(ctx, boil.GetContextDB())
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *JobRowSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := JobRowSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), jobRowPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"loriot_io\".\"job_row\".* FROM \"loriot_io\".\"job_row\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, jobRowPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to reload all in JobRowSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// JobRowExistsG checks if the JobRow row exists.
+func JobRowExistsG(ctx context.Context, jobID int64, rowNumber int32) (bool, error) {
+	return JobRowExists(ctx, boil.GetContextDB(), jobID, rowNumber)
+}
+
+// JobRowExists checks if the JobRow row exists.
+func JobRowExists(ctx context.Context, exec boil.ContextExecutor, jobID int64, rowNumber int32) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"loriot_io\".\"job_row\" where \"job_id\"=$1 AND \"row_number\"=$2 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, jobID, rowNumber)
+	}
+	row := exec.QueryRowContext(ctx, sql, jobID, rowNumber)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "appdb: unable to check if job_row exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the JobRow row exists.
+func (o *JobRow) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return JobRowExists(ctx, exec, o.JobID, o.RowNumber)
+}
@@ -0,0 +1,1247 @@
+// Code generated by SQLBoiler 4.16.1 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package appdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// KeyBatch is an object representing the database table.
+type KeyBatch struct {
+	ID        int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Name      string      `boil:"name" json:"name" toml:"name" yaml:"name"`
+	UserID    null.String `boil:"user_id" json:"user_id,omitempty" toml:"user_id" yaml:"user_id,omitempty"`
+	CreatedAt time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+
+	R *keyBatchR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L keyBatchL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var KeyBatchColumns = struct {
+	ID        string
+	Name      string
+	UserID    string
+	CreatedAt string
+}{
+	ID:        "id",
+	Name:      "name",
+	UserID:    "user_id",
+	CreatedAt: "created_at",
+}
+
+var KeyBatchTableColumns = struct {
+	ID        string
+	Name      string
+	UserID    string
+	CreatedAt string
+}{
+	ID:        "key_batch.id",
+	Name:      "key_batch.name",
+	UserID:    "key_batch.user_id",
+	CreatedAt: "key_batch.created_at",
+}
+
+// Generated where
+
+var KeyBatchWhere = struct {
+	ID        whereHelperint64
+	Name      whereHelperstring
+	UserID    whereHelpernull_String
+	CreatedAt whereHelpertime_Time
+}{
+	ID:        whereHelperint64{field: "\"loriot_io\".\"key_batch\".\"id\""},
+	Name:      whereHelperstring{field: "\"loriot_io\".\"key_batch\".\"name\""},
+	UserID:    whereHelpernull_String{field: "\"loriot_io\".\"key_batch\".\"user_id\""},
+	CreatedAt: whereHelpertime_Time{field: "\"loriot_io\".\"key_batch\".\"created_at\""},
+}
+
+// KeyBatchRels is where relationship names are stored.
+var KeyBatchRels = struct {
+	DeviceKeys string
+}{
+	DeviceKeys: "DeviceKeys",
+}
+
+// keyBatchR is where relationships are stored.
+type keyBatchR struct {
+	DeviceKeys DeviceKeySlice `boil:"DeviceKeys" json:"DeviceKeys" toml:"DeviceKeys" yaml:"DeviceKeys"`
+}
+
+// NewStruct creates a new relationship struct
+func (*keyBatchR) NewStruct() *keyBatchR {
+	return &keyBatchR{}
+}
+
+func (r *keyBatchR) GetDeviceKeys() DeviceKeySlice {
+	if r == nil {
+		return nil
+	}
+	return r.DeviceKeys
+}
+
+// keyBatchL is where Load methods for each relationship are stored.
+type keyBatchL struct{}
+
+var (
+	keyBatchAllColumns            = []string{"id", "name", "user_id", "created_at"}
+	keyBatchColumnsWithoutDefault = []string{"name"}
+	keyBatchColumnsWithDefault    = []string{"id", "user_id", "created_at"}
+	keyBatchPrimaryKeyColumns     = []string{"id"}
+	keyBatchGeneratedColumns      = []string{}
+)
+
+type (
+	// KeyBatchSlice is an alias for a slice of pointers to KeyBatch.
+	// This should almost always be used instead of []KeyBatch.
+	KeyBatchSlice []*KeyBatch
+	// KeyBatchHook is the signature for custom KeyBatch hook methods
+	KeyBatchHook func(context.Context, boil.ContextExecutor, *KeyBatch) error
+
+	keyBatchQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	keyBatchType                 = reflect.TypeOf(&KeyBatch{})
+	keyBatchMapping              = queries.MakeStructMapping(keyBatchType)
+	keyBatchPrimaryKeyMapping, _ = queries.BindMapping(keyBatchType, keyBatchMapping, keyBatchPrimaryKeyColumns)
+	keyBatchInsertCacheMut       sync.RWMutex
+	keyBatchInsertCache          = make(map[string]insertCache)
+	keyBatchUpdateCacheMut       sync.RWMutex
+	keyBatchUpdateCache          = make(map[string]updateCache)
+	keyBatchUpsertCacheMut       sync.RWMutex
+	keyBatchUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var keyBatchAfterSelectMu sync.Mutex
+var keyBatchAfterSelectHooks []KeyBatchHook
+
+var keyBatchBeforeInsertMu sync.Mutex
+var keyBatchBeforeInsertHooks []KeyBatchHook
+var keyBatchAfterInsertMu sync.Mutex
+var keyBatchAfterInsertHooks []KeyBatchHook
+
+var keyBatchBeforeUpdateMu sync.Mutex
+var keyBatchBeforeUpdateHooks []KeyBatchHook
+var keyBatchAfterUpdateMu sync.Mutex
+var keyBatchAfterUpdateHooks []KeyBatchHook
+
+var keyBatchBeforeDeleteMu sync.Mutex
+var keyBatchBeforeDeleteHooks []KeyBatchHook
+var keyBatchAfterDeleteMu sync.Mutex
+var keyBatchAfterDeleteHooks []KeyBatchHook
+
+var keyBatchBeforeUpsertMu sync.Mutex
+var keyBatchBeforeUpsertHooks []KeyBatchHook
+var keyBatchAfterUpsertMu sync.Mutex
+var keyBatchAfterUpsertHooks []KeyBatchHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *KeyBatch) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range keyBatchAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *KeyBatch) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range keyBatchBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *KeyBatch) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range keyBatchAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *KeyBatch) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range keyBatchBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *KeyBatch) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range keyBatchAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *KeyBatch) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range keyBatchBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *KeyBatch) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range keyBatchAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *KeyBatch) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range keyBatchBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *KeyBatch) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range keyBatchAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddKeyBatchHook registers your hook function for all future operations.
+func AddKeyBatchHook(hookPoint boil.HookPoint, keyBatchHook KeyBatchHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		keyBatchAfterSelectMu.Lock()
+		keyBatchAfterSelectHooks = append(keyBatchAfterSelectHooks, keyBatchHook)
+		keyBatchAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		keyBatchBeforeInsertMu.Lock()
+		keyBatchBeforeInsertHooks = append(keyBatchBeforeInsertHooks, keyBatchHook)
+		keyBatchBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		keyBatchAfterInsertMu.Lock()
+		keyBatchAfterInsertHooks = append(keyBatchAfterInsertHooks, keyBatchHook)
+		keyBatchAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		keyBatchBeforeUpdateMu.Lock()
+		keyBatchBeforeUpdateHooks = append(keyBatchBeforeUpdateHooks, keyBatchHook)
+		keyBatchBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		keyBatchAfterUpdateMu.Lock()
+		keyBatchAfterUpdateHooks = append(keyBatchAfterUpdateHooks, keyBatchHook)
+		keyBatchAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		keyBatchBeforeDeleteMu.Lock()
+		keyBatchBeforeDeleteHooks = append(keyBatchBeforeDeleteHooks, keyBatchHook)
+		keyBatchBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		keyBatchAfterDeleteMu.Lock()
+		keyBatchAfterDeleteHooks = append(keyBatchAfterDeleteHooks, keyBatchHook)
+		keyBatchAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		keyBatchBeforeUpsertMu.Lock()
+		keyBatchBeforeUpsertHooks = append(keyBatchBeforeUpsertHooks, keyBatchHook)
+		keyBatchBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		keyBatchAfterUpsertMu.Lock()
+		keyBatchAfterUpsertHooks = append(keyBatchAfterUpsertHooks, keyBatchHook)
+		keyBatchAfterUpsertMu.Unlock()
+	}
+}
+
+// OneG returns a single keyBatch record from the query using the global executor.
+func (q keyBatchQuery) OneG(ctx context.Context) (*KeyBatch, error) {
+	return q.One(ctx, boil.GetContextDB())
+}
+
+// One returns a single keyBatch record from the query.
+func (q keyBatchQuery) One(ctx context.Context, exec boil.ContextExecutor) (*KeyBatch, error) {
+	o := &KeyBatch{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "appdb: failed to execute a one query for key_batch")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// AllG returns all KeyBatch records from the query using the global executor.
+func (q keyBatchQuery) AllG(ctx context.Context) (KeyBatchSlice, error) {
+	return q.All(ctx, boil.GetContextDB())
+}
+
+// All returns all KeyBatch records from the query.
+func (q keyBatchQuery) All(ctx context.Context, exec boil.ContextExecutor) (KeyBatchSlice, error) {
+	var o []*KeyBatch
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "appdb: failed to assign all query results to KeyBatch slice")
+	}
+
+	if len(keyBatchAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// CountG returns the count of all KeyBatch records in the query using the global executor
+func (q keyBatchQuery) CountG(ctx context.Context) (int64, error) {
+	return q.Count(ctx, boil.GetContextDB())
+}
+
+// Count returns the count of all KeyBatch records in the query.
+func (q keyBatchQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to count key_batch rows")
+	}
+
+	return count, nil
+}
+
+// ExistsG checks if the row exists in the table using the global executor.
+func (q keyBatchQuery) ExistsG(ctx context.Context) (bool, error) {
+	return q.Exists(ctx, boil.GetContextDB())
+}
+
+// Exists checks if the row exists in the table.
+func (q keyBatchQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "appdb: failed to check if key_batch exists")
+	}
+
+	return count > 0, nil
+}
+
+// DeviceKeys retrieves all the device_key's DeviceKeys with an executor.
+func (o *KeyBatch) DeviceKeys(mods ...qm.QueryMod) deviceKeyQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"loriot_io\".\"device_key\".\"key_batch_id\"=?", o.ID),
+	)
+
+	return DeviceKeys(queryMods...)
+}
+
+// LoadDeviceKeys allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (keyBatchL) LoadDeviceKeys(ctx context.Context, e boil.ContextExecutor, singular bool, maybeKeyBatch interface{}, mods queries.Applicator) error {
+	var slice []*KeyBatch
+	var object *KeyBatch
+
+	if singular {
+		var ok bool
+		object, ok = maybeKeyBatch.(*KeyBatch)
+		if !ok {
+			object = new(KeyBatch)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeKeyBatch)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeKeyBatch))
+			}
+		}
+	} else {
+		s, ok := maybeKeyBatch.(*[]*KeyBatch)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeKeyBatch)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeKeyBatch))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &keyBatchR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &keyBatchR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`loriot_io.device_key`),
+		qm.WhereIn(`loriot_io.device_key.key_batch_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load device_key")
+	}
+
+	var resultSlice []*DeviceKey
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice device_key")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on device_key")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for device_key")
+	}
+
+	if len(deviceKeyAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.DeviceKeys = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &deviceKeyR{}
+			}
+			foreign.R.KeyBatch = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.KeyBatchID {
+				local.R.DeviceKeys = append(local.R.DeviceKeys, foreign)
+				if foreign.R == nil {
+					foreign.R = &deviceKeyR{}
+				}
+				foreign.R.KeyBatch = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddDeviceKeysG adds the given related objects to the existing relationships
+// of the key_batch, optionally inserting them as new records.
+// Appends related to o.R.DeviceKeys.
+// Sets related.R.KeyBatch appropriately.
+// Uses the global database handle.
+func (o *KeyBatch) AddDeviceKeysG(ctx context.Context, insert bool, related ...*DeviceKey) error {
+	return o.AddDeviceKeys(ctx, boil.GetContextDB(), insert, related...)
+}
+
+// AddDeviceKeys adds the given related objects to the existing relationships
+// of the key_batch, optionally inserting them as new records.
+// Appends related to o.R.DeviceKeys.
+// Sets related.R.KeyBatch appropriately.
+func (o *KeyBatch) AddDeviceKeys(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*DeviceKey) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.KeyBatchID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"loriot_io\".\"device_key\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"key_batch_id"}),
+				strmangle.WhereClause("\"", "\"", 2, deviceKeyPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.DevEui}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.KeyBatchID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &keyBatchR{
+			DeviceKeys: related,
+		}
+	} else {
+		o.R.DeviceKeys = append(o.R.DeviceKeys, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &deviceKeyR{
+				KeyBatch: o,
+			}
+		} else {
+			rel.R.KeyBatch = o
+		}
+	}
+	return nil
+}
+
+// KeyBatches retrieves all the records using an executor.
+func KeyBatches(mods ...qm.QueryMod) keyBatchQuery {
+	mods = append(mods, qm.From("\"loriot_io\".\"key_batch\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"loriot_io\".\"key_batch\".*"})
+	}
+
+	return keyBatchQuery{q}
+}
+
+// FindKeyBatchG retrieves a single record by ID.
+func FindKeyBatchG(ctx context.Context, iD int64, selectCols ...string) (*KeyBatch, error) {
+	return FindKeyBatch(ctx, boil.GetContextDB(), iD, selectCols...)
+}
+
+// FindKeyBatch retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindKeyBatch(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*KeyBatch, error) {
+	keyBatchObj := &KeyBatch{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"loriot_io\".\"key_batch\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, keyBatchObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "appdb: unable to select from key_batch")
+	}
+
+	if err = keyBatchObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return keyBatchObj, err
+	}
+
+	return keyBatchObj, nil
+}
+
+// InsertG a single record. See Insert for whitelist behavior description.
+func (o *KeyBatch) InsertG(ctx context.Context, columns boil.Columns) error {
+	return o.Insert(ctx, boil.GetContextDB(), columns)
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *KeyBatch) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("appdb: no key_batch provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(keyBatchColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	keyBatchInsertCacheMut.RLock()
+	cache, cached := keyBatchInsertCache[key]
+	keyBatchInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			keyBatchAllColumns,
+			keyBatchColumnsWithDefault,
+			keyBatchColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(keyBatchType, keyBatchMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(keyBatchType, keyBatchMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"loriot_io\".\"key_batch\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"loriot_io\".\"key_batch\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to insert into key_batch")
+	}
+
+	if !cached {
+		keyBatchInsertCacheMut.Lock()
+		keyBatchInsertCache[key] = cache
+		keyBatchInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// UpdateG a single KeyBatch record using the global executor.
+// See Update for more documentation.
+func (o *KeyBatch) UpdateG(ctx context.Context, columns boil.Columns) (int64, error) {
+	return o.Update(ctx, boil.GetContextDB(), columns)
+}
+
+// Update uses an executor to update the KeyBatch.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *KeyBatch) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	keyBatchUpdateCacheMut.RLock()
+	cache, cached := keyBatchUpdateCache[key]
+	keyBatchUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			keyBatchAllColumns,
+			keyBatchPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("appdb: unable to update key_batch, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"loriot_io\".\"key_batch\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, keyBatchPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(keyBatchType, keyBatchMapping, append(wl, keyBatchPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update key_batch row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by update for key_batch")
+	}
+
+	if !cached {
+		keyBatchUpdateCacheMut.Lock()
+		keyBatchUpdateCache[key] = cache
+		keyBatchUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (q keyBatchQuery) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return q.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q keyBatchQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update all for key_batch")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to retrieve rows affected for key_batch")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (o KeyBatchSlice) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return o.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o KeyBatchSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("appdb: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), keyBatchPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"loriot_io\".\"key_batch\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, keyBatchPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update all in keyBatch slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to retrieve rows affected all in update all keyBatch")
+	}
+	return rowsAff, nil
+}
+
+// UpsertG attempts an insert, and does an update or ignore on conflict.
+func (o *KeyBatch) UpsertG(ctx context.Context, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	return o.Upsert(ctx, boil.GetContextDB(), updateOnConflict, conflictColumns, updateColumns, insertColumns, opts...)
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *KeyBatch) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("appdb: no key_batch provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(keyBatchColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	keyBatchUpsertCacheMut.RLock()
+	cache, cached := keyBatchUpsertCache[key]
+	keyBatchUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			keyBatchAllColumns,
+			keyBatchColumnsWithDefault,
+			keyBatchColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			keyBatchAllColumns,
+			keyBatchPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("appdb: unable to upsert key_batch, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(keyBatchAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(keyBatchPrimaryKeyColumns) == 0 {
+				return errors.New("appdb: unable to upsert key_batch, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(keyBatchPrimaryKeyColumns))
+			copy(conflict, keyBatchPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"loriot_io\".\"key_batch\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(keyBatchType, keyBatchMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(keyBatchType, keyBatchMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to upsert key_batch")
+	}
+
+	if !cached {
+		keyBatchUpsertCacheMut.Lock()
+		keyBatchUpsertCache[key] = cache
+		keyBatchUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// DeleteG deletes a single KeyBatch record.
+// DeleteG will match against the primary key column to find the record to delete.
+func (o *KeyBatch) DeleteG(ctx context.Context) (int64, error) {
+	return o.Delete(ctx, boil.GetContextDB())
+}
+
+// Delete deletes a single KeyBatch record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *KeyBatch) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("appdb: no KeyBatch provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), keyBatchPrimaryKeyMapping)
+	sql := "DELETE FROM \"loriot_io\".\"key_batch\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete from key_batch")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by delete for key_batch")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+func (q keyBatchQuery) DeleteAllG(ctx context.Context) (int64, error) {
+	return q.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all matching rows.
+func (q keyBatchQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("appdb: no keyBatchQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete all from key_batch")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by deleteall for key_batch")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAllG deletes all rows in the slice.
+func (o KeyBatchSlice) DeleteAllG(ctx context.Context) (int64, error) {
+	return o.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o KeyBatchSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(keyBatchBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), keyBatchPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"loriot_io\".\"key_batch\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, keyBatchPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete all from keyBatch slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by deleteall for key_batch")
+	}
+
+	if len(keyBatchAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// ReloadG refetches the object from the database using the primary keys.
+func (o *KeyBatch) ReloadG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("appdb: no KeyBatch provided for reload")
+	}
+
+	return o.Reload(ctx, boil.GetContextDB())
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *KeyBatch) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindKeyBatch(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAllG refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *KeyBatchSlice) ReloadAllG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("appdb: empty KeyBatchSlice provided for reload all")
+	}
+
+	return o.ReloadAll(ctx, boil.GetContextDB())
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *KeyBatchSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := KeyBatchSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), keyBatchPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"loriot_io\".\"key_batch\".* FROM \"loriot_io\".\"key_batch\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, keyBatchPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to reload all in KeyBatchSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// KeyBatchExistsG checks if the KeyBatch row exists.
+func KeyBatchExistsG(ctx context.Context, iD int64) (bool, error) {
+	return KeyBatchExists(ctx, boil.GetContextDB(), iD)
+}
+
+// KeyBatchExists checks if the KeyBatch row exists.
+func KeyBatchExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"loriot_io\".\"key_batch\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "appdb: unable to check if key_batch exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the KeyBatch row exists.
+func (o *KeyBatch) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return KeyBatchExists(ctx, exec, o.ID)
+}
@@ -0,0 +1,1056 @@
+// Code generated by SQLBoiler 4.16.1 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package appdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// KeyVault is an object representing the database table.
+type KeyVault struct {
+	DevEui     string    `boil:"dev_eui" json:"dev_eui" toml:"dev_eui" yaml:"dev_eui"`
+	Keys       []byte    `boil:"keys" json:"keys" toml:"keys" yaml:"keys"`
+	CreatedAt  time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	ModifiedAt null.Time `boil:"modified_at" json:"modified_at,omitempty" toml:"modified_at" yaml:"modified_at,omitempty"`
+
+	R *keyVaultR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L keyVaultL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var KeyVaultColumns = struct {
+	DevEui     string
+	Keys       string
+	CreatedAt  string
+	ModifiedAt string
+}{
+	DevEui:     "dev_eui",
+	Keys:       "keys",
+	CreatedAt:  "created_at",
+	ModifiedAt: "modified_at",
+}
+
+var KeyVaultTableColumns = struct {
+	DevEui     string
+	Keys       string
+	CreatedAt  string
+	ModifiedAt string
+}{
+	DevEui:     "key_vault.dev_eui",
+	Keys:       "key_vault.keys",
+	CreatedAt:  "key_vault.created_at",
+	ModifiedAt: "key_vault.modified_at",
+}
+
+// Generated where
+
+type whereHelper__byte struct{ field string }
+
+func (w whereHelper__byte) EQ(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelper__byte) NEQ(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelper__byte) LT(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelper__byte) LTE(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelper__byte) GT(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelper__byte) GTE(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+
+var KeyVaultWhere = struct {
+	DevEui     whereHelperstring
+	Keys       whereHelper__byte
+	CreatedAt  whereHelpertime_Time
+	ModifiedAt whereHelpernull_Time
+}{
+	DevEui:     whereHelperstring{field: "\"loriot_io\".\"key_vault\".\"dev_eui\""},
+	Keys:       whereHelper__byte{field: "\"loriot_io\".\"key_vault\".\"keys\""},
+	CreatedAt:  whereHelpertime_Time{field: "\"loriot_io\".\"key_vault\".\"created_at\""},
+	ModifiedAt: whereHelpernull_Time{field: "\"loriot_io\".\"key_vault\".\"modified_at\""},
+}
+
+// KeyVaultRels is where relationship names are stored.
+var KeyVaultRels = struct {
+}{}
+
+// keyVaultR is where relationships are stored.
+type keyVaultR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*keyVaultR) NewStruct() *keyVaultR {
+	return &keyVaultR{}
+}
+
+// keyVaultL is where Load methods for each relationship are stored.
+type keyVaultL struct{}
+
+var (
+	keyVaultAllColumns            = []string{"dev_eui", "keys", "created_at", "modified_at"}
+	keyVaultColumnsWithoutDefault = []string{"dev_eui", "keys"}
+	keyVaultColumnsWithDefault    = []string{"created_at", "modified_at"}
+	keyVaultPrimaryKeyColumns     = []string{"dev_eui"}
+	keyVaultGeneratedColumns      = []string{}
+)
+
+type (
+	// KeyVaultSlice is an alias for a slice of pointers to KeyVault.
+	// This should almost always be used instead of []KeyVault.
+	KeyVaultSlice []*KeyVault
+	// KeyVaultHook is the signature for custom KeyVault hook methods
+	KeyVaultHook func(context.Context, boil.ContextExecutor, *KeyVault) error
+
+	keyVaultQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	keyVaultType                 = reflect.TypeOf(&KeyVault{})
+	keyVaultMapping              = queries.MakeStructMapping(keyVaultType)
+	keyVaultPrimaryKeyMapping, _ = queries.BindMapping(keyVaultType, keyVaultMapping, keyVaultPrimaryKeyColumns)
+	keyVaultInsertCacheMut       sync.RWMutex
+	keyVaultInsertCache          = make(map[string]insertCache)
+	keyVaultUpdateCacheMut       sync.RWMutex
+	keyVaultUpdateCache          = make(map[string]updateCache)
+	keyVaultUpsertCacheMut       sync.RWMutex
+	keyVaultUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var keyVaultAfterSelectMu sync.Mutex
+var keyVaultAfterSelectHooks []KeyVaultHook
+
+var keyVaultBeforeInsertMu sync.Mutex
+var keyVaultBeforeInsertHooks []KeyVaultHook
+var keyVaultAfterInsertMu sync.Mutex
+var keyVaultAfterInsertHooks []KeyVaultHook
+
+var keyVaultBeforeUpdateMu sync.Mutex
+var keyVaultBeforeUpdateHooks []KeyVaultHook
+var keyVaultAfterUpdateMu sync.Mutex
+var keyVaultAfterUpdateHooks []KeyVaultHook
+
+var keyVaultBeforeDeleteMu sync.Mutex
+var keyVaultBeforeDeleteHooks []KeyVaultHook
+var keyVaultAfterDeleteMu sync.Mutex
+var keyVaultAfterDeleteHooks []KeyVaultHook
+
+var keyVaultBeforeUpsertMu sync.Mutex
+var keyVaultBeforeUpsertHooks []KeyVaultHook
+var keyVaultAfterUpsertMu sync.Mutex
+var keyVaultAfterUpsertHooks []KeyVaultHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *KeyVault) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range keyVaultAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *KeyVault) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range keyVaultBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *KeyVault) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range keyVaultAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *KeyVault) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range keyVaultBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *KeyVault) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range keyVaultAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *KeyVault) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range keyVaultBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *KeyVault) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range keyVaultAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *KeyVault) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range keyVaultBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *KeyVault) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range keyVaultAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddKeyVaultHook registers your hook function for all future operations.
+func AddKeyVaultHook(hookPoint boil.HookPoint, keyVaultHook KeyVaultHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		keyVaultAfterSelectMu.Lock()
+		keyVaultAfterSelectHooks = append(keyVaultAfterSelectHooks, keyVaultHook)
+		keyVaultAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		keyVaultBeforeInsertMu.Lock()
+		keyVaultBeforeInsertHooks = append(keyVaultBeforeInsertHooks, keyVaultHook)
+		keyVaultBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		keyVaultAfterInsertMu.Lock()
+		keyVaultAfterInsertHooks = append(keyVaultAfterInsertHooks, keyVaultHook)
+		keyVaultAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		keyVaultBeforeUpdateMu.Lock()
+		keyVaultBeforeUpdateHooks = append(keyVaultBeforeUpdateHooks, keyVaultHook)
+		keyVaultBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		keyVaultAfterUpdateMu.Lock()
+		keyVaultAfterUpdateHooks = append(keyVaultAfterUpdateHooks, keyVaultHook)
+		keyVaultAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		keyVaultBeforeDeleteMu.Lock()
+		keyVaultBeforeDeleteHooks = append(keyVaultBeforeDeleteHooks, keyVaultHook)
+		keyVaultBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		keyVaultAfterDeleteMu.Lock()
+		keyVaultAfterDeleteHooks = append(keyVaultAfterDeleteHooks, keyVaultHook)
+		keyVaultAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		keyVaultBeforeUpsertMu.Lock()
+		keyVaultBeforeUpsertHooks = append(keyVaultBeforeUpsertHooks, keyVaultHook)
+		keyVaultBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		keyVaultAfterUpsertMu.Lock()
+		keyVaultAfterUpsertHooks = append(keyVaultAfterUpsertHooks, keyVaultHook)
+		keyVaultAfterUpsertMu.Unlock()
+	}
+}
+
+// OneG returns a single keyVault record from the query using the global executor.
+func (q keyVaultQuery) OneG(ctx context.Context) (*KeyVault, error) {
+	return q.One(ctx, boil.GetContextDB())
+}
+
+// One returns a single keyVault record from the query.
+func (q keyVaultQuery) One(ctx context.Context, exec boil.ContextExecutor) (*KeyVault, error) {
+	o := &KeyVault{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "appdb: failed to execute a one query for key_vault")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// AllG returns all KeyVault records from the query using the global executor.
+func (q keyVaultQuery) AllG(ctx context.Context) (KeyVaultSlice, error) {
+	return q.All(ctx, boil.GetContextDB())
+}
+
+// All returns all KeyVault records from the query.
+func (q keyVaultQuery) All(ctx context.Context, exec boil.ContextExecutor) (KeyVaultSlice, error) {
+	var o []*KeyVault
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "appdb: failed to assign all query results to KeyVault slice")
+	}
+
+	if len(keyVaultAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// CountG returns the count of all KeyVault records in the query using the global executor
+func (q keyVaultQuery) CountG(ctx context.Context) (int64, error) {
+	return q.Count(ctx, boil.GetContextDB())
+}
+
+// Count returns the count of all KeyVault records in the query.
+func (q keyVaultQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to count key_vault rows")
+	}
+
+	return count, nil
+}
+
+// ExistsG checks if the row exists in the table using the global executor.
+func (q keyVaultQuery) ExistsG(ctx context.Context) (bool, error) {
+	return q.Exists(ctx, boil.GetContextDB())
+}
+
+// Exists checks if the row exists in the table.
+func (q keyVaultQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "appdb: failed to check if key_vault exists")
+	}
+
+	return count > 0, nil
+}
+
+// KeyVaults retrieves all the records using an executor.
+func KeyVaults(mods ...qm.QueryMod) keyVaultQuery {
+	mods = append(mods, qm.From("\"loriot_io\".\"key_vault\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"loriot_io\".\"key_vault\".*"})
+	}
+
+	return keyVaultQuery{q}
+}
+
+// FindKeyVaultG retrieves a single record by ID.
+func FindKeyVaultG(ctx context.Context, devEui string, selectCols ...string) (*KeyVault, error) {
+	return FindKeyVault(ctx, boil.GetContextDB(), devEui, selectCols...)
+}
+
+// FindKeyVault retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindKeyVault(ctx context.Context, exec boil.ContextExecutor, devEui string, selectCols ...string) (*KeyVault, error) {
+	keyVaultObj := &KeyVault{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"loriot_io\".\"key_vault\" where \"dev_eui\"=$1", sel,
+	)
+
+	q := queries.Raw(query, devEui)
+
+	err := q.Bind(ctx, exec, keyVaultObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "appdb: unable to select from key_vault")
+	}
+
+	if err = keyVaultObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return keyVaultObj, err
+	}
+
+	return keyVaultObj, nil
+}
+
+// InsertG a single record. See Insert for whitelist behavior description.
+func (o *KeyVault) InsertG(ctx context.Context, columns boil.Columns) error {
+	return o.Insert(ctx, boil.GetContextDB(), columns)
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *KeyVault) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("appdb: no key_vault provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(keyVaultColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	keyVaultInsertCacheMut.RLock()
+	cache, cached := keyVaultInsertCache[key]
+	keyVaultInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			keyVaultAllColumns,
+			keyVaultColumnsWithDefault,
+			keyVaultColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(keyVaultType, keyVaultMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(keyVaultType, keyVaultMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"loriot_io\".\"key_vault\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"loriot_io\".\"key_vault\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to insert into key_vault")
+	}
+
+	if !cached {
+		keyVaultInsertCacheMut.Lock()
+		keyVaultInsertCache[key] = cache
+		keyVaultInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// UpdateG a single KeyVault record using the global executor.
+// See Update for more documentation.
+func (o *KeyVault) UpdateG(ctx context.Context, columns boil.Columns) (int64, error) {
+	return o.Update(ctx, boil.GetContextDB(), columns)
+}
+
+// Update uses an executor to update the KeyVault.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *KeyVault) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	keyVaultUpdateCacheMut.RLock()
+	cache, cached := keyVaultUpdateCache[key]
+	keyVaultUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			keyVaultAllColumns,
+			keyVaultPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("appdb: unable to update key_vault, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"loriot_io\".\"key_vault\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, keyVaultPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(keyVaultType, keyVaultMapping, append(wl, keyVaultPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update key_vault row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by update for key_vault")
+	}
+
+	if !cached {
+		keyVaultUpdateCacheMut.Lock()
+		keyVaultUpdateCache[key] = cache
+		keyVaultUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (q keyVaultQuery) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return q.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q keyVaultQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update all for key_vault")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to retrieve rows affected for key_vault")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (o KeyVaultSlice) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return o.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o KeyVaultSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("appdb: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), keyVaultPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"loriot_io\".\"key_vault\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, keyVaultPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update all in keyVault slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to retrieve rows affected all in update all keyVault")
+	}
+	return rowsAff, nil
+}
+
+// UpsertG attempts an insert, and does an update or ignore on conflict.
+func (o *KeyVault) UpsertG(ctx context.Context, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	return o.Upsert(ctx, boil.GetContextDB(), updateOnConflict, conflictColumns, updateColumns, insertColumns, opts...)
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *KeyVault) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("appdb: no key_vault provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(keyVaultColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	keyVaultUpsertCacheMut.RLock()
+	cache, cached := keyVaultUpsertCache[key]
+	keyVaultUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			keyVaultAllColumns,
+			keyVaultColumnsWithDefault,
+			keyVaultColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			keyVaultAllColumns,
+			keyVaultPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("appdb: unable to upsert key_vault, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(keyVaultAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(keyVaultPrimaryKeyColumns) == 0 {
+				return errors.New("appdb: unable to upsert key_vault, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(keyVaultPrimaryKeyColumns))
+			copy(conflict, keyVaultPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"loriot_io\".\"key_vault\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(keyVaultType, keyVaultMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(keyVaultType, keyVaultMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to upsert key_vault")
+	}
+
+	if !cached {
+		keyVaultUpsertCacheMut.Lock()
+		keyVaultUpsertCache[key] = cache
+		keyVaultUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// DeleteG deletes a single KeyVault record.
+// DeleteG will match against the primary key column to find the record to delete.
+func (o *KeyVault) DeleteG(ctx context.Context) (int64, error) {
+	return o.Delete(ctx, boil.GetContextDB())
+}
+
+// Delete deletes a single KeyVault record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *KeyVault) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("appdb: no KeyVault provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), keyVaultPrimaryKeyMapping)
+	sql := "DELETE FROM \"loriot_io\".\"key_vault\" WHERE \"dev_eui\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete from key_vault")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by delete for key_vault")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+func (q keyVaultQuery) DeleteAllG(ctx context.Context) (int64, error) {
+	return q.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all matching rows.
+func (q keyVaultQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("appdb: no keyVaultQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete all from key_vault")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by deleteall for key_vault")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAllG deletes all rows in the slice.
+func (o KeyVaultSlice) DeleteAllG(ctx context.Context) (int64, error) {
+	return o.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o KeyVaultSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(keyVaultBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), keyVaultPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"loriot_io\".\"key_vault\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, keyVaultPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete all from keyVault slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by deleteall for key_vault")
+	}
+
+	if len(keyVaultAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// ReloadG refetches the object from the database using the primary keys.
+func (o *KeyVault) ReloadG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("appdb: no KeyVault provided for reload")
+	}
+
+	return o.Reload(ctx, boil.GetContextDB())
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *KeyVault) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindKeyVault(ctx, exec, o.DevEui)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAllG refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *KeyVaultSlice) ReloadAllG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("appdb: empty KeyVaultSlice provided for reload all")
+	}
+
+	return o.ReloadAll(ctx, boil.GetContextDB())
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *KeyVaultSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := KeyVaultSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), keyVaultPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"loriot_io\".\"key_vault\".* FROM \"loriot_io\".\"key_vault\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, keyVaultPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to reload all in KeyVaultSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// KeyVaultExistsG checks if the KeyVault row exists.
+func KeyVaultExistsG(ctx context.Context, devEui string) (bool, error) {
+	return KeyVaultExists(ctx, boil.GetContextDB(), devEui)
+}
+
+// KeyVaultExists checks if the KeyVault row exists.
+func KeyVaultExists(ctx context.Context, exec boil.ContextExecutor, devEui string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"loriot_io\".\"key_vault\" where \"dev_eui\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, devEui)
+	}
+	row := exec.QueryRowContext(ctx, sql, devEui)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "appdb: unable to check if key_vault exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the KeyVault row exists.
+func (o *KeyVault) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return KeyVaultExists(ctx, exec, o.DevEui)
+}
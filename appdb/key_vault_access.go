@@ -0,0 +1,1033 @@
+// Code generated by SQLBoiler 4.16.1 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package appdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// KeyVaultAccess is an object representing the database table.
+type KeyVaultAccess struct {
+	ID         int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	DevEui     string      `boil:"dev_eui" json:"dev_eui" toml:"dev_eui" yaml:"dev_eui"`
+	UserID     null.String `boil:"user_id" json:"user_id,omitempty" toml:"user_id" yaml:"user_id,omitempty"`
+	AccessedAt time.Time   `boil:"accessed_at" json:"accessed_at" toml:"accessed_at" yaml:"accessed_at"`
+
+	R *keyVaultAccessR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L keyVaultAccessL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var KeyVaultAccessColumns = struct {
+	ID         string
+	DevEui     string
+	UserID     string
+	AccessedAt string
+}{
+	ID:         "id",
+	DevEui:     "dev_eui",
+	UserID:     "user_id",
+	AccessedAt: "accessed_at",
+}
+
+var KeyVaultAccessTableColumns = struct {
+	ID         string
+	DevEui     string
+	UserID     string
+	AccessedAt string
+}{
+	ID:         "key_vault_access.id",
+	DevEui:     "key_vault_access.dev_eui",
+	UserID:     "key_vault_access.user_id",
+	AccessedAt: "key_vault_access.accessed_at",
+}
+
+// Generated where
+
+var KeyVaultAccessWhere = struct {
+	ID         whereHelperint64
+	DevEui     whereHelperstring
+	UserID     whereHelpernull_String
+	AccessedAt whereHelpertime_Time
+}{
+	ID:         whereHelperint64{field: "\"loriot_io\".\"key_vault_access\".\"id\""},
+	DevEui:     whereHelperstring{field: "\"loriot_io\".\"key_vault_access\".\"dev_eui\""},
+	UserID:     whereHelpernull_String{field: "\"loriot_io\".\"key_vault_access\".\"user_id\""},
+	AccessedAt: whereHelpertime_Time{field: "\"loriot_io\".\"key_vault_access\".\"accessed_at\""},
+}
+
+// KeyVaultAccessRels is where relationship names are stored.
+var KeyVaultAccessRels = struct {
+}{}
+
+// keyVaultAccessR is where relationships are stored.
+type keyVaultAccessR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*keyVaultAccessR) NewStruct() *keyVaultAccessR {
+	return &keyVaultAccessR{}
+}
+
+// keyVaultAccessL is where Load methods for each relationship are stored.
+type keyVaultAccessL struct{}
+
+var (
+	keyVaultAccessAllColumns            = []string{"id", "dev_eui", "user_id", "accessed_at"}
+	keyVaultAccessColumnsWithoutDefault = []string{"dev_eui"}
+	keyVaultAccessColumnsWithDefault    = []string{"id", "user_id", "accessed_at"}
+	keyVaultAccessPrimaryKeyColumns     = []string{"id"}
+	keyVaultAccessGeneratedColumns      = []string{}
+)
+
+type (
+	// KeyVaultAccessSlice is an alias for a slice of pointers to KeyVaultAccess.
+	// This should almost always be used instead of []KeyVaultAccess.
+	KeyVaultAccessSlice []*KeyVaultAccess
+	// KeyVaultAccessHook is the signature for custom KeyVaultAccess hook methods
+	KeyVaultAccessHook func(context.Context, boil.ContextExecutor, *KeyVaultAccess) error
+
+	keyVaultAccessQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	keyVaultAccessType                 = reflect.TypeOf(&KeyVaultAccess{})
+	keyVaultAccessMapping              = queries.MakeStructMapping(keyVaultAccessType)
+	keyVaultAccessPrimaryKeyMapping, _ = queries.BindMapping(keyVaultAccessType, keyVaultAccessMapping, keyVaultAccessPrimaryKeyColumns)
+	keyVaultAccessInsertCacheMut       sync.RWMutex
+	keyVaultAccessInsertCache          = make(map[string]insertCache)
+	keyVaultAccessUpdateCacheMut       sync.RWMutex
+	keyVaultAccessUpdateCache          = make(map[string]updateCache)
+	keyVaultAccessUpsertCacheMut       sync.RWMutex
+	keyVaultAccessUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var keyVaultAccessAfterSelectMu sync.Mutex
+var keyVaultAccessAfterSelectHooks []KeyVaultAccessHook
+
+var keyVaultAccessBeforeInsertMu sync.Mutex
+var keyVaultAccessBeforeInsertHooks []KeyVaultAccessHook
+var keyVaultAccessAfterInsertMu sync.Mutex
+var keyVaultAccessAfterInsertHooks []KeyVaultAccessHook
+
+var keyVaultAccessBeforeUpdateMu sync.Mutex
+var keyVaultAccessBeforeUpdateHooks []KeyVaultAccessHook
+var keyVaultAccessAfterUpdateMu sync.Mutex
+var keyVaultAccessAfterUpdateHooks []KeyVaultAccessHook
+
+var keyVaultAccessBeforeDeleteMu sync.Mutex
+var keyVaultAccessBeforeDeleteHooks []KeyVaultAccessHook
+var keyVaultAccessAfterDeleteMu sync.Mutex
+var keyVaultAccessAfterDeleteHooks []KeyVaultAccessHook
+
+var keyVaultAccessBeforeUpsertMu sync.Mutex
+var keyVaultAccessBeforeUpsertHooks []KeyVaultAccessHook
+var keyVaultAccessAfterUpsertMu sync.Mutex
+var keyVaultAccessAfterUpsertHooks []KeyVaultAccessHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *KeyVaultAccess) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range keyVaultAccessAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *KeyVaultAccess) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range keyVaultAccessBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *KeyVaultAccess) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range keyVaultAccessAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *KeyVaultAccess) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range keyVaultAccessBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *KeyVaultAccess) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range keyVaultAccessAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *KeyVaultAccess) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range keyVaultAccessBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *KeyVaultAccess) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range keyVaultAccessAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *KeyVaultAccess) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range keyVaultAccessBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *KeyVaultAccess) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range keyVaultAccessAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddKeyVaultAccessHook registers your hook function for all future operations.
+func AddKeyVaultAccessHook(hookPoint boil.HookPoint, keyVaultAccessHook KeyVaultAccessHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		keyVaultAccessAfterSelectMu.Lock()
+		keyVaultAccessAfterSelectHooks = append(keyVaultAccessAfterSelectHooks, keyVaultAccessHook)
+		keyVaultAccessAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		keyVaultAccessBeforeInsertMu.Lock()
+		keyVaultAccessBeforeInsertHooks = append(keyVaultAccessBeforeInsertHooks, keyVaultAccessHook)
+		keyVaultAccessBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		keyVaultAccessAfterInsertMu.Lock()
+		keyVaultAccessAfterInsertHooks = append(keyVaultAccessAfterInsertHooks, keyVaultAccessHook)
+		keyVaultAccessAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		keyVaultAccessBeforeUpdateMu.Lock()
+		keyVaultAccessBeforeUpdateHooks = append(keyVaultAccessBeforeUpdateHooks, keyVaultAccessHook)
+		keyVaultAccessBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		keyVaultAccessAfterUpdateMu.Lock()
+		keyVaultAccessAfterUpdateHooks = append(keyVaultAccessAfterUpdateHooks, keyVaultAccessHook)
+		keyVaultAccessAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		keyVaultAccessBeforeDeleteMu.Lock()
+		keyVaultAccessBeforeDeleteHooks = append(keyVaultAccessBeforeDeleteHooks, keyVaultAccessHook)
+		keyVaultAccessBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		keyVaultAccessAfterDeleteMu.Lock()
+		keyVaultAccessAfterDeleteHooks = append(keyVaultAccessAfterDeleteHooks, keyVaultAccessHook)
+		keyVaultAccessAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		keyVaultAccessBeforeUpsertMu.Lock()
+		keyVaultAccessBeforeUpsertHooks = append(keyVaultAccessBeforeUpsertHooks, keyVaultAccessHook)
+		keyVaultAccessBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		keyVaultAccessAfterUpsertMu.Lock()
+		keyVaultAccessAfterUpsertHooks = append(keyVaultAccessAfterUpsertHooks, keyVaultAccessHook)
+		keyVaultAccessAfterUpsertMu.Unlock()
+	}
+}
+
+// OneG returns a single keyVaultAccess record from the query using the global executor.
+func (q keyVaultAccessQuery) OneG(ctx context.Context) (*KeyVaultAccess, error) {
+	return q.One(ctx, boil.GetContextDB())
+}
+
+// One returns a single keyVaultAccess record from the query.
+func (q keyVaultAccessQuery) One(ctx context.Context, exec boil.ContextExecutor) (*KeyVaultAccess, error) {
+	o := &KeyVaultAccess{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "appdb: failed to execute a one query for key_vault_access")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// AllG returns all KeyVaultAccess records from the query using the global executor.
+func (q keyVaultAccessQuery) AllG(ctx context.Context) (KeyVaultAccessSlice, error) {
+	return q.All(ctx, boil.GetContextDB())
+}
+
+// All returns all KeyVaultAccess records from the query.
+func (q keyVaultAccessQuery) All(ctx context.Context, exec boil.ContextExecutor) (KeyVaultAccessSlice, error) {
+	var o []*KeyVaultAccess
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "appdb: failed to assign all query results to KeyVaultAccess slice")
+	}
+
+	if len(keyVaultAccessAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// CountG returns the count of all KeyVaultAccess records in the query using the global executor
+func (q keyVaultAccessQuery) CountG(ctx context.Context) (int64, error) {
+	return q.Count(ctx, boil.GetContextDB())
+}
+
+// Count returns the count of all KeyVaultAccess records in the query.
+func (q keyVaultAccessQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to count key_vault_access rows")
+	}
+
+	return count, nil
+}
+
+// ExistsG checks if the row exists in the table using the global executor.
+func (q keyVaultAccessQuery) ExistsG(ctx context.Context) (bool, error) {
+	return q.Exists(ctx, boil.GetContextDB())
+}
+
+// Exists checks if the row exists in the table.
+func (q keyVaultAccessQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "appdb: failed to check if key_vault_access exists")
+	}
+
+	return count > 0, nil
+}
+
+// KeyVaultAccesses retrieves all the records using an executor.
+func KeyVaultAccesses(mods ...qm.QueryMod) keyVaultAccessQuery {
+	mods = append(mods, qm.From("\"loriot_io\".\"key_vault_access\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"loriot_io\".\"key_vault_access\".*"})
+	}
+
+	return keyVaultAccessQuery{q}
+}
+
+// FindKeyVaultAccessG retrieves a single record by ID.
+func FindKeyVaultAccessG(ctx context.Context, iD int64, selectCols ...string) (*KeyVaultAccess, error) {
+	return FindKeyVaultAccess(ctx, boil.GetContextDB(), iD, selectCols...)
+}
+
+// FindKeyVaultAccess retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindKeyVaultAccess(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*KeyVaultAccess, error) {
+	keyVaultAccessObj := &KeyVaultAccess{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"loriot_io\".\"key_vault_access\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, keyVaultAccessObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "appdb: unable to select from key_vault_access")
+	}
+
+	if err = keyVaultAccessObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return keyVaultAccessObj, err
+	}
+
+	return keyVaultAccessObj, nil
+}
+
+// InsertG a single record. See Insert for whitelist behavior description.
+func (o *KeyVaultAccess) InsertG(ctx context.Context, columns boil.Columns) error {
+	return o.Insert(ctx, boil.GetContextDB(), columns)
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *KeyVaultAccess) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("appdb: no key_vault_access provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(keyVaultAccessColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	keyVaultAccessInsertCacheMut.RLock()
+	cache, cached := keyVaultAccessInsertCache[key]
+	keyVaultAccessInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			keyVaultAccessAllColumns,
+			keyVaultAccessColumnsWithDefault,
+			keyVaultAccessColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(keyVaultAccessType, keyVaultAccessMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(keyVaultAccessType, keyVaultAccessMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"loriot_io\".\"key_vault_access\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"loriot_io\".\"key_vault_access\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to insert into key_vault_access")
+	}
+
+	if !cached {
+		keyVaultAccessInsertCacheMut.Lock()
+		keyVaultAccessInsertCache[key] = cache
+		keyVaultAccessInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// UpdateG a single KeyVaultAccess record using the global executor.
+// See Update for more documentation.
+func (o *KeyVaultAccess) UpdateG(ctx context.Context, columns boil.Columns) (int64, error) {
+	return o.Update(ctx, boil.GetContextDB(), columns)
+}
+
+// Update uses an executor to update the KeyVaultAccess.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *KeyVaultAccess) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	keyVaultAccessUpdateCacheMut.RLock()
+	cache, cached := keyVaultAccessUpdateCache[key]
+	keyVaultAccessUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			keyVaultAccessAllColumns,
+			keyVaultAccessPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("appdb: unable to update key_vault_access, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"loriot_io\".\"key_vault_access\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, keyVaultAccessPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(keyVaultAccessType, keyVaultAccessMapping, append(wl, keyVaultAccessPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update key_vault_access row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by update for key_vault_access")
+	}
+
+	if !cached {
+		keyVaultAccessUpdateCacheMut.Lock()
+		keyVaultAccessUpdateCache[key] = cache
+		keyVaultAccessUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (q keyVaultAccessQuery) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return q.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q keyVaultAccessQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update all for key_vault_access")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to retrieve rows affected for key_vault_access")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (o KeyVaultAccessSlice) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return o.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o KeyVaultAccessSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("appdb: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), keyVaultAccessPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"loriot_io\".\"key_vault_access\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, keyVaultAccessPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update all in keyVaultAccess slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to retrieve rows affected all in update all keyVaultAccess")
+	}
+	return rowsAff, nil
+}
+
+// UpsertG attempts an insert, and does an update or ignore on conflict.
+func (o *KeyVaultAccess) UpsertG(ctx context.Context, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	return o.Upsert(ctx, boil.GetContextDB(), updateOnConflict, conflictColumns, updateColumns, insertColumns, opts...)
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *KeyVaultAccess) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("appdb: no key_vault_access provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(keyVaultAccessColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	keyVaultAccessUpsertCacheMut.RLock()
+	cache, cached := keyVaultAccessUpsertCache[key]
+	keyVaultAccessUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			keyVaultAccessAllColumns,
+			keyVaultAccessColumnsWithDefault,
+			keyVaultAccessColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			keyVaultAccessAllColumns,
+			keyVaultAccessPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("appdb: unable to upsert key_vault_access, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(keyVaultAccessAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(keyVaultAccessPrimaryKeyColumns) == 0 {
+				return errors.New("appdb: unable to upsert key_vault_access, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(keyVaultAccessPrimaryKeyColumns))
+			copy(conflict, keyVaultAccessPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"loriot_io\".\"key_vault_access\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(keyVaultAccessType, keyVaultAccessMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(keyVaultAccessType, keyVaultAccessMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to upsert key_vault_access")
+	}
+
+	if !cached {
+		keyVaultAccessUpsertCacheMut.Lock()
+		keyVaultAccessUpsertCache[key] = cache
+		keyVaultAccessUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// DeleteG deletes a single KeyVaultAccess record.
+// DeleteG will match against the primary key column to find the record to delete.
+func (o *KeyVaultAccess) DeleteG(ctx context.Context) (int64, error) {
+	return o.Delete(ctx, boil.GetContextDB())
+}
+
+// Delete deletes a single KeyVaultAccess record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *KeyVaultAccess) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("appdb: no KeyVaultAccess provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), keyVaultAccessPrimaryKeyMapping)
+	sql := "DELETE FROM \"loriot_io\".\"key_vault_access\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete from key_vault_access")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by delete for key_vault_access")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+func (q keyVaultAccessQuery) DeleteAllG(ctx context.Context) (int64, error) {
+	return q.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all matching rows.
+func (q keyVaultAccessQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("appdb: no keyVaultAccessQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete all from key_vault_access")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by deleteall for key_vault_access")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAllG deletes all rows in the slice.
+func (o KeyVaultAccessSlice) DeleteAllG(ctx context.Context) (int64, error) {
+	return o.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o KeyVaultAccessSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(keyVaultAccessBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), keyVaultAccessPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"loriot_io\".\"key_vault_access\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, keyVaultAccessPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete all from keyVaultAccess slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by deleteall for key_vault_access")
+	}
+
+	if len(keyVaultAccessAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// ReloadG refetches the object from the database using the primary keys.
+func (o *KeyVaultAccess) ReloadG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("appdb: no KeyVaultAccess provided for reload")
+	}
+
+	return o.Reload(ctx, boil.GetContextDB())
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *KeyVaultAccess) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindKeyVaultAccess(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAllG refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *KeyVaultAccessSlice) ReloadAllG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("appdb: empty KeyVaultAccessSlice provided for reload all")
+	}
+
+	return o.ReloadAll(ctx, boil.GetContextDB())
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *KeyVaultAccessSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := KeyVaultAccessSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), keyVaultAccessPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"loriot_io\".\"key_vault_access\".* FROM \"loriot_io\".\"key_vault_access\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, keyVaultAccessPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to reload all in KeyVaultAccessSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// KeyVaultAccessExistsG checks if the KeyVaultAccess row exists.
+func KeyVaultAccessExistsG(ctx context.Context, iD int64) (bool, error) {
+	return KeyVaultAccessExists(ctx, boil.GetContextDB(), iD)
+}
+
+// KeyVaultAccessExists checks if the KeyVaultAccess row exists.
+func KeyVaultAccessExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"loriot_io\".\"key_vault_access\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "appdb: unable to check if key_vault_access exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the KeyVaultAccess row exists.
+func (o *KeyVaultAccess) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return KeyVaultAccessExists(ctx, exec, o.ID)
+}
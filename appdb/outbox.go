@@ -0,0 +1,1322 @@
+// Code generated by SQLBoiler 4.16.1 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package appdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// Outbox is an object representing the database table.
+type Outbox struct {
+	ID              int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	ConfigurationID int64       `boil:"configuration_id" json:"configuration_id" toml:"configuration_id" yaml:"configuration_id"`
+	AssetID         int32       `boil:"asset_id" json:"asset_id" toml:"asset_id" yaml:"asset_id"`
+	DevEui          string      `boil:"dev_eui" json:"dev_eui" toml:"dev_eui" yaml:"dev_eui"`
+	Operation       string      `boil:"operation" json:"operation" toml:"operation" yaml:"operation"`
+	Asset           types.JSON  `boil:"asset" json:"asset" toml:"asset" yaml:"asset"`
+	Status          string      `boil:"status" json:"status" toml:"status" yaml:"status"`
+	Attempts        int32       `boil:"attempts" json:"attempts" toml:"attempts" yaml:"attempts"`
+	NextAttemptAt   time.Time   `boil:"next_attempt_at" json:"next_attempt_at" toml:"next_attempt_at" yaml:"next_attempt_at"`
+	LastError       null.String `boil:"last_error" json:"last_error,omitempty" toml:"last_error" yaml:"last_error,omitempty"`
+	CreatedAt       time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	ModifiedAt      null.Time   `boil:"modified_at" json:"modified_at,omitempty" toml:"modified_at" yaml:"modified_at,omitempty"`
+
+	R *outboxR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L outboxL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var OutboxColumns = struct {
+	ID              string
+	ConfigurationID string
+	AssetID         string
+	DevEui          string
+	Operation       string
+	Asset           string
+	Status          string
+	Attempts        string
+	NextAttemptAt   string
+	LastError       string
+	CreatedAt       string
+	ModifiedAt      string
+}{
+	ID:              "id",
+	ConfigurationID: "configuration_id",
+	AssetID:         "asset_id",
+	DevEui:          "dev_eui",
+	Operation:       "operation",
+	Asset:           "asset",
+	Status:          "status",
+	Attempts:        "attempts",
+	NextAttemptAt:   "next_attempt_at",
+	LastError:       "last_error",
+	CreatedAt:       "created_at",
+	ModifiedAt:      "modified_at",
+}
+
+var OutboxTableColumns = struct {
+	ID              string
+	ConfigurationID string
+	AssetID         string
+	DevEui          string
+	Operation       string
+	Asset           string
+	Status          string
+	Attempts        string
+	NextAttemptAt   string
+	LastError       string
+	CreatedAt       string
+	ModifiedAt      string
+}{
+	ID:              "outbox.id",
+	ConfigurationID: "outbox.configuration_id",
+	AssetID:         "outbox.asset_id",
+	DevEui:          "outbox.dev_eui",
+	Operation:       "outbox.operation",
+	Asset:           "outbox.asset",
+	Status:          "outbox.status",
+	Attempts:        "outbox.attempts",
+	NextAttemptAt:   "outbox.next_attempt_at",
+	LastError:       "outbox.last_error",
+	CreatedAt:       "outbox.created_at",
+	ModifiedAt:      "outbox.modified_at",
+}
+
+// Generated where
+
+type whereHelpertypes_JSON struct{ field string }
+
+func (w whereHelpertypes_JSON) EQ(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.EQ, x)
+}
+func (w whereHelpertypes_JSON) NEQ(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelpertypes_JSON) LT(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertypes_JSON) LTE(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertypes_JSON) GT(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertypes_JSON) GTE(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+var OutboxWhere = struct {
+	ID              whereHelperint64
+	ConfigurationID whereHelperint64
+	AssetID         whereHelperint32
+	DevEui          whereHelperstring
+	Operation       whereHelperstring
+	Asset           whereHelpertypes_JSON
+	Status          whereHelperstring
+	Attempts        whereHelperint32
+	NextAttemptAt   whereHelpertime_Time
+	LastError       whereHelpernull_String
+	CreatedAt       whereHelpertime_Time
+	ModifiedAt      whereHelpernull_Time
+}{
+	ID:              whereHelperint64{field: "\"loriot_io\".\"outbox\".\"id\""},
+	ConfigurationID: whereHelperint64{field: "\"loriot_io\".\"outbox\".\"configuration_id\""},
+	AssetID:         whereHelperint32{field: "\"loriot_io\".\"outbox\".\"asset_id\""},
+	DevEui:          whereHelperstring{field: "\"loriot_io\".\"outbox\".\"dev_eui\""},
+	Operation:       whereHelperstring{field: "\"loriot_io\".\"outbox\".\"operation\""},
+	Asset:           whereHelpertypes_JSON{field: "\"loriot_io\".\"outbox\".\"asset\""},
+	Status:          whereHelperstring{field: "\"loriot_io\".\"outbox\".\"status\""},
+	Attempts:        whereHelperint32{field: "\"loriot_io\".\"outbox\".\"attempts\""},
+	NextAttemptAt:   whereHelpertime_Time{field: "\"loriot_io\".\"outbox\".\"next_attempt_at\""},
+	LastError:       whereHelpernull_String{field: "\"loriot_io\".\"outbox\".\"last_error\""},
+	CreatedAt:       whereHelpertime_Time{field: "\"loriot_io\".\"outbox\".\"created_at\""},
+	ModifiedAt:      whereHelpernull_Time{field: "\"loriot_io\".\"outbox\".\"modified_at\""},
+}
+
+// OutboxRels is where relationship names are stored.
+var OutboxRels = struct {
+	Configuration string
+}{
+	Configuration: "Configuration",
+}
+
+// outboxR is where relationships are stored.
+type outboxR struct {
+	Configuration *Configuration `boil:"Configuration" json:"Configuration" toml:"Configuration" yaml:"Configuration"`
+}
+
+// NewStruct creates a new relationship struct
+func (*outboxR) NewStruct() *outboxR {
+	return &outboxR{}
+}
+
+func (r *outboxR) GetConfiguration() *Configuration {
+	if r == nil {
+		return nil
+	}
+	return r.Configuration
+}
+
+// outboxL is where Load methods for each relationship are stored.
+type outboxL struct{}
+
+var (
+	outboxAllColumns            = []string{"id", "configuration_id", "asset_id", "dev_eui", "operation", "asset", "status", "attempts", "next_attempt_at", "last_error", "created_at", "modified_at"}
+	outboxColumnsWithoutDefault = []string{"configuration_id", "asset_id", "dev_eui", "operation", "asset", "status"}
+	outboxColumnsWithDefault    = []string{"id", "attempts", "next_attempt_at", "last_error", "created_at", "modified_at"}
+	outboxPrimaryKeyColumns     = []string{"id"}
+	outboxGeneratedColumns      = []string{}
+)
+
+type (
+	// OutboxSlice is an alias for a slice of pointers to Outbox.
+	// This should almost always be used instead of []Outbox.
+	OutboxSlice []*Outbox
+	// OutboxHook is the signature for custom Outbox hook methods
+	OutboxHook func(context.Context, boil.ContextExecutor, *Outbox) error
+
+	outboxQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	outboxType                 = reflect.TypeOf(&Outbox{})
+	outboxMapping              = queries.MakeStructMapping(outboxType)
+	outboxPrimaryKeyMapping, _ = queries.BindMapping(outboxType, outboxMapping, outboxPrimaryKeyColumns)
+	outboxInsertCacheMut       sync.RWMutex
+	outboxInsertCache          = make(map[string]insertCache)
+	outboxUpdateCacheMut       sync.RWMutex
+	outboxUpdateCache          = make(map[string]updateCache)
+	outboxUpsertCacheMut       sync.RWMutex
+	outboxUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var outboxAfterSelectMu sync.Mutex
+var outboxAfterSelectHooks []OutboxHook
+
+var outboxBeforeInsertMu sync.Mutex
+var outboxBeforeInsertHooks []OutboxHook
+var outboxAfterInsertMu sync.Mutex
+var outboxAfterInsertHooks []OutboxHook
+
+var outboxBeforeUpdateMu sync.Mutex
+var outboxBeforeUpdateHooks []OutboxHook
+var outboxAfterUpdateMu sync.Mutex
+var outboxAfterUpdateHooks []OutboxHook
+
+var outboxBeforeDeleteMu sync.Mutex
+var outboxBeforeDeleteHooks []OutboxHook
+var outboxAfterDeleteMu sync.Mutex
+var outboxAfterDeleteHooks []OutboxHook
+
+var outboxBeforeUpsertMu sync.Mutex
+var outboxBeforeUpsertHooks []OutboxHook
+var outboxAfterUpsertMu sync.Mutex
+var outboxAfterUpsertHooks []OutboxHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *Outbox) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range outboxAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *Outbox) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range outboxBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *Outbox) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range outboxAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *Outbox) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range outboxBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *Outbox) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range outboxAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *Outbox) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range outboxBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *Outbox) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range outboxAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *Outbox) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range outboxBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *Outbox) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range outboxAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddOutboxHook registers your hook function for all future operations.
+func AddOutboxHook(hookPoint boil.HookPoint, outboxHook OutboxHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		outboxAfterSelectMu.Lock()
+		outboxAfterSelectHooks = append(outboxAfterSelectHooks, outboxHook)
+		outboxAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		outboxBeforeInsertMu.Lock()
+		outboxBeforeInsertHooks = append(outboxBeforeInsertHooks, outboxHook)
+		outboxBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		outboxAfterInsertMu.Lock()
+		outboxAfterInsertHooks = append(outboxAfterInsertHooks, outboxHook)
+		outboxAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		outboxBeforeUpdateMu.Lock()
+		outboxBeforeUpdateHooks = append(outboxBeforeUpdateHooks, outboxHook)
+		outboxBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		outboxAfterUpdateMu.Lock()
+		outboxAfterUpdateHooks = append(outboxAfterUpdateHooks, outboxHook)
+		outboxAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		outboxBeforeDeleteMu.Lock()
+		outboxBeforeDeleteHooks = append(outboxBeforeDeleteHooks, outboxHook)
+		outboxBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		outboxAfterDeleteMu.Lock()
+		outboxAfterDeleteHooks = append(outboxAfterDeleteHooks, outboxHook)
+		outboxAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		outboxBeforeUpsertMu.Lock()
+		outboxBeforeUpsertHooks = append(outboxBeforeUpsertHooks, outboxHook)
+		outboxBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		outboxAfterUpsertMu.Lock()
+		outboxAfterUpsertHooks = append(outboxAfterUpsertHooks, outboxHook)
+		outboxAfterUpsertMu.Unlock()
+	}
+}
+
+// OneG returns a single outbox record from the query using the global executor.
+func (q outboxQuery) OneG(ctx context.Context) (*Outbox, error) {
+	return q.One(ctx, boil.GetContextDB())
+}
+
+// One returns a single outbox record from the query.
+func (q outboxQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Outbox, error) {
+	o := &Outbox{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "appdb: failed to execute a one query for outbox")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// AllG returns all Outbox records from the query using the global executor.
+func (q outboxQuery) AllG(ctx context.Context) (OutboxSlice, error) {
+	return q.All(ctx, boil.GetContextDB())
+}
+
+// All returns all Outbox records from the query.
+func (q outboxQuery) All(ctx context.Context, exec boil.ContextExecutor) (OutboxSlice, error) {
+	var o []*Outbox
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "appdb: failed to assign all query results to Outbox slice")
+	}
+
+	if len(outboxAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// CountG returns the count of all Outbox records in the query using the global executor
+func (q outboxQuery) CountG(ctx context.Context) (int64, error) {
+	return q.Count(ctx, boil.GetContextDB())
+}
+
+// Count returns the count of all Outbox records in the query.
+func (q outboxQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to count outbox rows")
+	}
+
+	return count, nil
+}
+
+// ExistsG checks if the row exists in the table using the global executor.
+func (q outboxQuery) ExistsG(ctx context.Context) (bool, error) {
+	return q.Exists(ctx, boil.GetContextDB())
+}
+
+// Exists checks if the row exists in the table.
+func (q outboxQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "appdb: failed to check if outbox exists")
+	}
+
+	return count > 0, nil
+}
+
+// Configuration pointed to by the foreign key.
+func (o *Outbox) Configuration(mods ...qm.QueryMod) configurationQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.ConfigurationID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Configurations(queryMods...)
+}
+
+// LoadConfiguration allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (outboxL) LoadConfiguration(ctx context.Context, e boil.ContextExecutor, singular bool, maybeOutbox interface{}, mods queries.Applicator) error {
+	var slice []*Outbox
+	var object *Outbox
+
+	if singular {
+		var ok bool
+		object, ok = maybeOutbox.(*Outbox)
+		if !ok {
+			object = new(Outbox)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeOutbox)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeOutbox))
+			}
+		}
+	} else {
+		s, ok := maybeOutbox.(*[]*Outbox)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeOutbox)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeOutbox))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &outboxR{}
+		}
+		args[object.ConfigurationID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &outboxR{}
+			}
+
+			args[obj.ConfigurationID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`loriot_io.configuration`),
+		qm.WhereIn(`loriot_io.configuration.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Configuration")
+	}
+
+	var resultSlice []*Configuration
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Configuration")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for configuration")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for configuration")
+	}
+
+	if len(configurationAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Configuration = foreign
+		if foreign.R == nil {
+			foreign.R = &configurationR{}
+		}
+		foreign.R.Outboxes = append(foreign.R.Outboxes, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ConfigurationID == foreign.ID {
+				local.R.Configuration = foreign
+				if foreign.R == nil {
+					foreign.R = &configurationR{}
+				}
+				foreign.R.Outboxes = append(foreign.R.Outboxes, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetConfigurationG of the outbox to the related item.
+// Sets o.R.Configuration to related.
+// Adds o to related.R.Outboxes.
+// Uses the global database handle.
+func (o *Outbox) SetConfigurationG(ctx context.Context, insert bool, related *Configuration) error {
+	return o.SetConfiguration(ctx, boil.GetContextDB(), insert, related)
+}
+
+// SetConfiguration of the outbox to the related item.
+// Sets o.R.Configuration to related.
+// Adds o to related.R.Outboxes.
+func (o *Outbox) SetConfiguration(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Configuration) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"loriot_io\".\"outbox\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"configuration_id"}),
+		strmangle.WhereClause("\"", "\"", 2, outboxPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.ConfigurationID = related.ID
+	if o.R == nil {
+		o.R = &outboxR{
+			Configuration: related,
+		}
+	} else {
+		o.R.Configuration = related
+	}
+
+	if related.R == nil {
+		related.R = &configurationR{
+			Outboxes: OutboxSlice{o},
+		}
+	} else {
+		related.R.Outboxes = append(related.R.Outboxes, o)
+	}
+
+	return nil
+}
+
+// Outboxes retrieves all the records using an executor.
+func Outboxes(mods ...qm.QueryMod) outboxQuery {
+	mods = append(mods, qm.From("\"loriot_io\".\"outbox\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"loriot_io\".\"outbox\".*"})
+	}
+
+	return outboxQuery{q}
+}
+
+// FindOutboxG retrieves a single record by ID.
+func FindOutboxG(ctx context.Context, iD int64, selectCols ...string) (*Outbox, error) {
+	return FindOutbox(ctx, boil.GetContextDB(), iD, selectCols...)
+}
+
+// FindOutbox retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindOutbox(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*Outbox, error) {
+	outboxObj := &Outbox{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"loriot_io\".\"outbox\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, outboxObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "appdb: unable to select from outbox")
+	}
+
+	if err = outboxObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return outboxObj, err
+	}
+
+	return outboxObj, nil
+}
+
+// InsertG a single record. See Insert for whitelist behavior description.
+func (o *Outbox) InsertG(ctx context.Context, columns boil.Columns) error {
+	return o.Insert(ctx, boil.GetContextDB(), columns)
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Outbox) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("appdb: no outbox provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(outboxColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	outboxInsertCacheMut.RLock()
+	cache, cached := outboxInsertCache[key]
+	outboxInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			outboxAllColumns,
+			outboxColumnsWithDefault,
+			outboxColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(outboxType, outboxMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(outboxType, outboxMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"loriot_io\".\"outbox\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"loriot_io\".\"outbox\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to insert into outbox")
+	}
+
+	if !cached {
+		outboxInsertCacheMut.Lock()
+		outboxInsertCache[key] = cache
+		outboxInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// UpdateG a single Outbox record using the global executor.
+// See Update for more documentation.
+func (o *Outbox) UpdateG(ctx context.Context, columns boil.Columns) (int64, error) {
+	return o.Update(ctx, boil.GetContextDB(), columns)
+}
+
+// Update uses an executor to update the Outbox.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Outbox) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	outboxUpdateCacheMut.RLock()
+	cache, cached := outboxUpdateCache[key]
+	outboxUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			outboxAllColumns,
+			outboxPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("appdb: unable to update outbox, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"loriot_io\".\"outbox\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, outboxPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(outboxType, outboxMapping, append(wl, outboxPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update outbox row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by update for outbox")
+	}
+
+	if !cached {
+		outboxUpdateCacheMut.Lock()
+		outboxUpdateCache[key] = cache
+		outboxUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (q outboxQuery) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return q.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q outboxQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update all for outbox")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to retrieve rows affected for outbox")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (o OutboxSlice) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return o.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o OutboxSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("appdb: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), outboxPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"loriot_io\".\"outbox\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, outboxPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update all in outbox slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to retrieve rows affected all in update all outbox")
+	}
+	return rowsAff, nil
+}
+
+// UpsertG attempts an insert, and does an update or ignore on conflict.
+func (o *Outbox) UpsertG(ctx context.Context, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	return o.Upsert(ctx, boil.GetContextDB(), updateOnConflict, conflictColumns, updateColumns, insertColumns, opts...)
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Outbox) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("appdb: no outbox provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(outboxColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	outboxUpsertCacheMut.RLock()
+	cache, cached := outboxUpsertCache[key]
+	outboxUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			outboxAllColumns,
+			outboxColumnsWithDefault,
+			outboxColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			outboxAllColumns,
+			outboxPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("appdb: unable to upsert outbox, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(outboxAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(outboxPrimaryKeyColumns) == 0 {
+				return errors.New("appdb: unable to upsert outbox, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(outboxPrimaryKeyColumns))
+			copy(conflict, outboxPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"loriot_io\".\"outbox\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(outboxType, outboxMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(outboxType, outboxMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to upsert outbox")
+	}
+
+	if !cached {
+		outboxUpsertCacheMut.Lock()
+		outboxUpsertCache[key] = cache
+		outboxUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// DeleteG deletes a single Outbox record.
+// DeleteG will match against the primary key column to find the record to delete.
+func (o *Outbox) DeleteG(ctx context.Context) (int64, error) {
+	return o.Delete(ctx, boil.GetContextDB())
+}
+
+// Delete deletes a single Outbox record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Outbox) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("appdb: no Outbox provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), outboxPrimaryKeyMapping)
+	sql := "DELETE FROM \"loriot_io\".\"outbox\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete from outbox")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by delete for outbox")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+func (q outboxQuery) DeleteAllG(ctx context.Context) (int64, error) {
+	return q.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all matching rows.
+func (q outboxQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("appdb: no outboxQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete all from outbox")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by deleteall for outbox")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAllG deletes all rows in the slice.
+func (o OutboxSlice) DeleteAllG(ctx context.Context) (int64, error) {
+	return o.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o OutboxSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(outboxBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), outboxPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"loriot_io\".\"outbox\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, outboxPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete all from outbox slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by deleteall for outbox")
+	}
+
+	if len(outboxAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// ReloadG refetches the object from the database using the primary keys.
+func (o *Outbox) ReloadG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("appdb: no Outbox provided for reload")
+	}
+
+	return o.Reload(ctx, boil.GetContextDB())
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Outbox) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindOutbox(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAllG refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *OutboxSlice) ReloadAllG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("appdb: empty OutboxSlice provided for reload all")
+	}
+
+	return o.ReloadAll(ctx, boil.GetContextDB())
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *OutboxSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := OutboxSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), outboxPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"loriot_io\".\"outbox\".* FROM \"loriot_io\".\"outbox\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, outboxPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to reload all in OutboxSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// OutboxExistsG checks if the Outbox row exists.
+func OutboxExistsG(ctx context.Context, iD int64) (bool, error) {
+	return OutboxExists(ctx, boil.GetContextDB(), iD)
+}
+
+// OutboxExists checks if the Outbox row exists.
+func OutboxExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"loriot_io\".\"outbox\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "appdb: unable to check if outbox exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the Outbox row exists.
+func (o *Outbox) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return OutboxExists(ctx, exec, o.ID)
+}
@@ -21,6 +21,7 @@ import (
 	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
 	"loriot-io/apiserver"
 	"loriot-io/app"
+	"loriot-io/appdb"
 	"loriot-io/eliona"
 	"loriot-io/loriot"
 	"net/http"
@@ -30,12 +31,16 @@ import (
 	"github.com/eliona-smart-building-assistant/go-utils/log"
 )
 
+// ListenForAssetChanges performs the actions in Loriot.io triggered by asset changes in Eliona. Changes missed while
+// the websocket was not connected are caught up on every (re)connect.
 func ListenForAssetChanges() {
 	ctx := context.Background()
 	for {
 
 		// Listen for asset changes in Eliona
-		assetListens, err := eliona.ListenForAssetChanges()
+		assetListens, err := eliona.ListenForAssetChanges(func() {
+			catchUpAssetChanges(ctx)
+		})
 		if err != nil {
 			log.Error("eliona", "listening for asset changes: %v", err)
 			continue
@@ -44,112 +49,171 @@ func ListenForAssetChanges() {
 
 		for assetListen := range assetListens {
 			asset, statusCode := eliona.AssetFromAssetListen(assetListen)
+			handleAssetChange(ctx, asset, statusCode)
+		}
+		log.Warn("Eliona", "Websocket connection broke. Restarting in 5 seconds.")
+		time.Sleep(time.Second * 5) // Give the server a little break.
+	}
+}
+
+// handleAssetChange performs the action (recreate, delete, update) for an asset changed in Eliona with the given
+// status code.
+func handleAssetChange(ctx context.Context, asset api.Asset, statusCode int32) {
+	// Try to get apps information about asset device
+	dbAssetDevice, err := app.GetDbDeviceAssetById(asset.Id.Get())
+	if err != nil {
+		log.Error("eliona", "Error selecting device asset: %v", err)
+	}
+
+	// Try to get device EUI. If not defined (e.g. after archiving in frontend) use the app data to find the device EUI
+	devEUI := loriot.GetDeviceEUI(asset)
+	if devEUI == nil && dbAssetDevice != nil && loriot.IsValidEUI(&dbAssetDevice.DevEui) {
+		asset.DeviceIds = []string{
+			dbAssetDevice.DevEui,
+		}
+		devEUI = common.Ptr(dbAssetDevice.DevEui)
+	}
+
+	// Perform the action (recreate, delete, update) triggert by Eliona for each config
+	if devEUI != nil {
+		log.Info("eliona", "Asset %v changed: %d", asset.Id, statusCode)
 
-			// Try to get apps information about asset device
-			dbAssetDevice, err := app.GetDbDeviceAssetById(asset.Id.Get())
-			if err != nil {
-				log.Error("eliona", "Error selecting device asset: %v", err)
+		configs, err := app.GetConfigs(ctx)
+		if err != nil {
+			log.Error("eliona", "Error getting configs: %v", err)
+			return
+		}
+		for _, config := range configs {
+			if !app.IsConfigEnabled(config) {
+				continue
 			}
 
-			// Try to get device EUI. If not defined (e.g. after archiving in frontend) use the app data to find the device EUI
-			devEUI := loriot.GetDeviceEUI(asset)
-			if devEUI == nil && dbAssetDevice != nil && loriot.IsValidEUI(&dbAssetDevice.DevEui) {
-				asset.DeviceIds = []string{
-					dbAssetDevice.DevEui,
-				}
-				devEUI = common.Ptr(dbAssetDevice.DevEui)
+			// check if the device is routed to the project of this asset
+			if !sliceContains(assetRoute(config, asset, dbAssetDevice, *devEUI), asset.ProjectId) {
+				log.Info("asset", "Modified asset with project ID %s doesn't matches project IDs from configuration %d", asset.ProjectId, *config.Id)
+				continue
 			}
 
-			// Perform the action (recreate, delete, update) triggert by Eliona for each config
-			if devEUI != nil {
-				log.Info("eliona", "Asset %v changed: %d", asset.Id, statusCode)
+			// Perform creation action.
+			if statusCode == http.StatusCreated {
+				// at the moment no further action must be performed if an asset is recreated
+				app.NotifyUser(config.UserId, &asset.ProjectId, &api.Translation{
+					De: api.PtrString(fmt.Sprintf("Loriot App hat Gerät '%s' und Asset '%d' angelegt.", *devEUI, *asset.Id.Get())),
+					En: api.PtrString(fmt.Sprintf("Loriot app created device '%s' and asset '%d'.", *devEUI, *asset.Id.Get())),
+				})
+				continue
+			}
 
-				configs, err := app.GetConfigs(ctx)
-				if err != nil {
-					log.Error("eliona", "Error getting configs: %v", err)
+			// Queue update and delete actions. They are performed in Loriot.io by the outbox worker.
+			operation := app.OutboxOperationUpdate
+			if statusCode == http.StatusNoContent {
+				if asset.Id.Get() != nil && isAssetCompensated(*asset.Id.Get()) {
+					log.Debug("eliona", "Asset %d was deleted by a rollback, keeping device %s", *asset.Id.Get(), *devEUI)
 					continue
 				}
-				for _, config := range configs {
-					if !app.IsConfigEnabled(config) {
-						continue
-					}
-
-					// check if project is defined for this asset
-					if !sliceContains(app.ProjIds(config), asset.ProjectId) {
-						log.Info("asset", "Modified asset with project ID %s doesn't matches project IDs from configuration %d", asset.ProjectId, config.Id)
-						continue
-					}
-
-					// Perform the action (recreate, delete, update)
-					var device *loriot.Device
-					var err error
-
-					// Perform creation action.
-					if statusCode == http.StatusCreated {
-						// at the moment no further action must be performed if an asset is recreated
-						app.NotifyUser(config.UserId, &asset.ProjectId, &api.Translation{
-							De: api.PtrString(fmt.Sprintf("Loriot App hat Gerät '%s' und Asset '%d' angelegt.", *devEUI, *asset.Id.Get())),
-							En: api.PtrString(fmt.Sprintf("Loriot app created device '%s' and asset '%d'.", *devEUI, *asset.Id.Get())),
-						})
-					}
-
-					// Perform update action.
-					if statusCode == http.StatusOK {
-						device, err = loriot.UpdateDevice(ctx, config, *devEUI, asset)
-						if err == nil {
-							app.NotifyUser(config.UserId, &asset.ProjectId, &api.Translation{
-								De: api.PtrString(fmt.Sprintf("Loriot App hat Gerät '%s' und Asset '%d' geändert.", *devEUI, *asset.Id.Get())),
-								En: api.PtrString(fmt.Sprintf("Loriot app updated device '%s' and asset '%d'.", *devEUI, *asset.Id.Get())),
-							})
-						}
-					}
-
-					// Perform delete action. Perform is always possible.
-					if statusCode == http.StatusNoContent {
-						device, err = loriot.DeleteDevice(ctx, config, *devEUI)
-						if err == nil {
-							app.NotifyUser(config.UserId, &asset.ProjectId, &api.Translation{
-								De: api.PtrString(fmt.Sprintf("Loriot App hat Gerät '%s' und Asset '%d' gelöscht.", *devEUI, *asset.Id.Get())),
-								En: api.PtrString(fmt.Sprintf("Loriot app deleted device '%s' and asset '%d'.", *devEUI, *asset.Id.Get())),
-							})
-						}
-					}
-					if err != nil {
-						log.Error("loriot", "Error perform operation %d for device %s: %v", statusCode, *devEUI, err)
-						continue
-					}
-					if device == nil {
-						log.Warn("loriot", "Device %s for operation %d not found. Changes from Eliona are ignored", *devEUI, statusCode)
-						continue
-					}
-					log.Info("loriot", "Device %s operation %d successfully performed.", *devEUI, statusCode)
-					_, err = app.UpsertDeviceAsset(ctx, config, *device, asset, statusCode)
-					if err != nil {
-						log.Error("app", "Error updating app's device database for operation %d for device %s: %v", statusCode, *devEUI, err)
-					}
-				}
+				operation = app.OutboxOperationDelete
+			} else if statusCode != http.StatusOK {
+				continue
 			}
+			if _, err := app.InsertOutboxOperation(ctx, *config.Id, *devEUI, operation, asset); err != nil {
+				log.Error("app", "Error queueing operation %d for device %s: %v", statusCode, *devEUI, err)
+			}
+		}
+	}
+}
 
+// assetRoute returns the projects the device of a changed asset is routed to. The application and the title of the
+// device before the change are only known if the app created the asset.
+func assetRoute(config apiserver.Configuration, asset api.Asset, dbAssetDevice *appdb.Asset, devEUI string) []string {
+	var appID, title string
+	if asset.Name.Get() != nil {
+		title = *asset.Name.Get()
+	}
+	if dbAssetDevice != nil {
+		appID = dbAssetDevice.AppID
+		if dbAssetDevice.AssetName.Valid {
+			title = dbAssetDevice.AssetName.String
 		}
-		log.Warn("Eliona", "Websocket connection broke. Restarting in 5 seconds.")
-		time.Sleep(time.Second * 5) // Give the server a little break.
 	}
+	projectIDs, _, _ := app.RouteDevice(config, appID, devEUI, title)
+	return projectIDs
 }
 
-func UpsertDevice(ctx context.Context, putDeviceRequest apiserver.PutDeviceRequest) ([]apiserver.DeviceAsset, error) {
+// UpsertDevice creates or updates the device in Loriot.io and its assets in Eliona and the app. If a step fails, all
+// steps already applied are compensated in reverse order. The keys of the device, generated if requested, are stored
+// in the key vault. Keys are only accepted if the device is created, as Loriot.io keeps the keys of existing devices.
+// Returns the outcome of all steps performed.
+func UpsertDevice(ctx context.Context, putDeviceRequest apiserver.PutDeviceRequest) ([]apiserver.DeviceAsset, []apiserver.PutDeviceStep, error) {
+	configs, err := targetConfigs(ctx, putDeviceRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+	if putDeviceRequest.GenerateKeys {
+		if err := generateDeviceKeys(ctx, configs, &putDeviceRequest); err != nil {
+			return nil, nil, err
+		}
+	}
 	if !loriot.IsValidEUI(&putDeviceRequest.DevEUI) {
-		return nil, fmt.Errorf("invalid device EUI: %s", putDeviceRequest.DevEUI)
+		return nil, nil, fmt.Errorf("%w: invalid device EUI: %s", app.ErrBadRequest, putDeviceRequest.DevEUI)
+	}
+	routes, err := routeDevice(configs, putDeviceRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := rejectKeysOfExistingDevice(ctx, routes, putDeviceRequest); err != nil {
+		return nil, nil, err
 	}
 	var deviceAssets []apiserver.DeviceAsset
 
 	// For all configs update device and asset
-	configs, err := app.GetConfigs(ctx)
-	if len(configs) == 0 {
-		return nil, fmt.Errorf("no configuration found")
+	s := &saga{}
+	for _, route := range routes {
+		configDeviceAssets, err := upsertConfigDevice(ctx, s, route.config, route.projectIDs, putDeviceRequest)
+		if err != nil {
+			log.Error("loriot", "Error upserting device %s, rolling back: %v", putDeviceRequest.DevEUI, err)
+			s.rollback()
+			return nil, s.results(), err
+		}
+		deviceAssets = append(deviceAssets, configDeviceAssets...)
+	}
+	if deviceAssets != nil {
+		if err := storeDeviceKeys(ctx, s, putDeviceRequest); err != nil {
+			log.Error("app", "Error storing keys of device %s, rolling back: %v", putDeviceRequest.DevEUI, err)
+			s.rollback()
+			return nil, s.results(), err
+		}
+	}
+	return deviceAssets, s.results(), nil
+}
+
+// rejectKeysOfExistingDevice checks before anything is changed that the device doesn't exist in Loriot.io if the
+// request contains keys. Loriot.io keeps the keys of existing devices, so the keys would never reach the device.
+func rejectKeysOfExistingDevice(ctx context.Context, routes []deviceRoute, putDeviceRequest apiserver.PutDeviceRequest) error {
+	if !hasDeviceKeys(deviceKeys(putDeviceRequest)) {
+		return nil
+	}
+	for _, route := range routes {
+		device, err := loriot.GetDevice(ctx, route.config, putDeviceRequest.AppID, putDeviceRequest.DevEUI)
+		if err != nil {
+			return err
+		}
+		if device != nil {
+			return fmt.Errorf("%w: device %s already exists in Loriot.io, its keys cannot be changed", app.ErrConflict, putDeviceRequest.DevEUI)
+		}
 	}
+	return nil
+}
+
+// targetConfigs returns the enabled configurations the device is created or updated in.
+func targetConfigs(ctx context.Context, putDeviceRequest apiserver.PutDeviceRequest) ([]apiserver.Configuration, error) {
+	configs, err := app.GetConfigs(ctx)
 	if err != nil {
-		return deviceAssets, err
+		return nil, err
+	}
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("%w: no configuration found", app.ErrBadRequest)
 	}
+	var targets []apiserver.Configuration
 	for _, config := range configs {
 		if !app.IsConfigEnabled(config) {
 			continue
@@ -158,36 +222,146 @@ func UpsertDevice(ctx context.Context, putDeviceRequest apiserver.PutDeviceReque
 		if putDeviceRequest.ConfigID != nil && config.Id != nil && int64(*putDeviceRequest.ConfigID) != *config.Id {
 			continue
 		}
+		targets = append(targets, config)
+	}
+	return targets, nil
+}
 
-		// Upsert device
-		device, err := loriot.UpsertDevice(ctx, config, putDeviceRequest)
-		if err != nil {
-			return deviceAssets, err
-		}
-		if device == nil {
+// deviceRoute holds the projects of a configuration a device is routed to.
+type deviceRoute struct {
+	config     apiserver.Configuration
+	projectIDs []string
+}
+
+// routeDevice returns the projects of each configuration the device is routed to by the routing rules. Configurations
+// not routing the device are skipped, unless the request names the configuration.
+func routeDevice(configs []apiserver.Configuration, putDeviceRequest apiserver.PutDeviceRequest) ([]deviceRoute, error) {
+	var routes []deviceRoute
+	for _, config := range configs {
+		projectIDs, _, routed := app.RouteDevice(config, putDeviceRequest.AppID, putDeviceRequest.DevEUI, putDeviceRequest.Title)
+		if !routed {
+			if putDeviceRequest.ConfigID != nil {
+				return nil, fmt.Errorf("%w: device %s matches no routing rule of configuration %d", app.ErrBadRequest, putDeviceRequest.DevEUI, *config.Id)
+			}
 			continue
 		}
+		routes = append(routes, deviceRoute{config: config, projectIDs: projectIDs})
+	}
+	return routes, nil
+}
 
-		// For all project IDs upserts the corresponding asset
-		for _, projectID := range app.ProjIds(config) {
+// upsertConfigDevice performs the steps of upserting the device for one configuration and records them in the saga.
+// Assets are upserted in the given projects of the configuration.
+func upsertConfigDevice(ctx context.Context, s *saga, config apiserver.Configuration, projectIDs []string, putDeviceRequest apiserver.PutDeviceRequest) ([]apiserver.DeviceAsset, error) {
+	var deviceAssets []apiserver.DeviceAsset
+	devEUI := putDeviceRequest.DevEUI
 
-			asset, err := eliona.UpsertAssetWithPutDeviceRequest(ctx, projectID, putDeviceRequest)
-			if err != nil {
-				return deviceAssets, err
-			}
-			if asset == nil {
-				continue
-			}
+	// Upsert device
+	step := apiserver.PutDeviceStep{ConfigID: config.Id, Step: StepLoriotDevice}
+	device, previousDevice, err := loriot.UpsertDevice(ctx, config, putDeviceRequest)
+	if err != nil {
+		s.failed(step, err)
+		return nil, err
+	}
+	if device == nil {
+		return nil, nil
+	}
+	if previousDevice == nil {
+		step.Status = StepCreated
+		s.applied(step, func() error {
+			_, err := loriot.DeleteDevice(ctx, config, devEUI)
+			return err
+		})
+	} else {
+		step.Status = StepUpdated
+		s.applied(step, func() error {
+			return loriot.RestoreDevice(ctx, config, *previousDevice)
+		})
+		// The device was created concurrently after rejectKeysOfExistingDevice checked it
+		if hasDeviceKeys(deviceKeys(putDeviceRequest)) {
+			return nil, fmt.Errorf("%w: device %s already exists in Loriot.io, its keys cannot be changed", app.ErrConflict, devEUI)
+		}
+	}
 
-			// remember the asset info inside app
-			deviceAsset, err := app.UpsertDeviceAsset(ctx, config, *device, *asset, 201)
-			if err != nil {
-				return deviceAssets, err
-			}
-			if deviceAsset != nil {
-				deviceAssets = append(deviceAssets, *deviceAsset)
-			}
+	// For all project IDs upserts the corresponding asset
+	for _, projectID := range projectIDs {
+		projectID := projectID
+
+		step := apiserver.PutDeviceStep{ConfigID: config.Id, ProjectID: &projectID, Step: StepRootAsset}
+		rootAsset, created, err := eliona.UpsertRootAsset(projectID)
+		if err != nil {
+			s.failed(step, err)
+			return nil, err
+		}
+		step.AssetID = rootAsset.Id.Get()
+		if created {
+			step.Status = StepCreated
+			s.applied(step, func() error {
+				return eliona.DeleteAsset(*rootAsset.Id.Get())
+			})
+		} else {
+			step.Status = StepUnchanged
+			s.applied(step, nil)
+		}
 
+		step = apiserver.PutDeviceStep{ConfigID: config.Id, ProjectID: &projectID, Step: StepDeviceAsset}
+		previousAsset, err := eliona.GetAssetByDeviceId(projectID, devEUI)
+		if err != nil {
+			s.failed(step, err)
+			return nil, err
+		}
+		asset, err := eliona.UpsertAssetWithPutDeviceRequest(ctx, *rootAsset, putDeviceRequest)
+		if err != nil {
+			s.failed(step, err)
+			return nil, err
+		}
+		if asset == nil {
+			continue
+		}
+		if asset.Id.Get() == nil {
+			err := fmt.Errorf("no id returned for asset of device %s", devEUI)
+			s.failed(step, err)
+			return nil, err
+		}
+		step.AssetID = asset.Id.Get()
+		if previousAsset == nil {
+			step.Status = StepCreated
+			s.applied(step, func() error {
+				markAssetCompensated(*asset.Id.Get())
+				return eliona.DeleteAsset(*asset.Id.Get())
+			})
+		} else {
+			step.Status = StepUpdated
+			s.applied(step, func() error {
+				return eliona.RestoreAsset(*previousAsset)
+			})
+		}
+
+		// remember the asset info inside app
+		step = apiserver.PutDeviceStep{ConfigID: config.Id, ProjectID: &projectID, Step: StepAppAsset, AssetID: asset.Id.Get()}
+		previousDbAsset, err := app.GetDbDeviceAssetById(asset.Id.Get())
+		if err != nil {
+			s.failed(step, err)
+			return nil, err
+		}
+		deviceAsset, err := app.UpsertDeviceAsset(ctx, config, *device, *asset, 201, &putDeviceRequest)
+		if err != nil {
+			s.failed(step, err)
+			return nil, err
+		}
+		if previousDbAsset == nil {
+			step.Status = StepCreated
+			s.applied(step, func() error {
+				return app.DeleteDbDeviceAsset(ctx, *asset.Id.Get())
+			})
+		} else {
+			step.Status = StepUpdated
+			s.applied(step, func() error {
+				return app.RestoreDbDeviceAsset(ctx, previousDbAsset)
+			})
+		}
+		if deviceAsset != nil {
+			deviceAssets = append(deviceAssets, *deviceAsset)
 		}
 	}
 	return deviceAssets, nil
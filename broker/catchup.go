@@ -0,0 +1,158 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package broker
+
+import (
+	"context"
+	"loriot-io/app"
+	"loriot-io/appdb"
+	"loriot-io/eliona"
+	"net/http"
+	"sync"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// catchUpMutex prevents catch-ups of overlapping reconnects from replaying the same changes twice.
+var catchUpMutex sync.Mutex
+
+type assetChange struct {
+	asset      api.Asset
+	statusCode int32
+}
+
+// catchUpAssetChanges compares the Eliona assets of the configured projects with the device assets of the app and
+// replays the updates and deletions missed while the asset listener was not connected. Assets with pending or dead
+// operations in the outbox are skipped, their change is already queued.
+//
+// The Eliona API returns neither the modification time nor an archived flag of assets, so changes are detected by
+// the names, descriptions and existence of the assets. An archived asset missing in the asset list is caught up like a
+// deleted one. The modified_at of the device assets is used to skip device assets the app created or changed after
+// the Eliona assets were read.
+func catchUpAssetChanges(ctx context.Context) {
+	catchUpMutex.Lock()
+	defer catchUpMutex.Unlock()
+
+	configs, err := app.GetConfigs(ctx)
+	if err != nil {
+		log.Error("eliona", "Error getting configs: %v", err)
+		return
+	}
+	openAssetIDs, err := app.GetOpenOutboxAssetIDs(ctx)
+	if err != nil {
+		log.Error("app", "Error catching up asset changes: %v", err)
+		return
+	}
+	assetsByProject := make(map[string][]api.Asset)
+	readAt := make(map[string]time.Time)
+	var changes []assetChange
+	for _, config := range configs {
+		if !app.IsConfigEnabled(config) {
+			continue
+		}
+		// Read the device assets after the Eliona assets, so assets created in between aren't taken as deleted
+		for _, projectID := range app.ProjIds(config) {
+			if _, ok := assetsByProject[projectID]; ok {
+				continue
+			}
+			assets, err := eliona.GetProjectAssets(projectID)
+			if err != nil {
+				log.Error("eliona", "Error catching up asset changes: %v", err)
+				continue
+			}
+			assetsByProject[projectID] = assets
+			readAt[projectID] = time.Now()
+		}
+		dbAssets, err := app.GetActiveDbDeviceAssets(ctx, *config.Id)
+		if err != nil {
+			log.Error("app", "%v", err)
+			continue
+		}
+		for _, projectID := range app.ProjIds(config) {
+			assets, ok := assetsByProject[projectID]
+			if !ok {
+				continue
+			}
+			var projectDbAssets []*appdb.Asset
+			for _, dbAsset := range dbAssets {
+				if dbAsset.ProjectID == projectID && !openAssetIDs[dbAsset.AssetID] {
+					projectDbAssets = append(projectDbAssets, dbAsset)
+				}
+			}
+			rememberAssetNames(ctx, assets, projectDbAssets)
+			changes = append(changes, missedAssetChanges(assets, projectDbAssets, readAt[projectID])...)
+		}
+	}
+
+	for _, change := range changes {
+		log.Info("eliona", "Catching up missed change %d of asset %d", change.statusCode, *change.asset.Id.Get())
+		handleAssetChange(ctx, change.asset, change.statusCode)
+	}
+}
+
+// missedAssetChanges returns the changes of the Eliona assets of a project not yet performed for the device assets of
+// the app in that project: deletions of assets no longer existing and updates of renamed assets. Device assets
+// modified after the Eliona assets were read are skipped, as the assets may not reflect their state.
+func missedAssetChanges(assets []api.Asset, dbAssets []*appdb.Asset, readAt time.Time) []assetChange {
+	assetsByID := make(map[int32]api.Asset)
+	for _, asset := range assets {
+		if asset.Id.Get() != nil {
+			assetsByID[*asset.Id.Get()] = asset
+		}
+	}
+	var changes []assetChange
+	for _, dbAsset := range dbAssets {
+		if dbAsset.ModifiedAt.Valid && dbAsset.ModifiedAt.Time.After(readAt) {
+			continue
+		}
+		asset, exists := assetsByID[dbAsset.AssetID]
+		if !exists {
+			changes = append(changes, assetChange{
+				asset: api.Asset{
+					Id:                    *api.NewNullableInt32(common.Ptr(dbAsset.AssetID)),
+					DeviceIds:             []string{dbAsset.DevEui},
+					ProjectId:             dbAsset.ProjectID,
+					GlobalAssetIdentifier: dbAsset.GlobalAssetID,
+					AssetType:             dbAsset.AssetType.String,
+				},
+				statusCode: http.StatusNoContent,
+			})
+			continue
+		}
+		if app.DeviceAssetNamesChanged(dbAsset, asset) {
+			changes = append(changes, assetChange{asset: asset, statusCode: http.StatusOK})
+		}
+	}
+	return changes
+}
+
+// rememberAssetNames remembers the current names of assets whose names were never synchronized, so later renames can
+// be caught up.
+func rememberAssetNames(ctx context.Context, assets []api.Asset, dbAssets []*appdb.Asset) {
+	for _, asset := range assets {
+		for _, dbAsset := range dbAssets {
+			if asset.Id.Get() == nil || *asset.Id.Get() != dbAsset.AssetID || dbAsset.AssetName.Valid {
+				continue
+			}
+			if err := app.SetDeviceAssetNames(ctx, dbAsset, asset); err != nil {
+				log.Error("app", "%v", err)
+			}
+		}
+	}
+}
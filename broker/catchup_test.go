@@ -0,0 +1,43 @@
+package broker
+
+import (
+	"loriot-io/appdb"
+	"net/http"
+	"testing"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/volatiletech/null/v8"
+)
+
+func TestMissedAssetChanges(t *testing.T) {
+	readAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	asset := func(id int32, name string) api.Asset {
+		return api.Asset{Id: *api.NewNullableInt32(common.Ptr(id)), ProjectId: "10", Name: *api.NewNullableString(common.Ptr(name))}
+	}
+	assets := []api.Asset{
+		asset(1, "Unchanged"),
+		asset(2, "Renamed"),
+		asset(3, "Never synchronized"),
+	}
+	dbAssets := []*appdb.Asset{
+		{AssetID: 1, ProjectID: "10", DevEui: "BE7A000000000001", AssetName: null.StringFrom("Unchanged"), AssetDescription: null.StringFrom("")},
+		{AssetID: 2, ProjectID: "10", DevEui: "BE7A000000000002", AssetName: null.StringFrom("Old name"), AssetDescription: null.StringFrom("")},
+		{AssetID: 3, ProjectID: "10", DevEui: "BE7A000000000003"},
+		{AssetID: 4, ProjectID: "10", DevEui: "BE7A000000000004", AssetName: null.StringFrom("Deleted")},
+		{AssetID: 5, ProjectID: "10", DevEui: "BE7A000000000005", AssetName: null.StringFrom("Created later"), ModifiedAt: null.TimeFrom(readAt.Add(time.Second))},
+	}
+
+	changes := missedAssetChanges(assets, dbAssets, readAt)
+	if len(changes) != 2 {
+		t.Fatalf("missedAssetChanges() = %v", changes)
+	}
+	if id := *changes[0].asset.Id.Get(); id != 2 || changes[0].statusCode != http.StatusOK {
+		t.Errorf("missedAssetChanges() update = %d, %d", id, changes[0].statusCode)
+	}
+	deleted := changes[1].asset
+	if *deleted.Id.Get() != 4 || changes[1].statusCode != http.StatusNoContent || deleted.ProjectId != "10" || deleted.DeviceIds[0] != "BE7A000000000004" {
+		t.Errorf("missedAssetChanges() delete = %v, %d", deleted, changes[1].statusCode)
+	}
+}
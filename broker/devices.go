@@ -0,0 +1,156 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package broker
+
+import (
+	"context"
+	"fmt"
+	"loriot-io/apiserver"
+	"loriot-io/app"
+	"loriot-io/eliona"
+	"loriot-io/loriot"
+	"net/http"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// defaultRefreshInterval is used if the configuration defines no refresh interval.
+const defaultRefreshInterval = time.Minute
+
+// SyncDevices periodically fetches the devices and gateways of each enabled configuration from Loriot.io in the
+// refresh interval of the configuration. Assets are created for devices provisioned outside of Eliona and for all
+// gateways, and the radio and health metadata is written to the assets.
+func SyncDevices() {
+	newConfigWorkers("devices", syncConfigDevices).run()
+}
+
+func syncConfigDevices(ctx context.Context, config apiserver.Configuration) {
+	interval := time.Duration(config.RefreshInterval) * time.Second
+	if interval <= 0 {
+		interval = defaultRefreshInterval
+	}
+	for {
+		snapshot := time.Now()
+		devices, err := syncDevices(ctx, config)
+		if err != nil {
+			log.Error("loriot", "Error syncing devices for config %d: %v", *config.Id, err)
+		}
+		syncGateways(ctx, config, devices, err == nil)
+		if err == nil {
+			reportDrift(ctx, config, devices, snapshot)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// reportDrift logs the drift of the configuration, whatever its reconcile policy. Drift is only fixed by an explicit
+// reconcile, since the actions of the reconcile policies may delete devices and assets.
+func reportDrift(ctx context.Context, config apiserver.Configuration, devices []loriot.Device, snapshot time.Time) {
+	report, err := reconcile(ctx, config, devices, snapshot, false)
+	if err != nil {
+		log.Error("loriot", "Error detecting drift of config %d: %v", *config.Id, err)
+		return
+	}
+	if len(report.Items) > 0 {
+		log.Warn("loriot", "Found %d drift items for config %d, reconcile the configuration to fix them", len(report.Items), *config.Id)
+	}
+}
+
+// syncDevices discovers and writes the status of all devices of the configuration. Returns the devices of all
+// applications or an error if not all devices could be fetched.
+func syncDevices(ctx context.Context, config apiserver.Configuration) ([]loriot.Device, error) {
+	apps, err := loriot.GetApps(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("getting applications: %w", err)
+	}
+	var allDevices []loriot.Device
+	for _, loriotApp := range apps {
+		devices, err := loriot.GetDevices(ctx, config, loriotApp.AppHexID)
+		if err != nil {
+			return allDevices, fmt.Errorf("getting devices of application %s: %w", loriotApp.AppHexID, err)
+		}
+		for _, device := range devices {
+			if ctx.Err() != nil {
+				return allDevices, ctx.Err()
+			}
+			projectIDs, assetType, routed := app.RouteDevice(config, loriotApp.AppHexID, device.DevEUI, device.Title)
+			if routed && assetType != "" {
+				discoverDevice(ctx, config, device, projectIDs, assetType)
+			}
+			writeDeviceStatus(ctx, config, device)
+		}
+		allDevices = append(allDevices, devices...)
+	}
+	return allDevices, nil
+}
+
+// discoverDevice creates the assets for a device having a default asset type in the projects it is routed to. Devices
+// whose asset already exists or was deleted in Eliona are skipped.
+func discoverDevice(ctx context.Context, config apiserver.Configuration, device loriot.Device, projectIDs []string, assetType string) {
+	if !loriot.IsValidEUI(&device.DevEUI) {
+		log.Warn("loriot", "Ignoring discovered device with invalid EUI %s", device.DevEUI)
+		return
+	}
+	for _, projectID := range projectIDs {
+		exists, err := app.DeviceAssetExists(ctx, *config.Id, projectID, device.DevEUI)
+		if err != nil {
+			log.Error("app", "%v", err)
+			continue
+		}
+		if exists {
+			continue
+		}
+		asset, err := eliona.UpsertAssetWithDevice(ctx, projectID, device, assetType)
+		if err != nil {
+			log.Error("eliona", "Error creating asset for discovered device %s: %v", device.DevEUI, err)
+			continue
+		}
+		if asset == nil {
+			continue
+		}
+		if _, err := app.UpsertDeviceAsset(ctx, config, device, *asset, http.StatusCreated, nil); err != nil {
+			log.Error("app", "Error remembering asset for discovered device %s: %v", device.DevEUI, err)
+			continue
+		}
+		log.Info("loriot", "Discovered device %s and created asset %d in project %s", device.DevEUI, *asset.Id.Get(), projectID)
+	}
+}
+
+// writeDeviceStatus writes the radio and health metadata of the device to all its assets and watches whether the
+// device reports in its expected interval.
+func writeDeviceStatus(ctx context.Context, config apiserver.Configuration, device loriot.Device) {
+	dbAssets, err := app.GetDbDeviceAssetsByDevEUI(ctx, *config.Id, device.DevEUI)
+	if err != nil {
+		log.Error("app", "Error getting assets for device %s: %v", device.DevEUI, err)
+		return
+	}
+	for _, dbAsset := range dbAssets {
+		if dbAsset.AssetType.Valid {
+			if err := eliona.UpsertStatusAttributes(dbAsset.AssetType.String); err != nil {
+				log.Error("eliona", "Error adding status attributes for device %s: %v", device.DevEUI, err)
+			}
+		}
+		offline := watchDevice(ctx, config, device, dbAsset)
+		if err := eliona.UpsertStatusData(dbAsset.AssetID, device, offline); err != nil {
+			log.Error("eliona", "Error writing status of device %s: %v", device.DevEUI, err)
+		}
+	}
+}
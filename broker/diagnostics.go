@@ -0,0 +1,52 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package broker
+
+import (
+	"context"
+	"loriot-io/apiserver"
+	"loriot-io/app"
+	"loriot-io/loriot"
+
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// TestConfig tests the connection to the Loriot.io API of the stored configuration.
+func TestConfig(ctx context.Context, configID int64) (*apiserver.ConfigurationTestResult, error) {
+	config, err := app.GetConfig(ctx, configID)
+	if err != nil {
+		return nil, err
+	}
+	result := loriot.TestConnection(ctx, *config)
+	return &result, nil
+}
+
+// ValidateConfig tests the connection of an enabled configuration before it is saved. Returns the result of the test,
+// or nil if the configuration is disabled and isn't tested.
+func ValidateConfig(ctx context.Context, config apiserver.Configuration) (*apiserver.ConfigurationTestResult, error) {
+	if !app.IsConfigEnabled(config) {
+		return nil, nil
+	}
+	config, err := app.WithStoredToken(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	result := loriot.TestConnection(ctx, config)
+	if !result.Ok {
+		log.Info("loriot", "Configuration for %s failed validation", config.ApiBaseUrl)
+	}
+	return &result, nil
+}
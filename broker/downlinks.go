@@ -0,0 +1,292 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package broker
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"loriot-io/apiserver"
+	"loriot-io/app"
+	"loriot-io/appdb"
+	"loriot-io/decoder"
+	"loriot-io/eliona"
+	"loriot-io/loriot"
+	"net/http"
+	"sync"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+	"github.com/volatiletech/null/v8"
+)
+
+func init() {
+	// Codecs stored for asset types are used if no built-in encoder matches
+	decoder.RegisterEncoderProvider(app.CodecEncoder)
+}
+
+var (
+	errNoAppConnection      = errors.New("no connection to the application websocket")
+	errDownlinkQueueFlushed = errors.New("removed from the downlink queue")
+)
+
+var (
+	appConnectionsMutex sync.Mutex
+	appConnections      = make(map[int64]*loriot.AppConnection)
+)
+
+// setAppConnection remembers the open application websocket of the configuration to send downlinks through.
+func setAppConnection(configID int64, conn *loriot.AppConnection) {
+	appConnectionsMutex.Lock()
+	defer appConnectionsMutex.Unlock()
+	appConnections[configID] = conn
+}
+
+// removeAppConnection forgets the application websocket, if it is still the one used for the configuration.
+func removeAppConnection(configID int64, conn *loriot.AppConnection) {
+	appConnectionsMutex.Lock()
+	defer appConnectionsMutex.Unlock()
+	if appConnections[configID] == conn {
+		delete(appConnections, configID)
+	}
+}
+
+func getAppConnection(configID int64) *loriot.AppConnection {
+	appConnectionsMutex.Lock()
+	defer appConnectionsMutex.Unlock()
+	return appConnections[configID]
+}
+
+// ListenForOutputChanges sends changes of output attributes of device assets as downlinks to the devices.
+// The output data is encoded by the encoder of the device or, if not defined, of the asset type.
+func ListenForOutputChanges() {
+	ctx := context.Background()
+	for {
+		outputs, err := eliona.ListenForOutputData()
+		if err != nil {
+			log.Error("eliona", "listening for output data: %v", err)
+			continue
+		}
+		log.Debug("eliona", "Started output data listener")
+
+		for output := range outputs {
+			handleOutputData(ctx, output)
+		}
+		log.Warn("Eliona", "Output data listener broke. Restarting in 5 seconds.")
+		time.Sleep(time.Second * 5) // Give the server a little break.
+	}
+}
+
+func handleOutputData(ctx context.Context, output api.Data) {
+	if output.Subtype != api.SUBTYPE_OUTPUT || output.ClientReference.Get() != nil && *output.ClientReference.Get() == eliona.ClientReference {
+		return
+	}
+	dbAsset, err := app.GetDbDeviceAssetById(&output.AssetId)
+	if err != nil {
+		log.Error("app", "Error selecting device asset: %v", err)
+		return
+	}
+	if dbAsset == nil || dbAsset.LatestStatusCode.Int32 == http.StatusNoContent {
+		return
+	}
+	encoder, err := decoder.LookupEncoder(dbAsset.Decoder.String, dbAsset.AssetType.String)
+	if err != nil {
+		log.Error("decoder", "Error looking up encoder for device %s: %v", dbAsset.DevEui, err)
+		return
+	}
+	if encoder == nil {
+		log.Debug("decoder", "Ignoring output of device %s without encoder", dbAsset.DevEui)
+		return
+	}
+	downlink, err := encoder.Encode(output.Data)
+	if err != nil {
+		log.Warn("decoder", "Error encoding output of asset %d for device %s: %v", dbAsset.AssetID, dbAsset.DevEui, err)
+		return
+	}
+	if _, err := sendDownlink(ctx, dbAsset.ConfigurationID, &dbAsset.AssetID, dbAsset.DevEui, downlink); err != nil {
+		log.Error("loriot", "Error sending downlink to device %s: %v", dbAsset.DevEui, err)
+		return
+	}
+	log.Debug("loriot", "Downlink for asset %d sent to device %s", dbAsset.AssetID, dbAsset.DevEui)
+}
+
+// sendDownlink enqueues the downlink via the application websocket of the configuration and records it.
+func sendDownlink(ctx context.Context, configID int64, assetID *int32, devEUI string, downlink decoder.Downlink) (*appdb.Downlink, error) {
+	dbDownlink, err := app.InsertDownlink(ctx, appdb.Downlink{
+		ConfigurationID: configID,
+		AssetID:         null.Int32FromPtr(assetID),
+		DevEui:          devEUI,
+		Port:            int32(downlink.Port),
+		Confirmed:       downlink.Confirmed,
+		Payload:         hex.EncodeToString(downlink.Payload),
+		Status:          app.DownlinkStatusQueued,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sendErr := errNoAppConnection
+	if conn := getAppConnection(configID); conn != nil {
+		sendErr = conn.Send(loriot.NewDownlinkCommand(devEUI, downlink.Port, downlink.Confirmed, downlink.Payload))
+	}
+	if sendErr != nil {
+		if err := app.SetDownlinkStatus(ctx, dbDownlink, app.DownlinkStatusFailed, sendErr); err != nil {
+			log.Error("app", "%v", err)
+		}
+		return dbDownlink, sendErr
+	}
+	return dbDownlink, nil
+}
+
+// handleDownlinkMessage tracks the status of downlinks by the answers of the application websocket.
+func handleDownlinkMessage(ctx context.Context, configID int64, message loriot.Message) {
+	var err error
+	switch {
+	case message.Cmd == loriot.CmdDownlink && message.Error != "":
+		_, err = app.AdvanceDownlinkStatus(ctx, configID, message.EUI, []string{app.DownlinkStatusQueued}, app.DownlinkStatusFailed, errors.New(message.Error))
+	case message.Cmd == loriot.CmdDownlinkSent:
+		_, err = app.AdvanceDownlinkStatus(ctx, configID, message.EUI, []string{app.DownlinkStatusQueued}, app.DownlinkStatusSent, nil)
+	case message.IsUplink() && message.Ack:
+		_, err = app.AcknowledgeDownlink(ctx, configID, message.EUI)
+	}
+	if err != nil {
+		log.Error("app", "Error updating downlink status of device %s: %v", message.EUI, err)
+	}
+}
+
+// deviceQueueTarget returns the asset and configuration of the device to access its Loriot downlink queue with.
+func deviceQueueTarget(ctx context.Context, devEUI string) (*appdb.Asset, *apiserver.Configuration, error) {
+	if !loriot.IsValidEUI(&devEUI) {
+		return nil, nil, fmt.Errorf("%w: invalid device EUI: %s", app.ErrBadRequest, devEUI)
+	}
+	dbAsset, err := app.GetDbDeviceAssetByDevEUI(ctx, devEUI)
+	if err != nil {
+		return nil, nil, err
+	}
+	if dbAsset == nil {
+		return nil, nil, fmt.Errorf("%w: unknown device %s", app.ErrBadRequest, devEUI)
+	}
+	config, err := app.GetConfig(ctx, dbAsset.ConfigurationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !app.IsConfigEnabled(*config) {
+		return nil, nil, fmt.Errorf("%w: configuration %d of device %s is disabled", app.ErrBadRequest, dbAsset.ConfigurationID, devEUI)
+	}
+	return dbAsset, config, nil
+}
+
+// GetDeviceDownlinkQueue returns the downlinks waiting in the Loriot downlink queue of the device.
+func GetDeviceDownlinkQueue(ctx context.Context, devEUI string) ([]apiserver.QueuedDownlink, error) {
+	dbAsset, config, err := deviceQueueTarget(ctx, devEUI)
+	if err != nil {
+		return nil, err
+	}
+	queue, err := loriot.GetDownlinkQueue(ctx, *config, dbAsset.AppID, devEUI)
+	if err != nil {
+		return nil, fmt.Errorf("error getting downlink queue of device %s: %w", devEUI, err)
+	}
+	queuedDownlinks := []apiserver.QueuedDownlink{}
+	for _, queued := range queue {
+		queuedDownlinks = append(queuedDownlinks, apiserver.QueuedDownlink{
+			FPort:     int32(queued.Port),
+			Confirmed: queued.Confirmed,
+			Payload:   queued.Data,
+		})
+	}
+	return queuedDownlinks, nil
+}
+
+// EnqueueDeviceDownlink adds the downlink to the Loriot downlink queue of the device and records it. The downlink is
+// either given as hex payload or as data encoded by the encoder of the device or its asset type.
+func EnqueueDeviceDownlink(ctx context.Context, devEUI string, newDownlink apiserver.NewDownlink) (*apiserver.Downlink, error) {
+	dbAsset, config, err := deviceQueueTarget(ctx, devEUI)
+	if err != nil {
+		return nil, err
+	}
+	downlink, err := downlinkFromNewDownlink(dbAsset, newDownlink)
+	if err != nil {
+		return nil, err
+	}
+	dbDownlink, err := app.InsertDownlink(ctx, appdb.Downlink{
+		ConfigurationID: dbAsset.ConfigurationID,
+		AssetID:         null.Int32From(dbAsset.AssetID),
+		DevEui:          devEUI,
+		Port:            int32(downlink.Port),
+		Confirmed:       downlink.Confirmed,
+		Payload:         hex.EncodeToString(downlink.Payload),
+		Status:          app.DownlinkStatusQueued,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := loriot.EnqueueDownlink(ctx, *config, dbAsset.AppID, devEUI, downlink.Port, downlink.Confirmed, downlink.Payload); err != nil {
+		if err := app.SetDownlinkStatus(ctx, dbDownlink, app.DownlinkStatusFailed, err); err != nil {
+			log.Error("app", "%v", err)
+		}
+		return nil, fmt.Errorf("error enqueueing downlink for device %s: %w", devEUI, err)
+	}
+	return common.Ptr(app.ApiDownlinkFromDbDownlink(dbDownlink)), nil
+}
+
+func downlinkFromNewDownlink(dbAsset *appdb.Asset, newDownlink apiserver.NewDownlink) (decoder.Downlink, error) {
+	if (newDownlink.Payload == nil) == (newDownlink.Data == nil) {
+		return decoder.Downlink{}, fmt.Errorf("%w: either payload or data is required", app.ErrBadRequest)
+	}
+	if newDownlink.Payload != nil {
+		if newDownlink.FPort == nil {
+			return decoder.Downlink{}, fmt.Errorf("%w: fPort is required for raw payloads", app.ErrBadRequest)
+		}
+		payload, err := hex.DecodeString(*newDownlink.Payload)
+		if err != nil {
+			return decoder.Downlink{}, fmt.Errorf("%w: invalid hex payload: %v", app.ErrBadRequest, err)
+		}
+		return decoder.Downlink{Port: int(*newDownlink.FPort), Payload: payload, Confirmed: newDownlink.Confirmed}, nil
+	}
+
+	encoder, err := decoder.LookupEncoder(dbAsset.Decoder.String, dbAsset.AssetType.String)
+	if err != nil {
+		return decoder.Downlink{}, err
+	}
+	if encoder == nil {
+		return decoder.Downlink{}, fmt.Errorf("%w: no encoder for device %s", app.ErrBadRequest, dbAsset.DevEui)
+	}
+	downlink, err := encoder.Encode(newDownlink.Data)
+	if err != nil {
+		return decoder.Downlink{}, fmt.Errorf("%w: encoding data: %v", app.ErrBadRequest, err)
+	}
+	if newDownlink.FPort != nil {
+		downlink.Port = int(*newDownlink.FPort)
+	}
+	downlink.Confirmed = downlink.Confirmed || newDownlink.Confirmed
+	return downlink, nil
+}
+
+// FlushDeviceDownlinkQueue removes all downlinks from the Loriot downlink queue of the device. Recorded downlinks
+// still waiting in the queue are marked as failed.
+func FlushDeviceDownlinkQueue(ctx context.Context, devEUI string) error {
+	dbAsset, config, err := deviceQueueTarget(ctx, devEUI)
+	if err != nil {
+		return err
+	}
+	if err := loriot.FlushDownlinkQueue(ctx, *config, dbAsset.AppID, devEUI); err != nil {
+		return fmt.Errorf("error flushing downlink queue of device %s: %w", devEUI, err)
+	}
+	return app.DiscardQueuedDownlinks(ctx, dbAsset.ConfigurationID, devEUI, errDownlinkQueueFlushed)
+}
@@ -0,0 +1,338 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package broker
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"loriot-io/apiserver"
+	"loriot-io/app"
+	"loriot-io/eliona"
+	"loriot-io/loriot"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+const (
+	ExportFormatCsv  = "csv"
+	ExportFormatJson = "json"
+)
+
+// DeviceExportFilter restricts the exported devices. Empty fields match all devices.
+type DeviceExportFilter struct {
+	ConfigID    int64
+	ProjectID   string
+	AppID       string
+	IncludeKeys bool
+}
+
+// deviceExportColumns are the columns of the CSV export, named like the properties of the JSON export.
+var deviceExportColumns = []string{
+	"configID", "projectID", "appID", "devEUI", "assetID", "globalAssetIdentifier", "assetName", "assetTypeName",
+	"connectionState", "lastUplinkAt", "inLoriot", "title", "devAddr", "lastSeen", "bat", "appEUI", "appKey",
+	"nwkKey", "nwkSKey", "appSKey", "fNwkSIntKey", "sNwkSIntKey", "nwkSEncKey",
+}
+
+// exportPageSize is the number of devices after which the export is flushed to the client.
+const exportPageSize = 100
+
+// ExportDevicesJson writes the devices matching the filter as JSON array, joined with their Eliona asset and their
+// live state in Loriot.io. The devices are flushed to w page by page.
+func ExportDevicesJson(ctx context.Context, filter DeviceExportFilter, w io.Writer) error {
+	buffer := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buffer)
+	if _, err := buffer.WriteString("["); err != nil {
+		return fmt.Errorf("writing export: %w", err)
+	}
+	count := 0
+	err := exportDevices(ctx, filter, func(device apiserver.DeviceExport) error {
+		if count > 0 {
+			if _, err := buffer.WriteString(","); err != nil {
+				return err
+			}
+		}
+		if err := encoder.Encode(device); err != nil {
+			return err
+		}
+		count++
+		if count%exportPageSize == 0 {
+			return flushExport(w, buffer.Flush)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := buffer.WriteString("]\n"); err != nil {
+		return fmt.Errorf("writing export: %w", err)
+	}
+	return flushExport(w, buffer.Flush)
+}
+
+// ExportDevicesCsv writes the devices matching the filter as CSV, one row per device. The rows are flushed to w page
+// by page.
+func ExportDevicesCsv(ctx context.Context, filter DeviceExportFilter, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	flush := func() error {
+		writer.Flush()
+		return writer.Error()
+	}
+	if err := writer.Write(deviceExportColumns); err != nil {
+		return fmt.Errorf("writing export header: %w", err)
+	}
+	count := 0
+	err := exportDevices(ctx, filter, func(device apiserver.DeviceExport) error {
+		if err := writer.Write(deviceExportRecord(device)); err != nil {
+			return err
+		}
+		count++
+		if count%exportPageSize == 0 {
+			return flushExport(w, flush)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return flushExport(w, flush)
+}
+
+// flushExport flushes the buffered export to w and, if w is an HTTP response, on to the client.
+func flushExport(w io.Writer, flush func() error) error {
+	if err := flush(); err != nil {
+		return fmt.Errorf("flushing export: %w", err)
+	}
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// exportDevices calls export for each device asset matching the filter. Eliona and Loriot.io are queried once per
+// project and application.
+func exportDevices(ctx context.Context, filter DeviceExportFilter, export func(apiserver.DeviceExport) error) error {
+	configs, err := app.GetConfigs(ctx)
+	if err != nil {
+		return err
+	}
+	configsByID := make(map[int64]apiserver.Configuration)
+	for _, config := range configs {
+		if config.Id != nil {
+			configsByID[*config.Id] = config
+		}
+	}
+	dbAssets, err := app.GetExportDbDeviceAssets(ctx, filter.ConfigID, filter.ProjectID, filter.AppID)
+	if err != nil {
+		return err
+	}
+
+	projectAssets := make(map[string]map[int32]api.Asset)
+	appDevices := make(map[string]map[string]loriot.Device)
+	revealedKeys := make(map[string]*apiserver.DeviceKeys)
+	for _, dbAsset := range dbAssets {
+		device := apiserver.DeviceExport{
+			ConfigID:              dbAsset.ConfigurationID,
+			ProjectID:             dbAsset.ProjectID,
+			AppID:                 dbAsset.AppID,
+			DevEUI:                dbAsset.DevEui,
+			AssetID:               dbAsset.AssetID,
+			GlobalAssetIdentifier: dbAsset.GlobalAssetID,
+			AssetTypeName:         dbAsset.AssetType.Ptr(),
+			ConnectionState:       dbAsset.ConnectionState.Ptr(),
+			LastUplinkAt:          dbAsset.LastUplinkAt.Ptr(),
+		}
+
+		// Join the Eliona asset
+		assets, ok := projectAssets[dbAsset.ProjectID]
+		if !ok {
+			assets, err = getExportAssets(dbAsset.ProjectID)
+			if err != nil {
+				return err
+			}
+			projectAssets[dbAsset.ProjectID] = assets
+		}
+		if asset, ok := assets[dbAsset.AssetID]; ok {
+			device.AssetName = asset.Name.Get()
+			device.AssetTypeName = common.Ptr(asset.AssetType)
+		}
+
+		// Join the Loriot.io device
+		if config, ok := configsByID[dbAsset.ConfigurationID]; ok {
+			key := fmt.Sprintf("%d/%s", dbAsset.ConfigurationID, strings.ToUpper(dbAsset.AppID))
+			devices, ok := appDevices[key]
+			if !ok {
+				devices, err = getExportDevices(ctx, config, dbAsset.AppID)
+				if err != nil {
+					return err
+				}
+				appDevices[key] = devices
+			}
+			if loriotDevice, ok := devices[strings.ToUpper(dbAsset.DevEui)]; ok {
+				joinLoriotDevice(&device, loriotDevice)
+			}
+		}
+
+		// Join the keys from the vault, every reveal is audited
+		if filter.IncludeKeys {
+			keys, ok := revealedKeys[strings.ToUpper(dbAsset.DevEui)]
+			if !ok {
+				keys, err = revealExportKeys(ctx, dbAsset.DevEui)
+				if err != nil {
+					return err
+				}
+				revealedKeys[strings.ToUpper(dbAsset.DevEui)] = keys
+			}
+			joinDeviceKeys(&device, keys)
+		}
+
+		if err := export(device); err != nil {
+			return fmt.Errorf("exporting device %s: %w", dbAsset.DevEui, err)
+		}
+	}
+	return nil
+}
+
+func getExportAssets(projectID string) (map[int32]api.Asset, error) {
+	assets, err := eliona.GetProjectAssets(projectID)
+	if err != nil {
+		return nil, err
+	}
+	assetsByID := make(map[int32]api.Asset)
+	for _, asset := range assets {
+		if asset.Id.Get() != nil {
+			assetsByID[*asset.Id.Get()] = asset
+		}
+	}
+	return assetsByID, nil
+}
+
+func getExportDevices(ctx context.Context, config apiserver.Configuration, appID string) (map[string]loriot.Device, error) {
+	devices, err := loriot.GetDevices(ctx, config, appID)
+	if err != nil {
+		return nil, fmt.Errorf("getting devices of application %s: %w", appID, err)
+	}
+	devicesByEUI := make(map[string]loriot.Device)
+	for _, device := range devices {
+		devicesByEUI[strings.ToUpper(device.DevEUI)] = device
+	}
+	return devicesByEUI, nil
+}
+
+// revealExportKeys reveals the keys of the device from the vault. Returns nil if no keys are stored for the device.
+func revealExportKeys(ctx context.Context, devEUI string) (*apiserver.DeviceKeys, error) {
+	keys, err := app.RevealDeviceKeys(ctx, devEUI)
+	if errors.Is(err, app.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("revealing keys of device %s: %w", devEUI, err)
+	}
+	return keys, nil
+}
+
+// joinLoriotDevice adds the live state of the device in Loriot.io to the export.
+func joinLoriotDevice(device *apiserver.DeviceExport, loriotDevice loriot.Device) {
+	device.InLoriot = true
+	device.Title = common.Ptr(loriotDevice.Title)
+	device.DevAddr = nonEmpty(loriotDevice.DevAddr)
+	if !loriotDevice.LastSeen.IsZero() {
+		device.LastSeen = common.Ptr(loriotDevice.LastSeen)
+	}
+	device.Bat = common.Ptr(int32(loriotDevice.Bat))
+	device.AppEUI = nonEmpty(loriotDevice.AppEUI)
+}
+
+// joinDeviceKeys adds the keys revealed from the vault to the export. Devices without keys in the vault are exported
+// without keys.
+func joinDeviceKeys(device *apiserver.DeviceExport, keys *apiserver.DeviceKeys) {
+	if keys == nil {
+		return
+	}
+	device.AppKey = nonEmpty(keys.AppKey)
+	device.NwkKey = nonEmpty(keys.NwkKey)
+	device.NwkSKey = nonEmpty(keys.NwkSKey)
+	device.AppSKey = nonEmpty(keys.AppSKey)
+	device.FNwkSIntKey = nonEmpty(keys.FNwkSIntKey)
+	device.SNwkSIntKey = nonEmpty(keys.SNwkSIntKey)
+	device.NwkSEncKey = nonEmpty(keys.NwkSEncKey)
+}
+
+func nonEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// deviceExportRecord returns the CSV row of the device in the order of deviceExportColumns.
+func deviceExportRecord(device apiserver.DeviceExport) []string {
+	return []string{
+		strconv.FormatInt(device.ConfigID, 10),
+		device.ProjectID,
+		device.AppID,
+		device.DevEUI,
+		strconv.FormatInt(int64(device.AssetID), 10),
+		device.GlobalAssetIdentifier,
+		stringValue(device.AssetName),
+		stringValue(device.AssetTypeName),
+		stringValue(device.ConnectionState),
+		timeValue(device.LastUplinkAt),
+		strconv.FormatBool(device.InLoriot),
+		stringValue(device.Title),
+		stringValue(device.DevAddr),
+		timeValue(device.LastSeen),
+		intValue(device.Bat),
+		stringValue(device.AppEUI),
+		stringValue(device.AppKey),
+		stringValue(device.NwkKey),
+		stringValue(device.NwkSKey),
+		stringValue(device.AppSKey),
+		stringValue(device.FNwkSIntKey),
+		stringValue(device.SNwkSIntKey),
+		stringValue(device.NwkSEncKey),
+	}
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func timeValue(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func intValue(i *int32) string {
+	if i == nil {
+		return ""
+	}
+	return strconv.FormatInt(int64(*i), 10)
+}
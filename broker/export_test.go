@@ -0,0 +1,62 @@
+package broker
+
+import (
+	"loriot-io/apiserver"
+	"loriot-io/loriot"
+	"testing"
+	"time"
+)
+
+func TestJoinLoriotDevice(t *testing.T) {
+	loriotDevice := loriot.Device{
+		Title:    "Sensor",
+		DevAddr:  "26011BDA",
+		LastSeen: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Bat:      200,
+	}
+
+	var device apiserver.DeviceExport
+	joinLoriotDevice(&device, loriotDevice)
+	if !device.InLoriot || *device.Title != "Sensor" || *device.DevAddr != "26011BDA" || *device.Bat != 200 {
+		t.Errorf("unexpected live state: %+v", device)
+	}
+}
+
+func TestJoinDeviceKeys(t *testing.T) {
+	var device apiserver.DeviceExport
+	joinDeviceKeys(&device, nil)
+	if device.AppKey != nil || device.NwkKey != nil || device.NwkSKey != nil || device.AppSKey != nil ||
+		device.FNwkSIntKey != nil || device.SNwkSIntKey != nil || device.NwkSEncKey != nil {
+		t.Errorf("keys exported without vault entry: %+v", device)
+	}
+
+	joinDeviceKeys(&device, &apiserver.DeviceKeys{AppKey: "00112233445566778899AABBCCDDEEFF"})
+	if device.AppKey == nil || *device.AppKey != "00112233445566778899AABBCCDDEEFF" {
+		t.Error("app key from vault not exported")
+	}
+	if device.NwkSKey != nil {
+		t.Error("empty network session key exported")
+	}
+
+	device = apiserver.DeviceExport{}
+	joinDeviceKeys(&device, &apiserver.DeviceKeys{
+		NwkKey:      "FFEEDDCCBBAA99887766554433221100",
+		FNwkSIntKey: "11111111111111111111111111111111",
+		SNwkSIntKey: "22222222222222222222222222222222",
+		NwkSEncKey:  "33333333333333333333333333333333",
+	})
+	if device.NwkKey == nil || device.FNwkSIntKey == nil || device.SNwkSIntKey == nil || device.NwkSEncKey == nil {
+		t.Errorf("LoRaWAN 1.1 keys from vault not exported: %+v", device)
+	}
+}
+
+func TestDeviceExportRecord(t *testing.T) {
+	device := apiserver.DeviceExport{ConfigID: 1, DevEUI: "0123456789ABCDEF", AssetID: 42}
+	record := deviceExportRecord(device)
+	if len(record) != len(deviceExportColumns) {
+		t.Fatalf("record has %d columns, header has %d", len(record), len(deviceExportColumns))
+	}
+	if record[0] != "1" || record[3] != "0123456789ABCDEF" || record[4] != "42" || record[10] != "false" {
+		t.Errorf("unexpected record: %v", record)
+	}
+}
@@ -0,0 +1,195 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package broker
+
+import (
+	"context"
+	"fmt"
+	"loriot-io/apiserver"
+	"loriot-io/app"
+	"loriot-io/appdb"
+	"loriot-io/eliona"
+	"loriot-io/loriot"
+	"strings"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+	"github.com/volatiletech/null/v8"
+)
+
+// defaultGatewayOfflineThreshold is used in seconds if the configuration defines no offline threshold.
+const defaultGatewayOfflineThreshold = 600
+
+// syncGateways creates assets for all gateways of the networks of the configuration and writes their status. The
+// number of connected devices is counted by the gateway which received the latest uplink of each device, if all
+// devices are known. Gateways disconnected longer than the offline threshold raise an alarm and the users are
+// notified.
+func syncGateways(ctx context.Context, config apiserver.Configuration, devices []loriot.Device, allDevices bool) {
+	var devicesByGateway map[string]int
+	if allDevices {
+		devicesByGateway = make(map[string]int)
+		for _, device := range devices {
+			if device.Gw != "" {
+				devicesByGateway[strings.ToUpper(device.Gw)]++
+			}
+		}
+	}
+
+	networks, err := loriot.GetNetworks(ctx, config)
+	if err != nil {
+		log.Warn("loriot", "Error getting networks for config %d: %v", *config.Id, err)
+		return
+	}
+	for _, network := range networks {
+		gateways, err := loriot.GetGateways(ctx, config, network.Id)
+		if err != nil {
+			log.Error("loriot", "Error getting gateways of network %s for config %d: %v", network.Id, *config.Id, err)
+			continue
+		}
+		for _, gateway := range gateways {
+			if ctx.Err() != nil {
+				return
+			}
+			syncGateway(ctx, config, gateway, devices, devicesByGateway)
+		}
+	}
+}
+
+func syncGateway(ctx context.Context, config apiserver.Configuration, gateway loriot.Gateway, devices []loriot.Device, devicesByGateway map[string]int) {
+	dbGateways, err := app.GetDbGatewayAssets(ctx, *config.Id, gateway.EUI)
+	if err != nil {
+		log.Error("app", "%v", err)
+		return
+	}
+	byProject := make(map[string]*appdb.Gateway)
+	for _, dbGateway := range dbGateways {
+		byProject[dbGateway.ProjectID] = dbGateway
+	}
+	for _, projectID := range app.ProjIds(config) {
+		if _, ok := byProject[projectID]; ok {
+			continue
+		}
+		asset, err := eliona.UpsertGatewayAsset(ctx, projectID, gateway)
+		if err != nil {
+			log.Error("eliona", "Error creating asset for gateway %s: %v", gateway.EUI, err)
+			continue
+		}
+		if asset == nil {
+			continue
+		}
+		dbGateway, err := app.UpsertGatewayAsset(ctx, config, gateway, *asset)
+		if err != nil {
+			log.Error("app", "Error remembering asset for gateway %s: %v", gateway.EUI, err)
+			continue
+		}
+		log.Info("loriot", "Created asset %d for gateway %s in project %s", dbGateway.AssetID, gateway.EUI, projectID)
+		byProject[projectID] = dbGateway
+		dbGateways = append(dbGateways, dbGateway)
+	}
+	if len(dbGateways) == 0 {
+		return
+	}
+
+	// All assets of the gateway share the state, so the first one is representative
+	wasOffline := dbGateways[0].Offline
+	disconnectedSince, offline := gatewayOffline(gateway, dbGateways[0].DisconnectedSince, time.Duration(gatewayOfflineThreshold(config))*time.Second, time.Now())
+	if err := app.SetGatewayState(ctx, *config.Id, gateway.EUI, disconnectedSince, offline); err != nil {
+		log.Error("app", "%v", err)
+		return
+	}
+
+	var deviceCount *int
+	if devicesByGateway != nil {
+		deviceCount = common.Ptr(devicesByGateway[strings.ToUpper(gateway.EUI)])
+	}
+	for _, dbGateway := range dbGateways {
+		if !dbGateway.AlarmRuleID.Valid {
+			alarmRuleID, err := eliona.UpsertAlarmRule(dbGateway.AssetID, api.SUBTYPE_STATUS, "offline", gatewayOfflineAlarmMessage(gateway))
+			if err != nil {
+				log.Error("eliona", "Error creating offline alarm rule for gateway %s: %v", gateway.EUI, err)
+			} else if err := app.SetGatewayAlarmRule(ctx, dbGateway, alarmRuleID); err != nil {
+				log.Error("app", "%v", err)
+			}
+		}
+		if err := eliona.UpsertGatewayData(dbGateway.AssetID, gateway, deviceCount, offline); err != nil {
+			log.Error("eliona", "Error writing status of gateway %s: %v", gateway.EUI, err)
+		}
+		if offline != wasOffline {
+			app.NotifyUser(config.UserId, &dbGateway.ProjectID, gatewayOutageNotification(gateway, offline, devicesHeardVia(devices, gateway.EUI)))
+		}
+	}
+	if offline != wasOffline {
+		log.Info("loriot", "Gateway %s changed to offline %v", gateway.EUI, offline)
+	}
+}
+
+// gatewayOffline determines since when the gateway is disconnected and whether it is disconnected longer than the
+// threshold. The time the gateway was last seen is preferred over the time the disconnection was first noticed.
+func gatewayOffline(gateway loriot.Gateway, disconnectedSince null.Time, threshold time.Duration, now time.Time) (null.Time, bool) {
+	if gateway.Connected {
+		return null.Time{}, false
+	}
+	if !gateway.LastSeen.IsZero() {
+		disconnectedSince = null.TimeFrom(gateway.LastSeen)
+	} else if !disconnectedSince.Valid {
+		disconnectedSince = null.TimeFrom(now)
+	}
+	return disconnectedSince, now.Sub(disconnectedSince.Time) >= threshold
+}
+
+func gatewayOfflineThreshold(config apiserver.Configuration) int32 {
+	if config.GatewayOfflineThreshold == nil || *config.GatewayOfflineThreshold <= 0 {
+		return defaultGatewayOfflineThreshold
+	}
+	return *config.GatewayOfflineThreshold
+}
+
+// devicesHeardVia returns the EUIs of the devices whose latest uplink was received by the gateway.
+func devicesHeardVia(devices []loriot.Device, gatewayEUI string) []string {
+	var devEUIs []string
+	for _, device := range devices {
+		if strings.EqualFold(device.Gw, gatewayEUI) {
+			devEUIs = append(devEUIs, strings.ToUpper(device.DevEUI))
+		}
+	}
+	return devEUIs
+}
+
+func gatewayOfflineAlarmMessage(gateway loriot.Gateway) api.Translation {
+	return api.Translation{
+		De: api.PtrString(fmt.Sprintf("Loriot Gateway '%s' ist offline.", gateway.EUI)),
+		En: api.PtrString(fmt.Sprintf("Loriot gateway '%s' is offline.", gateway.EUI)),
+	}
+}
+
+func gatewayOutageNotification(gateway loriot.Gateway, offline bool, devEUIs []string) *api.Translation {
+	if !offline {
+		return &api.Translation{
+			De: api.PtrString(fmt.Sprintf("Loriot Gateway '%s' ist wieder online.", gateway.EUI)),
+			En: api.PtrString(fmt.Sprintf("Loriot gateway '%s' is online again.", gateway.EUI)),
+		}
+	}
+	devices := "-"
+	if len(devEUIs) > 0 {
+		devices = strings.Join(devEUIs, ", ")
+	}
+	return &api.Translation{
+		De: api.PtrString(fmt.Sprintf("Loriot Gateway '%s' ist offline. Zuletzt über das Gateway empfangene Geräte: %s", gateway.EUI, devices)),
+		En: api.PtrString(fmt.Sprintf("Loriot gateway '%s' is offline. Devices last heard via the gateway: %s", gateway.EUI, devices)),
+	}
+}
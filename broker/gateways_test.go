@@ -0,0 +1,47 @@
+package broker
+
+import (
+	"loriot-io/loriot"
+	"testing"
+	"time"
+
+	"github.com/volatiletech/null/v8"
+)
+
+func TestGatewayOffline(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	threshold := 10 * time.Minute
+	tests := []struct {
+		name              string
+		gateway           loriot.Gateway
+		disconnectedSince null.Time
+		wantSince         null.Time
+		wantOffline       bool
+	}{
+		{"connected", loriot.Gateway{Connected: true, LastSeen: now.Add(-time.Hour)}, null.TimeFrom(now.Add(-time.Hour)), null.Time{}, false},
+		{"just disconnected", loriot.Gateway{}, null.Time{}, null.TimeFrom(now), false},
+		{"disconnected below threshold", loriot.Gateway{}, null.TimeFrom(now.Add(-5 * time.Minute)), null.TimeFrom(now.Add(-5 * time.Minute)), false},
+		{"disconnected above threshold", loriot.Gateway{}, null.TimeFrom(now.Add(-15 * time.Minute)), null.TimeFrom(now.Add(-15 * time.Minute)), true},
+		{"last seen above threshold", loriot.Gateway{LastSeen: now.Add(-time.Hour)}, null.Time{}, null.TimeFrom(now.Add(-time.Hour)), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			since, offline := gatewayOffline(tt.gateway, tt.disconnectedSince, threshold, now)
+			if since.Valid != tt.wantSince.Valid || !since.Time.Equal(tt.wantSince.Time) || offline != tt.wantOffline {
+				t.Errorf("gatewayOffline() = %v, %v, want %v, %v", since, offline, tt.wantSince, tt.wantOffline)
+			}
+		})
+	}
+}
+
+func TestDevicesHeardVia(t *testing.T) {
+	devices := []loriot.Device{
+		{DevEUI: "be7a0000000014e2", Gw: "b827ebfffe000001"},
+		{DevEUI: "BE7A0000000014E3", Gw: "B827EBFFFE000002"},
+		{DevEUI: "BE7A0000000014E4", Gw: "B827EBFFFE000001"},
+	}
+	got := devicesHeardVia(devices, "B827EBFFFE000001")
+	if len(got) != 2 || got[0] != "BE7A0000000014E2" || got[1] != "BE7A0000000014E4" {
+		t.Errorf("devicesHeardVia() = %v", got)
+	}
+}
@@ -0,0 +1,113 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package broker
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"loriot-io/apiserver"
+	"loriot-io/app"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+const (
+	// idempotencyPollInterval defines how often a retry checks whether the request holding its key completed.
+	idempotencyPollInterval = 500 * time.Millisecond
+
+	// idempotencyWaitTimeout defines how long a retry waits for the request holding its idempotency key.
+	idempotencyWaitTimeout = time.Minute
+)
+
+// UpsertDeviceIdempotent performs UpsertDevice only once per idempotency key. A retry with the same key and request
+// returns the device assets of the first successful request without changing anything. The key is reserved in the
+// database before upserting, so a concurrent retry, also on another replica, waits for the first request instead of
+// upserting the device again. Requests with different keys don't wait for each other. Without key the device is
+// always upserted.
+func UpsertDeviceIdempotent(ctx context.Context, idempotencyKey string, putDeviceRequest apiserver.PutDeviceRequest) ([]apiserver.DeviceAsset, []apiserver.PutDeviceStep, error) {
+	if idempotencyKey == "" {
+		return UpsertDevice(ctx, putDeviceRequest)
+	}
+	requestHash, err := hashRequest(putDeviceRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := app.DeleteExpiredIdempotencyKeys(ctx); err != nil {
+		log.Error("app", "Error deleting expired idempotency keys: %v", err)
+	}
+	deviceAssets, found, err := waitForIdempotencyKey(ctx, idempotencyKey, requestHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	if found {
+		log.Info("loriot", "Device %s already upserted with idempotency key %s", putDeviceRequest.DevEUI, idempotencyKey)
+		return deviceAssets, nil, nil
+	}
+
+	deviceAssets, steps, err := UpsertDevice(ctx, putDeviceRequest)
+	if err != nil || deviceAssets == nil {
+		if err := app.ReleaseIdempotencyKey(ctx, idempotencyKey); err != nil {
+			log.Error("app", "Error releasing idempotency key %s: %v", idempotencyKey, err)
+		}
+		return deviceAssets, steps, err
+	}
+	if err := app.CompleteIdempotencyKey(ctx, idempotencyKey, deviceAssets); err != nil {
+		log.Error("app", "Error remembering idempotency key %s: %v", idempotencyKey, err)
+	}
+	return deviceAssets, steps, nil
+}
+
+// waitForIdempotencyKey reserves the idempotency key for the request. If another request holds the key, it waits
+// until that request completes and returns its device assets, or until it failed and the key can be reserved.
+// Returns app.ErrConflict if the other request doesn't complete within the wait timeout.
+func waitForIdempotencyKey(ctx context.Context, idempotencyKey string, requestHash string) ([]apiserver.DeviceAsset, bool, error) {
+	timeout := time.After(idempotencyWaitTimeout)
+	for {
+		reserved, err := app.ReserveIdempotencyKey(ctx, idempotencyKey, requestHash)
+		if err != nil {
+			return nil, false, err
+		}
+		if reserved {
+			return nil, false, nil
+		}
+		deviceAssets, found, err := app.GetIdempotentDeviceAssets(ctx, idempotencyKey, requestHash)
+		if err != nil || found {
+			return deviceAssets, found, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		case <-timeout:
+			return nil, false, fmt.Errorf("%w: request with idempotency key %s is still in progress", app.ErrConflict, idempotencyKey)
+		case <-time.After(idempotencyPollInterval):
+		}
+	}
+}
+
+// hashRequest identifies the content of the request, so an idempotency key reused for a different request is detected.
+func hashRequest(putDeviceRequest apiserver.PutDeviceRequest) (string, error) {
+	data, err := json.Marshal(putDeviceRequest)
+	if err != nil {
+		return "", fmt.Errorf("marshalling request for device %s: %w", putDeviceRequest.DevEUI, err)
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
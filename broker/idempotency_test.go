@@ -0,0 +1,23 @@
+package broker
+
+import (
+	"loriot-io/apiserver"
+	"testing"
+)
+
+func TestHashRequest(t *testing.T) {
+	request := apiserver.PutDeviceRequest{DevEUI: "0123456789ABCDEF", AppID: "BE7A0001", AssetTypeName: "loriot_io_cayenne_lpp", Title: "Sensor"}
+	hash, err := hashRequest(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	retried, _ := hashRequest(request)
+	if hash != retried {
+		t.Errorf("hash of retried request = %s, want %s", retried, hash)
+	}
+	request.Title = "Other sensor"
+	changed, _ := hashRequest(request)
+	if hash == changed {
+		t.Error("hash of changed request should differ")
+	}
+}
@@ -0,0 +1,273 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package broker
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"loriot-io/apiserver"
+	"loriot-io/app"
+	"loriot-io/appdb"
+	"loriot-io/eliona"
+	"loriot-io/loriot"
+	"strconv"
+	"strings"
+	"sync"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// importWorkers limits the number of devices upserted concurrently in Loriot.io by an import job.
+const importWorkers = 4
+
+// maxImportRows limits the number of devices of one import.
+const maxImportRows = 10000
+
+// runningImportJobs holds the IDs of the jobs running, so a job resumed after a restart isn't run twice.
+var runningImportJobs sync.Map
+
+// ImportDevices validates the devices of the uploaded CSV or JSON file and starts a job upserting them in the
+// background. Returns the pending job.
+func ImportDevices(ctx context.Context, file io.Reader) (*apiserver.Job, error) {
+	requests, err := parseDeviceImport(file)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", app.ErrBadRequest, err)
+	}
+	if err := validateDeviceImport(requests); err != nil {
+		return nil, fmt.Errorf("%w: %v", app.ErrBadRequest, err)
+	}
+	dbJob, err := app.InsertDeviceImportJob(ctx, requests)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("loriot", "Starting job %d importing %d devices", dbJob.ID, len(requests))
+	go runImportJob(context.Background(), dbJob)
+	return app.GetJob(ctx, dbJob.ID)
+}
+
+// ResumeImportJobs continues the import jobs interrupted by a restart of the app.
+func ResumeImportJobs() {
+	ctx := context.Background()
+	dbJobs, err := app.GetUnfinishedJobs(ctx, app.JobKindDeviceImport)
+	if err != nil {
+		log.Error("app", "Error getting unfinished import jobs: %v", err)
+		return
+	}
+	for _, dbJob := range dbJobs {
+		log.Info("loriot", "Resuming job %d importing devices", dbJob.ID)
+		runImportJob(ctx, dbJob)
+	}
+}
+
+// runImportJob upserts the pending devices of the job with a bounded number of workers.
+func runImportJob(ctx context.Context, dbJob *appdb.Job) {
+	if _, running := runningImportJobs.LoadOrStore(dbJob.ID, true); running {
+		return
+	}
+	defer runningImportJobs.Delete(dbJob.ID)
+
+	if err := app.SetJobStatus(ctx, dbJob, app.JobStatusRunning); err != nil {
+		log.Error("app", "Error starting job %d: %v", dbJob.ID, err)
+		return
+	}
+	dbRows, err := app.GetPendingJobRows(ctx, dbJob.ID)
+	if err != nil {
+		log.Error("app", "Error getting rows of job %d: %v", dbJob.ID, err)
+		return
+	}
+
+	// Create the root assets up front, so rolling back a failed device never removes the root asset of another one
+	prepareRootAssets(ctx)
+
+	rows := make(chan *appdb.JobRow)
+	var wg sync.WaitGroup
+	for i := 0; i < importWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for dbRow := range rows {
+				importDevice(ctx, dbRow)
+			}
+		}()
+	}
+	for _, dbRow := range dbRows {
+		rows <- dbRow
+	}
+	close(rows)
+	wg.Wait()
+
+	if err := app.SetJobStatus(ctx, dbJob, app.JobStatusDone); err != nil {
+		log.Error("app", "Error finishing job %d: %v", dbJob.ID, err)
+		return
+	}
+	job, err := app.GetJob(ctx, dbJob.ID)
+	if err != nil {
+		log.Error("app", "Error getting job %d: %v", dbJob.ID, err)
+		return
+	}
+	log.Info("loriot", "Finished job %d importing devices: %d succeeded, %d failed", job.Id, job.Succeeded, job.Failed)
+	app.NotifyUser(job.UserId, nil, &api.Translation{
+		De: api.PtrString(fmt.Sprintf("Loriot App hat den Geräteimport %d abgeschlossen: %d erfolgreich, %d fehlgeschlagen.", job.Id, job.Succeeded, job.Failed)),
+		En: api.PtrString(fmt.Sprintf("Loriot app finished device import %d: %d succeeded, %d failed.", job.Id, job.Succeeded, job.Failed)),
+	})
+}
+
+// importDevice upserts the device of the row and records the outcome.
+func importDevice(ctx context.Context, dbRow *appdb.JobRow) {
+	request, err := app.JobRowDeviceRequest(dbRow)
+	if err == nil {
+		var deviceAssets []apiserver.DeviceAsset
+		deviceAssets, _, err = UpsertDevice(ctx, request)
+		if err == nil && deviceAssets == nil {
+			err = fmt.Errorf("no enabled configuration matches the device")
+		}
+		if err == nil {
+			if err := app.SetJobRowDone(ctx, dbRow, deviceAssets); err != nil {
+				log.Error("app", "Error recording row %d of job %d: %v", dbRow.RowNumber, dbRow.JobID, err)
+			}
+			return
+		}
+	}
+	log.Error("loriot", "Error importing device %s in row %d of job %d: %v", dbRow.DevEui, dbRow.RowNumber, dbRow.JobID, err)
+	if err := app.SetJobRowFailed(ctx, dbRow, err); err != nil {
+		log.Error("app", "Error recording row %d of job %d: %v", dbRow.RowNumber, dbRow.JobID, err)
+	}
+}
+
+// prepareRootAssets creates the missing root assets of all projects of the enabled configurations.
+func prepareRootAssets(ctx context.Context) {
+	configs, err := app.GetConfigs(ctx)
+	if err != nil {
+		log.Error("app", "Error getting configs: %v", err)
+		return
+	}
+	for _, config := range configs {
+		if !app.IsConfigEnabled(config) {
+			continue
+		}
+		for _, projectID := range app.ProjIds(config) {
+			if _, _, err := eliona.UpsertRootAsset(projectID); err != nil {
+				log.Error("eliona", "Error upserting root asset of project %s: %v", projectID, err)
+			}
+		}
+	}
+}
+
+// parseDeviceImport reads the devices from a JSON list or a CSV file. The header of the CSV file names the properties
+// of the device request, e.g. devEUI, appID, assetTypeName and title.
+func parseDeviceImport(file io.Reader) ([]apiserver.PutDeviceRequest, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading import: %v", err)
+	}
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("[")) {
+		var requests []apiserver.PutDeviceRequest
+		d := json.NewDecoder(bytes.NewReader(data))
+		d.DisallowUnknownFields()
+		if err := d.Decode(&requests); err != nil {
+			return nil, fmt.Errorf("parsing JSON import: %v", err)
+		}
+		return requests, nil
+	}
+	return parseDeviceImportCsv(data)
+}
+
+// numericImportColumns are the CSV columns holding numbers instead of strings.
+var numericImportColumns = map[string]bool{
+	"configID":          true,
+	"reportingInterval": true,
+}
+
+func parseDeviceImportCsv(data []byte) ([]apiserver.PutDeviceRequest, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parsing CSV import: %v", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	header := records[0]
+	var requests []apiserver.PutDeviceRequest
+	for idx, record := range records[1:] {
+		row := map[string]any{}
+		for col, value := range record {
+			name := strings.TrimSpace(header[col])
+			if value == "" {
+				continue
+			}
+			if numericImportColumns[name] {
+				number, err := strconv.ParseInt(value, 10, 32)
+				if err != nil {
+					return nil, fmt.Errorf("row %d: invalid %s: %s", idx+1, name, value)
+				}
+				row[name] = number
+				continue
+			}
+			row[name] = value
+		}
+		rowData, err := json.Marshal(row)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %v", idx+1, err)
+		}
+		var request apiserver.PutDeviceRequest
+		d := json.NewDecoder(bytes.NewReader(rowData))
+		d.DisallowUnknownFields()
+		if err := d.Decode(&request); err != nil {
+			return nil, fmt.Errorf("row %d: %v", idx+1, err)
+		}
+		requests = append(requests, request)
+	}
+	return requests, nil
+}
+
+// validateDeviceImport checks all devices before the import starts. Returns the errors of all invalid rows.
+func validateDeviceImport(requests []apiserver.PutDeviceRequest) error {
+	if len(requests) == 0 {
+		return fmt.Errorf("no devices to import")
+	}
+	if len(requests) > maxImportRows {
+		return fmt.Errorf("%d devices exceed the limit of %d devices per import", len(requests), maxImportRows)
+	}
+	var errs []error
+	rowsByEUI := make(map[string]int)
+	for idx, request := range requests {
+		row := idx + 1
+		if err := apiserver.AssertPutDeviceRequestRequired(request); err != nil {
+			errs = append(errs, fmt.Errorf("row %d: %v", row, err))
+			continue
+		}
+		if !loriot.IsValidEUI(&request.DevEUI) {
+			errs = append(errs, fmt.Errorf("row %d: invalid device EUI: %s", row, request.DevEUI))
+			continue
+		}
+		devEUI := strings.ToUpper(request.DevEUI)
+		if first, ok := rowsByEUI[devEUI]; ok {
+			errs = append(errs, fmt.Errorf("row %d: device EUI %s already in row %d", row, request.DevEUI, first))
+			continue
+		}
+		rowsByEUI[devEUI] = row
+	}
+	return errors.Join(errs...)
+}
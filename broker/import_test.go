@@ -0,0 +1,75 @@
+package broker
+
+import (
+	"loriot-io/apiserver"
+	"strings"
+	"testing"
+)
+
+func TestParseDeviceImportCsv(t *testing.T) {
+	csv := `devEUI,appID,assetTypeName,configID,title,appEUI,appKey
+0123456789ABCDEF,BE7A0001,loriot_io_cayenne_lpp,1,Sensor 1,1000000000000000,00112233445566778899AABBCCDDEEFF
+0123456789ABCDF0,BE7A0001,loriot_io_cayenne_lpp,,Sensor 2,1000000000000000,00112233445566778899AABBCCDDEEFF
+`
+	requests, err := parseDeviceImport(strings.NewReader(csv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("got %d requests, want 2", len(requests))
+	}
+	if requests[0].ConfigID == nil || *requests[0].ConfigID != 1 || requests[0].Title != "Sensor 1" || requests[0].AppKey == "" {
+		t.Errorf("unexpected first request: %+v", requests[0])
+	}
+	if requests[1].ConfigID != nil {
+		t.Errorf("second request: configID = %d, want empty", *requests[1].ConfigID)
+	}
+}
+
+func TestParseDeviceImportCsvUnknownColumn(t *testing.T) {
+	csv := "devEUI,appID,assetTypeName,color\n0123456789ABCDEF,BE7A0001,loriot_io_cayenne_lpp,red\n"
+	if _, err := parseDeviceImport(strings.NewReader(csv)); err == nil {
+		t.Error("expected error for unknown column")
+	}
+}
+
+func TestParseDeviceImportJson(t *testing.T) {
+	json := ` [{"devEUI": "0123456789ABCDEF", "appID": "BE7A0001", "assetTypeName": "loriot_io_cayenne_lpp", "configID": 2}]`
+	requests, err := parseDeviceImport(strings.NewReader(json))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 1 || requests[0].DevEUI != "0123456789ABCDEF" || *requests[0].ConfigID != 2 {
+		t.Errorf("unexpected requests: %+v", requests)
+	}
+}
+
+func TestValidateDeviceImport(t *testing.T) {
+	valid := apiserver.PutDeviceRequest{DevEUI: "0123456789ABCDEF", AppID: "BE7A0001", AssetTypeName: "loriot_io_cayenne_lpp"}
+	if err := validateDeviceImport([]apiserver.PutDeviceRequest{valid}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	duplicate := valid
+	duplicate.DevEUI = strings.ToLower(valid.DevEUI)
+	invalidEUI := valid
+	invalidEUI.DevEUI = "XYZ"
+	missingApp := valid
+	missingApp.AppID = ""
+	err := validateDeviceImport([]apiserver.PutDeviceRequest{valid, duplicate, invalidEUI, missingApp})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	for _, want := range []string{"row 2", "row 3", "row 4"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q doesn't mention %s", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "row 1:") {
+		t.Errorf("error %q mentions valid row 1", err)
+	}
+
+	if err := validateDeviceImport(nil); err == nil {
+		t.Error("expected error for empty import")
+	}
+}
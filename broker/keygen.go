@@ -0,0 +1,153 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package broker
+
+import (
+	"context"
+	"fmt"
+	"loriot-io/apiserver"
+	"loriot-io/app"
+	"loriot-io/loriot"
+	"loriot-io/secret"
+	"strings"
+
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// devEUIAttempts limits the attempts to find an unused device EUI in a block.
+const devEUIAttempts = 10
+
+// generateDeviceKeys fills an empty device EUI and the empty keys of the activation mode with cryptographically random
+// values. The device EUI is generated within the block of the first configuration defining one. Generated keys are
+// stored in the key vault, so the vault must be configured.
+func generateDeviceKeys(ctx context.Context, configs []apiserver.Configuration, putDeviceRequest *apiserver.PutDeviceRequest) error {
+	if !secret.Configured() {
+		return fmt.Errorf("%w: generating keys requires the key vault: %v", app.ErrBadRequest, secret.ErrNotConfigured)
+	}
+	if putDeviceRequest.DevEUI == "" {
+		block := devEUIBlock(configs)
+		if block == "" {
+			return fmt.Errorf("%w: devEUI is required if no configuration defines a device EUI block", app.ErrBadRequest)
+		}
+		devEUI, err := generateDevEUI(ctx, block)
+		if err != nil {
+			return err
+		}
+		putDeviceRequest.DevEUI = devEUI
+	}
+
+	var keys []*string
+	switch loriot.Activation(*putDeviceRequest) {
+	case loriot.ActivationOTAA10:
+		keys = []*string{&putDeviceRequest.AppKey}
+	case loriot.ActivationOTAA11:
+		keys = []*string{&putDeviceRequest.AppKey, &putDeviceRequest.NwkKey}
+	case loriot.ActivationABP10:
+		keys = []*string{&putDeviceRequest.NwkSKey, &putDeviceRequest.AppSKey}
+	case loriot.ActivationABP11:
+		keys = []*string{&putDeviceRequest.AppSKey, &putDeviceRequest.FNwkSIntKey, &putDeviceRequest.SNwkSIntKey, &putDeviceRequest.NwkSEncKey}
+	}
+	for _, key := range keys {
+		if *key != "" {
+			continue
+		}
+		generated, err := secret.RandomHex(16)
+		if err != nil {
+			return err
+		}
+		*key = generated
+	}
+	if _, err := loriot.ValidateActivation(*putDeviceRequest); err != nil {
+		return fmt.Errorf("%w: %v", app.ErrBadRequest, err)
+	}
+	return nil
+}
+
+// devEUIBlock returns the device EUI block of the first configuration defining one.
+func devEUIBlock(configs []apiserver.Configuration) string {
+	for _, config := range configs {
+		if config.DevEUIBlock != nil && *config.DevEUIBlock != "" {
+			return strings.ToUpper(*config.DevEUIBlock)
+		}
+	}
+	return ""
+}
+
+// generateDevEUI returns a random device EUI within the block, which is not used by any device known to the app.
+func generateDevEUI(ctx context.Context, block string) (string, error) {
+	for attempt := 0; attempt < devEUIAttempts; attempt++ {
+		random, err := secret.RandomHex(8)
+		if err != nil {
+			return "", err
+		}
+		devEUI := block + random[len(block):]
+		used, err := app.IsDevEUIUsed(ctx, devEUI)
+		if err != nil {
+			return "", err
+		}
+		if !used {
+			return devEUI, nil
+		}
+	}
+	return "", fmt.Errorf("no unused device EUI found in block %s", block)
+}
+
+// deviceKeys returns the keys of the request to store in the key vault.
+func deviceKeys(putDeviceRequest apiserver.PutDeviceRequest) apiserver.DeviceKeys {
+	return apiserver.DeviceKeys{
+		DevEUI:      putDeviceRequest.DevEUI,
+		AppKey:      strings.ToUpper(putDeviceRequest.AppKey),
+		NwkKey:      strings.ToUpper(putDeviceRequest.NwkKey),
+		NwkSKey:     strings.ToUpper(putDeviceRequest.NwkSKey),
+		AppSKey:     strings.ToUpper(putDeviceRequest.AppSKey),
+		FNwkSIntKey: strings.ToUpper(putDeviceRequest.FNwkSIntKey),
+		SNwkSIntKey: strings.ToUpper(putDeviceRequest.SNwkSIntKey),
+		NwkSEncKey:  strings.ToUpper(putDeviceRequest.NwkSEncKey),
+	}
+}
+
+func hasDeviceKeys(keys apiserver.DeviceKeys) bool {
+	return keys.AppKey != "" || keys.NwkKey != "" || keys.NwkSKey != "" || keys.AppSKey != "" ||
+		keys.FNwkSIntKey != "" || keys.SNwkSIntKey != "" || keys.NwkSEncKey != ""
+}
+
+// storeDeviceKeys stores the keys of the request in the key vault and records the step in the saga. It must only be
+// called after the saga created the device in Loriot.io, so the vault holds the keys the device was created with.
+// Without configured vault the keys are not stored.
+func storeDeviceKeys(ctx context.Context, s *saga, putDeviceRequest apiserver.PutDeviceRequest) error {
+	keys := deviceKeys(putDeviceRequest)
+	if !hasDeviceKeys(keys) {
+		return nil
+	}
+	if !secret.Configured() {
+		log.Debug("app", "Keys of device %s not stored: %v", putDeviceRequest.DevEUI, secret.ErrNotConfigured)
+		return nil
+	}
+	step := apiserver.PutDeviceStep{Step: StepKeyVault}
+	previous, err := app.StoreDeviceKeys(ctx, keys)
+	if err != nil {
+		s.failed(step, err)
+		return err
+	}
+	step.Status = StepCreated
+	if previous != nil {
+		step.Status = StepUpdated
+	}
+	s.applied(step, func() error {
+		return app.RestoreDeviceKeys(ctx, putDeviceRequest.DevEUI, previous)
+	})
+	return nil
+}
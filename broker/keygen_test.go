@@ -0,0 +1,75 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"loriot-io/apiserver"
+	"loriot-io/app"
+	"loriot-io/loriot"
+	"testing"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+const testSecretKey = "BwcHBwcHBwcHBwcHBwcHBwcHBwcHBwcHBwcHBwcHBwc="
+
+func TestGenerateDeviceKeysOTAA11(t *testing.T) {
+	t.Setenv("SECRET_KEY", testSecretKey)
+	request := apiserver.PutDeviceRequest{DevEUI: "0004A30B001C0530", JoinEUI: "70B3D57ED0000000"}
+	if err := generateDeviceKeys(context.Background(), nil, &request); err != nil {
+		t.Fatal(err)
+	}
+	if activation, err := loriot.ValidateActivation(request); err != nil || activation != loriot.ActivationOTAA11 {
+		t.Errorf("activation %v, error %v", activation, err)
+	}
+	if request.AppKey == request.NwkKey {
+		t.Error("expected different keys")
+	}
+}
+
+func TestGenerateDeviceKeysKeepsGivenKeys(t *testing.T) {
+	t.Setenv("SECRET_KEY", testSecretKey)
+	request := apiserver.PutDeviceRequest{DevEUI: "0004A30B001C0530", DevAddr: "26011BDA", NwkSKey: "00112233445566778899AABBCCDDEEFF"}
+	if err := generateDeviceKeys(context.Background(), nil, &request); err != nil {
+		t.Fatal(err)
+	}
+	if request.NwkSKey != "00112233445566778899AABBCCDDEEFF" || request.AppSKey == "" {
+		t.Errorf("unexpected keys: %+v", request)
+	}
+}
+
+func TestGenerateDeviceKeysWithoutVault(t *testing.T) {
+	t.Setenv("SECRET_KEY", "")
+	t.Setenv("SECRET_KEY_FILE", "")
+	request := apiserver.PutDeviceRequest{DevEUI: "0004A30B001C0530", AppEUI: "70B3D57ED0000000"}
+	if err := generateDeviceKeys(context.Background(), nil, &request); !errors.Is(err, app.ErrBadRequest) {
+		t.Errorf("got %v, want bad request", err)
+	}
+}
+
+func TestGenerateDeviceKeysWithoutBlock(t *testing.T) {
+	t.Setenv("SECRET_KEY", testSecretKey)
+	request := apiserver.PutDeviceRequest{AppEUI: "70B3D57ED0000000"}
+	configs := []apiserver.Configuration{{Id: common.Ptr[int64](1)}}
+	if err := generateDeviceKeys(context.Background(), configs, &request); !errors.Is(err, app.ErrBadRequest) {
+		t.Errorf("got %v, want bad request", err)
+	}
+}
+
+func TestDevEUIBlock(t *testing.T) {
+	configs := []apiserver.Configuration{{}, {DevEUIBlock: common.Ptr("70b3d57ed")}, {DevEUIBlock: common.Ptr("0004A3")}}
+	if block := devEUIBlock(configs); block != "70B3D57ED" {
+		t.Errorf("got block %s, want 70B3D57ED", block)
+	}
+}
+
+func TestDeviceKeys(t *testing.T) {
+	keys := deviceKeys(apiserver.PutDeviceRequest{DevEUI: "0004A30B001C0530", AppEUI: "70B3D57ED0000000"})
+	if hasDeviceKeys(keys) {
+		t.Errorf("unexpected keys: %+v", keys)
+	}
+	keys = deviceKeys(apiserver.PutDeviceRequest{DevEUI: "0004A30B001C0530", AppKey: "00112233445566778899aabbccddeeff"})
+	if !hasDeviceKeys(keys) || keys.AppKey != "00112233445566778899AABBCCDDEEFF" {
+		t.Errorf("unexpected keys: %+v", keys)
+	}
+}
@@ -0,0 +1,148 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package broker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"loriot-io/apiserver"
+	"loriot-io/app"
+	"loriot-io/loriot"
+	"loriot-io/migration"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+const (
+	MigrationStatusMigrated = "migrated"
+	MigrationStatusFailed   = "failed"
+)
+
+// MigrationTarget defines the Loriot.io application and the Eliona project the devices are migrated to.
+type MigrationTarget struct {
+	migration.Target
+
+	// ProjectID restricts the assets to one project of the configuration. If empty the projects each device is routed
+	// to by the configuration are used.
+	ProjectID string
+}
+
+// MigrateDevices provisions the devices exported from another network server in the target Loriot.io application and
+// Eliona project, one by one with the same rollback as UpsertDevice. Returns the outcome per device.
+func MigrateDevices(ctx context.Context, source string, file io.Reader, target MigrationTarget) (*apiserver.MigrationReport, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading migration export: %w", err)
+	}
+	devices, err := migration.Parse(source, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", app.ErrBadRequest, err)
+	}
+	config, projectIDs, err := migrationConfig(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
+	report := apiserver.MigrationReport{
+		Source:  source,
+		Total:   int32(len(devices)),
+		Devices: []apiserver.MigratedDevice{},
+	}
+	for _, device := range devices {
+		migrated := migrateDevice(ctx, *config, projectIDs, device, target)
+		if migrated.Status == MigrationStatusMigrated {
+			report.Migrated++
+		} else {
+			report.Failed++
+		}
+		report.Devices = append(report.Devices, migrated)
+	}
+	log.Info("loriot", "Migrated %d of %d devices from %s", report.Migrated, report.Total, source)
+	return &report, nil
+}
+
+// migrationConfig returns the enabled target configuration and the project the assets are created in, or nil if the
+// devices are routed by the configuration.
+func migrationConfig(ctx context.Context, target MigrationTarget) (*apiserver.Configuration, []string, error) {
+	configs, err := app.GetConfigs(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, config := range configs {
+		if config.Id == nil || *config.Id != target.ConfigID {
+			continue
+		}
+		if !app.IsConfigEnabled(config) {
+			return nil, nil, fmt.Errorf("%w: configuration %d is disabled", app.ErrBadRequest, target.ConfigID)
+		}
+		if target.ProjectID == "" {
+			return &config, nil, nil
+		}
+		if !sliceContains(app.ProjIds(config), target.ProjectID) {
+			return nil, nil, fmt.Errorf("%w: project %s is not a project of configuration %d", app.ErrBadRequest, target.ProjectID, target.ConfigID)
+		}
+		return &config, []string{target.ProjectID}, nil
+	}
+	return nil, nil, fmt.Errorf("%w: configuration %d not found", app.ErrBadRequest, target.ConfigID)
+}
+
+// migrateDevice validates the keys of the device against its activation mode and upserts it.
+func migrateDevice(ctx context.Context, config apiserver.Configuration, projectIDs []string, device migration.Device, target MigrationTarget) apiserver.MigratedDevice {
+	request := device.PutDeviceRequest(target.Target)
+	migrated := apiserver.MigratedDevice{
+		DevEUI:     request.DevEUI,
+		Name:       device.Name,
+		MacVersion: device.MacVersion,
+		Status:     MigrationStatusFailed,
+		Warnings:   device.Warnings,
+	}
+	fail := func(err error) apiserver.MigratedDevice {
+		log.Error("loriot", "Error migrating device %s: %v", request.DevEUI, err)
+		migrated.Error = common.Ptr(err.Error())
+		return migrated
+	}
+
+	if !loriot.IsValidEUI(&request.DevEUI) {
+		return fail(fmt.Errorf("invalid device EUI: %s", request.DevEUI))
+	}
+	activation, err := loriot.ValidateActivation(request)
+	migrated.Activation = activation
+	if err != nil {
+		return fail(err)
+	}
+
+	if projectIDs == nil {
+		routedProjectIDs, _, routed := app.RouteDevice(config, request.AppID, request.DevEUI, request.Title)
+		if !routed {
+			return fail(fmt.Errorf("device matches no routing rule of configuration %d", *config.Id))
+		}
+		projectIDs = routedProjectIDs
+	}
+
+	s := &saga{}
+	deviceAssets, err := upsertConfigDevice(ctx, s, config, projectIDs, request)
+	if err != nil {
+		s.rollback()
+		return fail(err)
+	}
+	for _, deviceAsset := range deviceAssets {
+		migrated.AssetIDs = append(migrated.AssetIDs, deviceAsset.AssetID)
+	}
+	migrated.Status = MigrationStatusMigrated
+	return migrated
+}
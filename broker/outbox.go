@@ -0,0 +1,165 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package broker
+
+import (
+	"context"
+	"fmt"
+	"loriot-io/apiserver"
+	"loriot-io/app"
+	"loriot-io/appdb"
+	"loriot-io/loriot"
+	"net/http"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+const (
+	// outboxPollInterval defines how often pending outbox operations are checked.
+	outboxPollInterval = 5 * time.Second
+
+	// outboxMaxAttempts is the number of failed attempts after which an operation is moved to the dead letters.
+	outboxMaxAttempts = 8
+
+	outboxInitialBackoff = 10 * time.Second
+	outboxMaxBackoff     = time.Hour
+)
+
+// ProcessOutbox performs the pending Loriot.io operations of each enabled configuration. Failed operations are
+// attempted again with exponential backoff until they succeed or are moved to the dead letters.
+func ProcessOutbox() {
+	newConfigWorkers("outbox", processConfigOutbox).run()
+}
+
+func processConfigOutbox(ctx context.Context, config apiserver.Configuration) {
+	for {
+		processOutbox(ctx, config, time.Now())
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(outboxPollInterval):
+		}
+	}
+}
+
+// processOutbox performs the operations due at the given time. Operations of a device are performed in the order they
+// were created, so a device's operations wait until its earlier ones succeeded.
+func processOutbox(ctx context.Context, config apiserver.Configuration, now time.Time) {
+	dbOutboxes, err := app.GetPendingOutboxOperations(ctx, *config.Id)
+	if err != nil {
+		log.Error("app", "%v", err)
+		return
+	}
+	blocked := make(map[string]bool)
+	for _, dbOutbox := range dbOutboxes {
+		if ctx.Err() != nil {
+			return
+		}
+		if blocked[dbOutbox.DevEui] || dbOutbox.NextAttemptAt.After(now) {
+			blocked[dbOutbox.DevEui] = true
+			continue
+		}
+		asset, err := app.OutboxAsset(dbOutbox)
+		assetRead := err == nil
+		if err == nil {
+			err = performOutboxOperation(ctx, config, dbOutbox, asset)
+		}
+		if err == nil {
+			if err := app.SetOutboxOperationDone(ctx, dbOutbox); err != nil {
+				log.Error("app", "%v", err)
+			}
+			continue
+		}
+
+		blocked[dbOutbox.DevEui] = true
+		attempts := dbOutbox.Attempts + 1
+		dead := attempts >= outboxMaxAttempts
+		log.Error("loriot", "Error performing %s operation %d for device %s (attempt %d): %v", dbOutbox.Operation, dbOutbox.ID, dbOutbox.DevEui, attempts, err)
+		if err := app.SetOutboxOperationFailed(ctx, dbOutbox, err, now.Add(outboxBackoff(attempts)), dead); err != nil {
+			log.Error("app", "%v", err)
+		}
+		// Without the asset the project of the notification is unknown. The failure is logged above.
+		if dead && assetRead {
+			app.NotifyUser(config.UserId, &asset.ProjectId, outboxDeadNotification(dbOutbox))
+		}
+	}
+}
+
+func performOutboxOperation(ctx context.Context, config apiserver.Configuration, dbOutbox *appdb.Outbox, asset api.Asset) error {
+	var device *loriot.Device
+	var statusCode int32
+	var err error
+	switch dbOutbox.Operation {
+	case app.OutboxOperationUpdate:
+		statusCode = http.StatusOK
+		device, err = loriot.UpdateDevice(ctx, config, dbOutbox.DevEui, asset)
+	case app.OutboxOperationDelete:
+		statusCode = http.StatusNoContent
+		device, err = loriot.DeleteDevice(ctx, config, dbOutbox.DevEui)
+	default:
+		return fmt.Errorf("unknown operation %s", dbOutbox.Operation)
+	}
+	if err != nil {
+		return err
+	}
+	if device == nil {
+		log.Warn("loriot", "Device %s for operation %d not found. Changes from Eliona are ignored", dbOutbox.DevEui, statusCode)
+		return nil
+	}
+	app.NotifyUser(config.UserId, &asset.ProjectId, outboxDoneNotification(dbOutbox))
+	log.Info("loriot", "Device %s operation %d successfully performed.", dbOutbox.DevEui, statusCode)
+	if _, err := app.UpsertDeviceAsset(ctx, config, *device, asset, statusCode, nil); err != nil {
+		log.Error("app", "Error updating app's device database for operation %d for device %s: %v", statusCode, dbOutbox.DevEui, err)
+	}
+	return nil
+}
+
+// outboxBackoff returns the delay before the next attempt after the given number of failed attempts.
+func outboxBackoff(attempts int32) time.Duration {
+	backoff := outboxInitialBackoff
+	for i := int32(1); i < attempts && backoff < outboxMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, outboxMaxBackoff)
+}
+
+func outboxDoneNotification(dbOutbox *appdb.Outbox) *api.Translation {
+	if dbOutbox.Operation == app.OutboxOperationDelete {
+		return &api.Translation{
+			De: api.PtrString(fmt.Sprintf("Loriot App hat Gerät '%s' und Asset '%d' gelöscht.", dbOutbox.DevEui, dbOutbox.AssetID)),
+			En: api.PtrString(fmt.Sprintf("Loriot app deleted device '%s' and asset '%d'.", dbOutbox.DevEui, dbOutbox.AssetID)),
+		}
+	}
+	return &api.Translation{
+		De: api.PtrString(fmt.Sprintf("Loriot App hat Gerät '%s' und Asset '%d' geändert.", dbOutbox.DevEui, dbOutbox.AssetID)),
+		En: api.PtrString(fmt.Sprintf("Loriot app updated device '%s' and asset '%d'.", dbOutbox.DevEui, dbOutbox.AssetID)),
+	}
+}
+
+func outboxDeadNotification(dbOutbox *appdb.Outbox) *api.Translation {
+	if dbOutbox.Operation == app.OutboxOperationDelete {
+		return &api.Translation{
+			De: api.PtrString(fmt.Sprintf("Loriot App konnte Gerät '%s' von Asset '%d' nicht löschen: %s", dbOutbox.DevEui, dbOutbox.AssetID, dbOutbox.LastError.String)),
+			En: api.PtrString(fmt.Sprintf("Loriot app failed to delete device '%s' of asset '%d': %s", dbOutbox.DevEui, dbOutbox.AssetID, dbOutbox.LastError.String)),
+		}
+	}
+	return &api.Translation{
+		De: api.PtrString(fmt.Sprintf("Loriot App konnte Gerät '%s' von Asset '%d' nicht ändern: %s", dbOutbox.DevEui, dbOutbox.AssetID, dbOutbox.LastError.String)),
+		En: api.PtrString(fmt.Sprintf("Loriot app failed to update device '%s' of asset '%d': %s", dbOutbox.DevEui, dbOutbox.AssetID, dbOutbox.LastError.String)),
+	}
+}
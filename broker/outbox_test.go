@@ -0,0 +1,48 @@
+package broker
+
+import (
+	"loriot-io/app"
+	"loriot-io/appdb"
+	"testing"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+func TestOutboxBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int32
+		want     time.Duration
+	}{
+		{1, 10 * time.Second},
+		{2, 20 * time.Second},
+		{4, 80 * time.Second},
+		{20, time.Hour},
+	}
+	for _, tt := range tests {
+		if got := outboxBackoff(tt.attempts); got != tt.want {
+			t.Errorf("outboxBackoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestOutboxAsset(t *testing.T) {
+	asset := api.Asset{
+		Id:        *api.NewNullableInt32(common.Ptr(int32(42))),
+		ProjectId: "10",
+		Name:      *api.NewNullableString(common.Ptr("Renamed")),
+		DeviceIds: []string{"BE7A000000000001"},
+	}
+	var dbOutbox appdb.Outbox
+	if err := dbOutbox.Asset.Marshal(asset); err != nil {
+		t.Fatal(err)
+	}
+	got, err := app.OutboxAsset(&dbOutbox)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *got.Id.Get() != 42 || got.ProjectId != "10" || *got.Name.Get() != "Renamed" || got.Description.IsSet() && got.Description.Get() != nil {
+		t.Errorf("OutboxAsset() = %+v", got)
+	}
+}
@@ -0,0 +1,125 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package broker
+
+import (
+	"context"
+	"fmt"
+	"loriot-io/apiserver"
+	"loriot-io/app"
+	"loriot-io/eliona"
+	"loriot-io/loriot"
+	"loriot-io/secret"
+)
+
+// PlanDevice returns the steps UpsertDevice would perform for the request, without changing anything. The keys of the
+// request are validated against its activation mode if the device would be created, and rejected if it exists.
+func PlanDevice(ctx context.Context, putDeviceRequest apiserver.PutDeviceRequest) (*apiserver.PutDevicePlan, error) {
+	configs, err := targetConfigs(ctx, putDeviceRequest)
+	if err != nil {
+		return nil, err
+	}
+	if putDeviceRequest.GenerateKeys {
+		// Generated values only show the plan, they are neither stored nor returned
+		if err := generateDeviceKeys(ctx, configs, &putDeviceRequest); err != nil {
+			return nil, err
+		}
+	}
+	if !loriot.IsValidEUI(&putDeviceRequest.DevEUI) {
+		return nil, fmt.Errorf("%w: invalid device EUI: %s", app.ErrBadRequest, putDeviceRequest.DevEUI)
+	}
+	routes, err := routeDevice(configs, putDeviceRequest)
+	if err != nil {
+		return nil, err
+	}
+	activation, activationErr := loriot.ValidateActivation(putDeviceRequest)
+	plan := apiserver.PutDevicePlan{
+		Activation: activation,
+	}
+
+	for _, route := range routes {
+		config := route.config
+		device, err := loriot.GetDevice(ctx, config, putDeviceRequest.AppID, putDeviceRequest.DevEUI)
+		if err != nil {
+			return nil, err
+		}
+		step := apiserver.PutDeviceStep{ConfigID: config.Id, Step: StepLoriotDevice, Status: StepUpdated}
+		if device != nil && hasDeviceKeys(deviceKeys(putDeviceRequest)) {
+			return nil, fmt.Errorf("%w: device %s already exists in Loriot.io, its keys cannot be changed", app.ErrConflict, putDeviceRequest.DevEUI)
+		}
+		if device == nil {
+			if activationErr != nil {
+				return nil, fmt.Errorf("%w: %v", app.ErrBadRequest, activationErr)
+			}
+			step.Status = StepCreated
+		}
+		plan.Steps = append(plan.Steps, step)
+
+		for _, projectID := range route.projectIDs {
+			projectID := projectID
+			steps, err := planProjectAssets(config, projectID, putDeviceRequest.DevEUI)
+			if err != nil {
+				return nil, err
+			}
+			plan.Steps = append(plan.Steps, steps...)
+		}
+	}
+	if hasDeviceKeys(deviceKeys(putDeviceRequest)) && secret.Configured() {
+		stored, err := app.HasDeviceKeys(ctx, putDeviceRequest.DevEUI)
+		if err != nil {
+			return nil, err
+		}
+		step := apiserver.PutDeviceStep{Step: StepKeyVault, Status: StepCreated}
+		if stored {
+			step.Status = StepUpdated
+		}
+		plan.Steps = append(plan.Steps, step)
+	}
+	return &plan, nil
+}
+
+// planProjectAssets returns the steps performed for the assets of the device in the project.
+func planProjectAssets(config apiserver.Configuration, projectID string, devEUI string) ([]apiserver.PutDeviceStep, error) {
+	rootStep := apiserver.PutDeviceStep{ConfigID: config.Id, ProjectID: &projectID, Step: StepRootAsset, Status: StepCreated}
+	rootAsset, err := eliona.GetRootAsset(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if rootAsset != nil {
+		rootStep.Status = StepUnchanged
+		rootStep.AssetID = rootAsset.Id.Get()
+	}
+
+	assetStep := apiserver.PutDeviceStep{ConfigID: config.Id, ProjectID: &projectID, Step: StepDeviceAsset, Status: StepCreated}
+	appStep := apiserver.PutDeviceStep{ConfigID: config.Id, ProjectID: &projectID, Step: StepAppAsset, Status: StepCreated}
+	asset, err := eliona.GetAssetByDeviceId(projectID, devEUI)
+	if err != nil {
+		return nil, err
+	}
+	if asset != nil {
+		assetStep.Status = StepUpdated
+		assetStep.AssetID = asset.Id.Get()
+		appStep.AssetID = asset.Id.Get()
+		dbAsset, err := app.GetDbDeviceAssetById(asset.Id.Get())
+		if err != nil {
+			return nil, err
+		}
+		if dbAsset != nil {
+			appStep.Status = StepUpdated
+		}
+	}
+	return []apiserver.PutDeviceStep{rootStep, assetStep, appStep}, nil
+}
@@ -0,0 +1,202 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package broker
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"loriot-io/apiserver"
+	"loriot-io/app"
+	"loriot-io/appdb"
+	"loriot-io/loriot"
+	"loriot-io/secret"
+	"strings"
+
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+	"github.com/volatiletech/null/v8"
+)
+
+// keyBatchColumns are the columns of a key batch CSV file. Only devEUI and appKey are required.
+var keyBatchColumns = []string{"devEUI", "joinEUI", "appKey", "nwkKey", "ownerToken"}
+
+// ProvisionQRCode creates or updates the device scanned from its TR005 QR code. The keys of the device are taken
+// from the loaded key batches. The device is created with OTAA 1.1 if a network key is loaded, otherwise with
+// OTAA 1.0.
+func ProvisionQRCode(ctx context.Context, qrCodeRequest apiserver.QrCodeRequest) ([]apiserver.DeviceAsset, []apiserver.PutDeviceStep, error) {
+	qr, err := loriot.ParseQRCode(qrCodeRequest.QrCode)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", app.ErrBadRequest, err)
+	}
+	dbKey, err := app.GetDeviceKey(ctx, qr.DevEUI)
+	if err != nil {
+		return nil, nil, err
+	}
+	if dbKey == nil {
+		return nil, nil, fmt.Errorf("%w: no keys loaded for device %s", app.ErrBadRequest, qr.DevEUI)
+	}
+	if dbKey.JoinEui.Valid && !strings.EqualFold(dbKey.JoinEui.String, qr.JoinEUI) {
+		return nil, nil, fmt.Errorf("%w: JoinEUI %s of the QR code doesn't match the loaded keys of device %s", app.ErrBadRequest, qr.JoinEUI, qr.DevEUI)
+	}
+	if dbKey.OwnerToken.Valid && dbKey.OwnerToken.String != qr.OwnerToken {
+		return nil, nil, fmt.Errorf("%w: owner token of the QR code doesn't match the loaded keys of device %s", app.ErrBadRequest, qr.DevEUI)
+	}
+
+	putDeviceRequest := putDeviceRequestFromQRCode(*qr, *dbKey, qrCodeRequest)
+	if _, err := loriot.ValidateActivation(putDeviceRequest); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", app.ErrBadRequest, err)
+	}
+	deviceAssets, steps, err := UpsertDevice(ctx, putDeviceRequest)
+	if err != nil || deviceAssets == nil {
+		return deviceAssets, steps, err
+	}
+	if err := app.SetDeviceKeyProvisioned(ctx, dbKey); err != nil {
+		log.Error("app", "Error marking keys of device %s as provisioned: %v", qr.DevEUI, err)
+	}
+	return deviceAssets, steps, nil
+}
+
+func putDeviceRequestFromQRCode(qr loriot.QRCode, dbKey appdb.DeviceKey, qrCodeRequest apiserver.QrCodeRequest) apiserver.PutDeviceRequest {
+	putDeviceRequest := apiserver.PutDeviceRequest{
+		DevEUI:        qr.DevEUI,
+		AppID:         qrCodeRequest.AppID,
+		AssetTypeName: qrCodeRequest.AssetTypeName,
+		ConfigID:      qrCodeRequest.ConfigID,
+		Title:         qrCodeRequest.Title,
+		Description:   qrCodeRequest.Description,
+		AppKey:        strings.ToUpper(dbKey.AppKey),
+	}
+	if putDeviceRequest.Title == "" {
+		putDeviceRequest.Title = qr.SerialNumber
+	}
+	if putDeviceRequest.Title == "" {
+		putDeviceRequest.Title = qr.DevEUI
+	}
+	if dbKey.NWKKey.Valid {
+		putDeviceRequest.JoinEUI = qr.JoinEUI
+		putDeviceRequest.NwkKey = strings.ToUpper(dbKey.NWKKey.String)
+	} else {
+		putDeviceRequest.AppEUI = qr.JoinEUI
+	}
+	return putDeviceRequest
+}
+
+// LoadKeyBatch validates and remembers the device keys of the uploaded CSV file. The header of the file names the
+// columns devEUI, joinEUI, appKey, nwkKey and ownerToken. The keys are stored encrypted, so the vault must be
+// configured.
+func LoadKeyBatch(ctx context.Context, name string, file io.Reader) (*apiserver.KeyBatch, error) {
+	if name == "" {
+		return nil, fmt.Errorf("%w: name of key batch is required", app.ErrBadRequest)
+	}
+	if !secret.Configured() {
+		return nil, fmt.Errorf("%w: loading key batches requires the key vault: %v", app.ErrBadRequest, secret.ErrNotConfigured)
+	}
+	dbKeys, err := parseKeyBatch(file)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", app.ErrBadRequest, err)
+	}
+	batch, err := app.InsertKeyBatch(ctx, name, dbKeys)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("app", "Loaded key batch %s with keys of %d devices", name, len(dbKeys))
+	return batch, nil
+}
+
+func parseKeyBatch(file io.Reader) ([]appdb.DeviceKey, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading key batch: %v", err)
+	}
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimSpace(data)))
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parsing key batch: %v", err)
+	}
+	if len(records) < 2 {
+		return nil, fmt.Errorf("key batch contains no devices")
+	}
+	columns := make(map[string]int)
+	for idx, name := range records[0] {
+		name = strings.TrimSpace(name)
+		if !sliceContains(keyBatchColumns, name) {
+			return nil, fmt.Errorf("unknown column %s", name)
+		}
+		columns[name] = idx
+	}
+	value := func(record []string, name string) string {
+		if idx, ok := columns[name]; ok {
+			return strings.TrimSpace(record[idx])
+		}
+		return ""
+	}
+
+	var dbKeys []appdb.DeviceKey
+	var errs []error
+	rowsByEUI := make(map[string]int)
+	for idx, record := range records[1:] {
+		row := idx + 1
+		request := apiserver.PutDeviceRequest{
+			DevEUI:  strings.ToUpper(value(record, "devEUI")),
+			AppEUI:  strings.ToUpper(value(record, "joinEUI")),
+			JoinEUI: strings.ToUpper(value(record, "joinEUI")),
+			AppKey:  strings.ToUpper(value(record, "appKey")),
+			NwkKey:  strings.ToUpper(value(record, "nwkKey")),
+		}
+		if request.AppEUI == "" {
+			// The JoinEUI is taken from the QR code if not loaded
+			request.AppEUI = "0000000000000000"
+			request.JoinEUI = request.AppEUI
+		}
+		if !loriot.IsValidEUI(&request.DevEUI) {
+			errs = append(errs, fmt.Errorf("row %d: invalid device EUI: %s", row, request.DevEUI))
+			continue
+		}
+		if request.NwkKey == "" {
+			request.JoinEUI = ""
+		} else {
+			request.AppEUI = ""
+		}
+		if _, err := loriot.ValidateActivation(request); err != nil {
+			errs = append(errs, fmt.Errorf("row %d: %v", row, err))
+			continue
+		}
+		if first, ok := rowsByEUI[request.DevEUI]; ok {
+			errs = append(errs, fmt.Errorf("row %d: device EUI %s already in row %d", row, request.DevEUI, first))
+			continue
+		}
+		rowsByEUI[request.DevEUI] = row
+		dbKeys = append(dbKeys, appdb.DeviceKey{
+			DevEui:     request.DevEUI,
+			JoinEui:    nullString(strings.ToUpper(value(record, "joinEUI"))),
+			AppKey:     request.AppKey,
+			NWKKey:     nullString(request.NwkKey),
+			OwnerToken: nullString(value(record, "ownerToken")),
+		})
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return dbKeys, nil
+}
+
+func nullString(s string) null.String {
+	return null.NewString(s, s != "")
+}
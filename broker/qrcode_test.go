@@ -0,0 +1,73 @@
+package broker
+
+import (
+	"loriot-io/apiserver"
+	"loriot-io/appdb"
+	"loriot-io/loriot"
+	"strings"
+	"testing"
+
+	"github.com/volatiletech/null/v8"
+)
+
+func TestParseKeyBatch(t *testing.T) {
+	csv := `devEUI,joinEUI,appKey,nwkKey,ownerToken
+0004a30b001c0530,70B3D57ED0000000,00112233445566778899AABBCCDDEEFF,,AABB1122
+0004A30B001C0531,,00112233445566778899AABBCCDDEEFF,FFEEDDCCBBAA99887766554433221100,
+`
+	dbKeys, err := parseKeyBatch(strings.NewReader(csv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dbKeys) != 2 {
+		t.Fatalf("got %d keys, want 2", len(dbKeys))
+	}
+	if dbKeys[0].DevEui != "0004A30B001C0530" || dbKeys[0].JoinEui.String != "70B3D57ED0000000" || dbKeys[0].OwnerToken.String != "AABB1122" || dbKeys[0].NWKKey.Valid {
+		t.Errorf("unexpected first keys: %+v", dbKeys[0])
+	}
+	if dbKeys[1].JoinEui.Valid || dbKeys[1].OwnerToken.Valid || dbKeys[1].NWKKey.String != "FFEEDDCCBBAA99887766554433221100" {
+		t.Errorf("unexpected second keys: %+v", dbKeys[1])
+	}
+}
+
+func TestParseKeyBatchInvalidRows(t *testing.T) {
+	csv := `devEUI,appKey
+0004A30B001C0530,00112233
+XYZ,00112233445566778899AABBCCDDEEFF
+0004A30B001C0531,00112233445566778899AABBCCDDEEFF
+0004A30B001C0531,00112233445566778899AABBCCDDEEFF
+`
+	_, err := parseKeyBatch(strings.NewReader(csv))
+	if err == nil {
+		t.Fatal("expected error for invalid rows")
+	}
+	for _, row := range []string{"row 1:", "row 2:", "row 4:"} {
+		if !strings.Contains(err.Error(), row) {
+			t.Errorf("error %q doesn't report %s", err, row)
+		}
+	}
+}
+
+func TestPutDeviceRequestFromQRCode(t *testing.T) {
+	qr := loriot.QRCode{JoinEUI: "70B3D57ED0000000", DevEUI: "0004A30B001C0530", SerialNumber: "ABC123"}
+	request := apiserver.QrCodeRequest{AppID: "BE7A0001", AssetTypeName: "loriot_io_cayenne_lpp"}
+
+	otaa10 := putDeviceRequestFromQRCode(qr, appdb.DeviceKey{AppKey: "00112233445566778899aabbccddeeff"}, request)
+	if otaa10.AppEUI != qr.JoinEUI || otaa10.JoinEUI != "" || otaa10.AppKey != "00112233445566778899AABBCCDDEEFF" || otaa10.Title != "ABC123" {
+		t.Errorf("unexpected OTAA 1.0 request: %+v", otaa10)
+	}
+	if activation, err := loriot.ValidateActivation(otaa10); err != nil || activation != loriot.ActivationOTAA10 {
+		t.Errorf("OTAA 1.0 request: activation %v, error %v", activation, err)
+	}
+
+	otaa11 := putDeviceRequestFromQRCode(qr, appdb.DeviceKey{
+		AppKey: "00112233445566778899AABBCCDDEEFF",
+		NWKKey: null.StringFrom("FFEEDDCCBBAA99887766554433221100"),
+	}, request)
+	if otaa11.JoinEUI != qr.JoinEUI || otaa11.AppEUI != "" || otaa11.NwkKey == "" {
+		t.Errorf("unexpected OTAA 1.1 request: %+v", otaa11)
+	}
+	if activation, err := loriot.ValidateActivation(otaa11); err != nil || activation != loriot.ActivationOTAA11 {
+		t.Errorf("OTAA 1.1 request: activation %v, error %v", activation, err)
+	}
+}
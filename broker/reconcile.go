@@ -0,0 +1,379 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"loriot-io/apiserver"
+	"loriot-io/app"
+	"loriot-io/appdb"
+	"loriot-io/eliona"
+	"loriot-io/loriot"
+	"net/http"
+	"strings"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// Kinds of drift between the devices in Loriot.io, the assets in Eliona and the device assets of the app.
+const (
+	DriftAssetMissing        = "asset_missing"
+	DriftDeviceMissing       = "device_missing"
+	DriftOrphaned            = "orphaned"
+	DriftDeletedDeviceExists = "deleted_device_exists"
+	DriftUntrackedAsset      = "untracked_asset"
+	DriftUntrackedDevice     = "untracked_device"
+)
+
+// Actions fixing a drift.
+const (
+	DriftActionNone          = "none"
+	DriftActionMarkDeleted   = "mark_deleted"
+	DriftActionDeleteDevice  = "delete_device"
+	DriftActionDeleteAsset   = "delete_asset"
+	DriftActionRecreateAsset = "recreate_asset"
+	DriftActionAdoptAsset    = "adopt_asset"
+)
+
+// driftActions defines the action fixing each kind of drift by reconcile policy. Kinds missing for a policy are only
+// reported. Devices missing in Loriot.io cannot be re-created because their keys are unknown, and devices without any
+// asset are left to the discovery by default asset type.
+var driftActions = map[string]map[string]string{
+	DriftAssetMissing: {
+		app.ReconcilePolicyAdopt:    DriftActionMarkDeleted,
+		app.ReconcilePolicyDelete:   DriftActionDeleteDevice,
+		app.ReconcilePolicyRecreate: DriftActionRecreateAsset,
+	},
+	DriftDeviceMissing: {
+		app.ReconcilePolicyAdopt:  DriftActionMarkDeleted,
+		app.ReconcilePolicyDelete: DriftActionDeleteAsset,
+	},
+	DriftOrphaned: {
+		app.ReconcilePolicyAdopt:    DriftActionMarkDeleted,
+		app.ReconcilePolicyDelete:   DriftActionMarkDeleted,
+		app.ReconcilePolicyRecreate: DriftActionMarkDeleted,
+	},
+	DriftDeletedDeviceExists: {
+		app.ReconcilePolicyAdopt:    DriftActionRecreateAsset,
+		app.ReconcilePolicyDelete:   DriftActionDeleteDevice,
+		app.ReconcilePolicyRecreate: DriftActionRecreateAsset,
+	},
+	DriftUntrackedAsset: {
+		app.ReconcilePolicyAdopt:    DriftActionAdoptAsset,
+		app.ReconcilePolicyRecreate: DriftActionAdoptAsset,
+	},
+}
+
+type drift struct {
+	kind      string
+	devEUI    string
+	projectID string
+	device    *loriot.Device
+	asset     *api.Asset
+	dbAsset   *appdb.Asset
+}
+
+type deviceKey struct {
+	projectID string
+	devEUI    string
+}
+
+// GetDrift compares the devices in Loriot.io, the assets in Eliona and the device assets of the app for the
+// configuration. Returns the drift with the actions the reconcile policy of the configuration would perform.
+func GetDrift(ctx context.Context, config apiserver.Configuration) (apiserver.DriftReport, error) {
+	snapshot := time.Now()
+	devices, err := getAllDevices(ctx, config)
+	if err != nil {
+		return apiserver.DriftReport{}, err
+	}
+	return reconcile(ctx, config, devices, snapshot, false)
+}
+
+// Reconcile compares like GetDrift and fixes the drift according to the reconcile policy of the configuration.
+func Reconcile(ctx context.Context, config apiserver.Configuration) (apiserver.DriftReport, error) {
+	snapshot := time.Now()
+	devices, err := getAllDevices(ctx, config)
+	if err != nil {
+		return apiserver.DriftReport{}, err
+	}
+	return reconcile(ctx, config, devices, snapshot, true)
+}
+
+// reconcile detects the drift of the devices fetched at the snapshot time and fixes it if requested. Device assets of
+// the app modified after the snapshot are skipped, because the devices and assets are fetched one after another and
+// may not reflect changes made in between.
+func reconcile(ctx context.Context, config apiserver.Configuration, devices []loriot.Device, snapshot time.Time, fix bool) (apiserver.DriftReport, error) {
+	policy := reconcilePolicy(config)
+	report := apiserver.DriftReport{
+		ConfigID:  *config.Id,
+		Policy:    policy,
+		Fixed:     fix && policy != app.ReconcilePolicyReport,
+		CheckedAt: time.Now(),
+		Items:     []apiserver.DriftItem{},
+	}
+	var assets []api.Asset
+	for _, projectID := range app.ProjIds(config) {
+		projectAssets, err := eliona.GetDeviceAssets(projectID)
+		if err != nil {
+			return report, err
+		}
+		assets = append(assets, projectAssets...)
+	}
+	dbAssets, err := app.GetDbDeviceAssets(ctx, *config.Id)
+	if err != nil {
+		return report, err
+	}
+
+	for _, d := range detectDrift(config, devices, assets, dbAssets) {
+		action := driftAction(d.kind, policy)
+		item := apiserver.DriftItem{
+			Kind:      d.kind,
+			DevEUI:    d.devEUI,
+			ProjectID: common.Ptr(d.projectID),
+			Action:    action,
+		}
+		if d.device != nil {
+			item.AppID = common.Ptr(d.device.AppID)
+		}
+		if d.dbAsset != nil {
+			item.AppID = common.Ptr(d.dbAsset.AppID)
+			item.AssetID = common.Ptr(d.dbAsset.AssetID)
+		} else if d.asset != nil {
+			item.AssetID = d.asset.Id.Get()
+		}
+		if report.Fixed && action != DriftActionNone {
+			if err := fixDrift(ctx, config, d, action, snapshot); err != nil {
+				log.Error("loriot", "Error fixing drift %s of device %s in project %s: %v", d.kind, d.devEUI, d.projectID, err)
+				item.Error = common.Ptr(err.Error())
+			} else {
+				log.Info("loriot", "Fixed drift %s of device %s in project %s: %s", d.kind, d.devEUI, d.projectID, action)
+			}
+		}
+		report.Items = append(report.Items, item)
+	}
+	return report, nil
+}
+
+// detectDrift compares the devices, the Eliona assets having device IDs and the device assets of the app within the
+// projects of the configuration. Devices are only expected in the projects they are routed to.
+func detectDrift(config apiserver.Configuration, devices []loriot.Device, assets []api.Asset, dbAssets []*appdb.Asset) []drift {
+	projectIDs := app.ProjIds(config)
+	devicesByEUI := make(map[string]*loriot.Device)
+	for i := range devices {
+		devicesByEUI[strings.ToUpper(devices[i].DevEUI)] = &devices[i]
+	}
+	assetIDs := make(map[int32]bool)
+	assetKeys := make(map[deviceKey]bool)
+	for _, asset := range assets {
+		if asset.Id.Get() == nil {
+			continue
+		}
+		assetIDs[*asset.Id.Get()] = true
+		for _, deviceID := range asset.DeviceIds {
+			assetKeys[deviceKey{asset.ProjectId, strings.ToUpper(deviceID)}] = true
+		}
+	}
+	dbAssetIDs := make(map[int32]bool)
+	dbAssetKeys := make(map[deviceKey]bool)
+	activeKeys := make(map[deviceKey]bool)
+	for _, dbAsset := range dbAssets {
+		key := deviceKey{dbAsset.ProjectID, strings.ToUpper(dbAsset.DevEui)}
+		dbAssetIDs[dbAsset.AssetID] = true
+		dbAssetKeys[key] = true
+		if !isDeleted(dbAsset) {
+			activeKeys[key] = true
+		}
+	}
+
+	var drifts []drift
+	deletedKeys := make(map[deviceKey]bool)
+	for _, dbAsset := range dbAssets {
+		if !sliceContains(projectIDs, dbAsset.ProjectID) {
+			continue
+		}
+		key := deviceKey{dbAsset.ProjectID, strings.ToUpper(dbAsset.DevEui)}
+		device := devicesByEUI[key.devEUI]
+		d := drift{devEUI: key.devEUI, projectID: dbAsset.ProjectID, device: device, dbAsset: dbAsset}
+		if isDeleted(dbAsset) {
+			// Only the latest deleted asset of a device is considered
+			if device != nil && !activeKeys[key] && !assetKeys[key] && !deletedKeys[key] {
+				d.kind = DriftDeletedDeviceExists
+				drifts = append(drifts, d)
+			}
+			deletedKeys[key] = true
+			continue
+		}
+		switch assetExists := assetIDs[dbAsset.AssetID]; {
+		case !assetExists && device == nil:
+			d.kind = DriftOrphaned
+		case !assetExists:
+			d.kind = DriftAssetMissing
+		case device == nil:
+			d.kind = DriftDeviceMissing
+		default:
+			continue
+		}
+		drifts = append(drifts, d)
+	}
+
+	for i := range assets {
+		asset := &assets[i]
+		if asset.Id.Get() == nil || dbAssetIDs[*asset.Id.Get()] || !sliceContains(projectIDs, asset.ProjectId) {
+			continue
+		}
+		for _, deviceID := range asset.DeviceIds {
+			if device := devicesByEUI[strings.ToUpper(deviceID)]; device != nil {
+				drifts = append(drifts, drift{kind: DriftUntrackedAsset, devEUI: strings.ToUpper(deviceID), projectID: asset.ProjectId, device: device, asset: asset})
+				break
+			}
+		}
+	}
+
+	for i := range devices {
+		devEUI := strings.ToUpper(devices[i].DevEUI)
+		routedProjectIDs, _, _ := app.RouteDevice(config, devices[i].AppID, devices[i].DevEUI, devices[i].Title)
+		for _, projectID := range routedProjectIDs {
+			key := deviceKey{projectID, devEUI}
+			if !dbAssetKeys[key] && !assetKeys[key] {
+				drifts = append(drifts, drift{kind: DriftUntrackedDevice, devEUI: devEUI, projectID: projectID, device: &devices[i]})
+			}
+		}
+	}
+	return drifts
+}
+
+// errDriftChanged is returned if the drift changed after it was detected, so it isn't fixed.
+var errDriftChanged = errors.New("changed since the drift was detected, skipped")
+
+func fixDrift(ctx context.Context, config apiserver.Configuration, d drift, action string, snapshot time.Time) error {
+	if d.dbAsset != nil && d.dbAsset.ModifiedAt.Valid && d.dbAsset.ModifiedAt.Time.After(snapshot) {
+		return errDriftChanged
+	}
+	switch action {
+	case DriftActionMarkDeleted:
+		return app.SetDeviceAssetStatusCode(ctx, d.dbAsset, http.StatusNoContent)
+	case DriftActionDeleteDevice:
+		if err := confirmDrift(ctx, config, d, snapshot); err != nil {
+			return err
+		}
+		if _, err := loriot.DeleteDevice(ctx, config, d.devEUI); err != nil {
+			return err
+		}
+		if isDeleted(d.dbAsset) {
+			return nil
+		}
+		return app.SetDeviceAssetStatusCode(ctx, d.dbAsset, http.StatusNoContent)
+	case DriftActionDeleteAsset:
+		if err := confirmDrift(ctx, config, d, snapshot); err != nil {
+			return err
+		}
+		if err := eliona.DeleteAsset(d.dbAsset.AssetID); err != nil {
+			return err
+		}
+		return app.SetDeviceAssetStatusCode(ctx, d.dbAsset, http.StatusNoContent)
+	case DriftActionRecreateAsset:
+		assetType := d.dbAsset.AssetType.String
+		if assetType == "" {
+			_, assetType, _ = app.RouteDevice(config, d.device.AppID, d.device.DevEUI, d.device.Title)
+		}
+		if assetType == "" {
+			return fmt.Errorf("unknown asset type for re-creating asset of device %s", d.devEUI)
+		}
+		asset, err := eliona.UpsertAssetWithDevice(ctx, d.projectID, *d.device, assetType)
+		if err != nil {
+			return fmt.Errorf("re-creating asset of device %s: %w", d.devEUI, err)
+		}
+		if asset == nil {
+			return fmt.Errorf("no asset re-created for device %s", d.devEUI)
+		}
+		return app.ReplaceDeviceAsset(ctx, d.dbAsset, *asset)
+	case DriftActionAdoptAsset:
+		_, err := app.UpsertDeviceAsset(ctx, config, *d.device, *d.asset, http.StatusOK, nil)
+		return err
+	}
+	return nil
+}
+
+// confirmDrift fetches the device asset of the app and the missing asset or device again right before a deletion.
+// Returns errDriftChanged if the device asset was modified after the snapshot or the asset or device exists again.
+func confirmDrift(ctx context.Context, config apiserver.Configuration, d drift, snapshot time.Time) error {
+	dbAsset, err := app.GetDbDeviceAssetById(&d.dbAsset.AssetID)
+	if err != nil {
+		return err
+	}
+	if dbAsset == nil || (dbAsset.ModifiedAt.Valid && dbAsset.ModifiedAt.Time.After(snapshot)) {
+		return errDriftChanged
+	}
+	switch d.kind {
+	case DriftAssetMissing, DriftDeletedDeviceExists:
+		asset, err := eliona.GetAssetByDeviceId(d.projectID, d.devEUI)
+		if err != nil {
+			return fmt.Errorf("fetching asset of device %s: %w", d.devEUI, err)
+		}
+		if asset != nil {
+			return errDriftChanged
+		}
+	case DriftDeviceMissing:
+		device, err := loriot.GetDevice(ctx, config, d.dbAsset.AppID, d.devEUI)
+		if err != nil {
+			return fmt.Errorf("fetching device %s: %w", d.devEUI, err)
+		}
+		if device != nil {
+			return errDriftChanged
+		}
+	}
+	return nil
+}
+
+func driftAction(kind string, policy string) string {
+	if action, ok := driftActions[kind][policy]; ok {
+		return action
+	}
+	return DriftActionNone
+}
+
+func reconcilePolicy(config apiserver.Configuration) string {
+	if config.ReconcilePolicy == nil || *config.ReconcilePolicy == "" {
+		return app.ReconcilePolicyReport
+	}
+	return *config.ReconcilePolicy
+}
+
+func isDeleted(dbAsset *appdb.Asset) bool {
+	return dbAsset.LatestStatusCode.Valid && dbAsset.LatestStatusCode.Int32 == http.StatusNoContent
+}
+
+// getAllDevices returns the devices of all applications of the configuration.
+func getAllDevices(ctx context.Context, config apiserver.Configuration) ([]loriot.Device, error) {
+	apps, err := loriot.GetApps(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("getting applications: %w", err)
+	}
+	var allDevices []loriot.Device
+	for _, loriotApp := range apps {
+		devices, err := loriot.GetDevices(ctx, config, loriotApp.AppHexID)
+		if err != nil {
+			return nil, fmt.Errorf("getting devices of application %s: %w", loriotApp.AppHexID, err)
+		}
+		allDevices = append(allDevices, devices...)
+	}
+	return allDevices, nil
+}
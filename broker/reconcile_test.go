@@ -0,0 +1,104 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"loriot-io/apiserver"
+	"loriot-io/app"
+	"loriot-io/appdb"
+	"loriot-io/loriot"
+	"net/http"
+	"testing"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/volatiletech/null/v8"
+)
+
+func TestDetectDrift(t *testing.T) {
+	devices := []loriot.Device{
+		{DevEUI: "be7a000000000001"},
+		{DevEUI: "BE7A000000000002"},
+		{DevEUI: "BE7A000000000003"},
+		{DevEUI: "BE7A000000000004"},
+		{DevEUI: "BE7A000000000005"},
+	}
+	asset := func(id int32, projectID string, devEUI string) api.Asset {
+		return api.Asset{Id: *api.NewNullableInt32(common.Ptr(id)), ProjectId: projectID, DeviceIds: []string{devEUI}}
+	}
+	assets := []api.Asset{
+		asset(1, "10", "BE7A000000000001"),
+		asset(3, "10", "BE7A000000000009"),
+		asset(5, "10", "BE7A000000000005"),
+	}
+	dbAsset := func(id int32, projectID string, devEUI string, statusCode int32) *appdb.Asset {
+		return &appdb.Asset{AssetID: id, ProjectID: projectID, DevEui: devEUI, LatestStatusCode: null.Int32From(statusCode)}
+	}
+	dbAssets := []*appdb.Asset{
+		dbAsset(1, "10", "BE7A000000000001", http.StatusCreated),
+		dbAsset(2, "10", "BE7A000000000002", http.StatusCreated),
+		dbAsset(3, "10", "BE7A000000000009", http.StatusOK),
+		dbAsset(4, "10", "BE7A000000000008", http.StatusOK),
+		dbAsset(6, "10", "BE7A000000000003", http.StatusNoContent),
+		dbAsset(7, "10", "BE7A000000000003", http.StatusNoContent),
+		dbAsset(8, "99", "BE7A000000000001", http.StatusOK),
+	}
+
+	got := make(map[string][]string)
+	for _, d := range detectDrift(apiserver.Configuration{ProjectIDs: &[]string{"10"}}, devices, assets, dbAssets) {
+		got[d.kind] = append(got[d.kind], d.devEUI)
+	}
+	want := map[string][]string{
+		DriftAssetMissing:        {"BE7A000000000002"},
+		DriftDeviceMissing:       {"BE7A000000000009"},
+		DriftOrphaned:            {"BE7A000000000008"},
+		DriftDeletedDeviceExists: {"BE7A000000000003"},
+		DriftUntrackedAsset:      {"BE7A000000000005"},
+		DriftUntrackedDevice:     {"BE7A000000000004"},
+	}
+	if len(got) != len(want) {
+		t.Errorf("detectDrift() = %v, want %v", got, want)
+	}
+	for kind, devEUIs := range want {
+		if len(got[kind]) != len(devEUIs) || got[kind][0] != devEUIs[0] {
+			t.Errorf("detectDrift() %s = %v, want %v", kind, got[kind], devEUIs)
+		}
+	}
+}
+
+func TestDriftAction(t *testing.T) {
+	tests := []struct {
+		kind   string
+		policy string
+		want   string
+	}{
+		{DriftAssetMissing, app.ReconcilePolicyReport, DriftActionNone},
+		{DriftAssetMissing, app.ReconcilePolicyAdopt, DriftActionMarkDeleted},
+		{DriftAssetMissing, app.ReconcilePolicyDelete, DriftActionDeleteDevice},
+		{DriftAssetMissing, app.ReconcilePolicyRecreate, DriftActionRecreateAsset},
+		{DriftDeviceMissing, app.ReconcilePolicyRecreate, DriftActionNone},
+		{DriftUntrackedAsset, app.ReconcilePolicyAdopt, DriftActionAdoptAsset},
+		{DriftUntrackedDevice, app.ReconcilePolicyAdopt, DriftActionNone},
+	}
+	for _, tt := range tests {
+		if got := driftAction(tt.kind, tt.policy); got != tt.want {
+			t.Errorf("driftAction(%s, %s) = %s, want %s", tt.kind, tt.policy, got, tt.want)
+		}
+	}
+}
+
+func TestFixDriftSkipsChangedAssets(t *testing.T) {
+	snapshot := time.Now()
+	d := drift{
+		kind:      DriftAssetMissing,
+		devEUI:    "BE7A000000000001",
+		projectID: "10",
+		dbAsset:   &appdb.Asset{AssetID: 1, ModifiedAt: null.TimeFrom(snapshot.Add(time.Second))},
+	}
+	for _, action := range []string{DriftActionMarkDeleted, DriftActionDeleteDevice, DriftActionRecreateAsset} {
+		if err := fixDrift(context.Background(), apiserver.Configuration{}, d, action, snapshot); !errors.Is(err, errDriftChanged) {
+			t.Errorf("fixDrift(%s) = %v, want %v", action, err, errDriftChanged)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package broker
+
+import (
+	"loriot-io/apiserver"
+	"sync"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// Steps of creating or updating a device with its assets.
+const (
+	StepLoriotDevice = "loriot_device"
+	StepRootAsset    = "root_asset"
+	StepDeviceAsset  = "device_asset"
+	StepAppAsset     = "app_asset"
+	StepKeyVault     = "key_vault"
+)
+
+// Outcomes of a step.
+const (
+	StepCreated            = "created"
+	StepUpdated            = "updated"
+	StepUnchanged          = "unchanged"
+	StepFailed             = "failed"
+	StepCompensated        = "compensated"
+	StepCompensationFailed = "compensation_failed"
+)
+
+// compensatedAssetTTL defines how long the deletion of a compensated asset is ignored by the asset listener.
+const compensatedAssetTTL = time.Minute
+
+// compensatedAssets holds the IDs of assets deleted by a rollback. Their deletion must not delete the device in
+// Loriot.io, which is either compensated separately or existed before.
+var compensatedAssets sync.Map
+
+// saga tracks the steps of an operation spanning Loriot.io, Eliona and the app, so the applied steps can be
+// compensated in reverse order if a later step fails.
+type saga struct {
+	steps []sagaStep
+}
+
+type sagaStep struct {
+	result     apiserver.PutDeviceStep
+	compensate func() error
+}
+
+// applied records a successful step with the function undoing it. The function is nil if nothing has to be undone.
+func (s *saga) applied(result apiserver.PutDeviceStep, compensate func() error) {
+	s.steps = append(s.steps, sagaStep{result: result, compensate: compensate})
+}
+
+// failed records a failed step.
+func (s *saga) failed(result apiserver.PutDeviceStep, err error) {
+	result.Status = StepFailed
+	result.Error = common.Ptr(err.Error())
+	s.steps = append(s.steps, sagaStep{result: result})
+}
+
+// rollback compensates all applied steps in reverse order. Compensation continues after a failed compensation.
+func (s *saga) rollback() {
+	for i := len(s.steps) - 1; i >= 0; i-- {
+		step := &s.steps[i]
+		if step.compensate == nil {
+			continue
+		}
+		if err := step.compensate(); err != nil {
+			log.Error("loriot", "Error compensating step %s: %v", step.result.Step, err)
+			step.result.Status = StepCompensationFailed
+			step.result.Error = common.Ptr(err.Error())
+			continue
+		}
+		step.result.Status = StepCompensated
+	}
+}
+
+// results returns the outcome of all steps in the order they were performed.
+func (s *saga) results() []apiserver.PutDeviceStep {
+	results := make([]apiserver.PutDeviceStep, 0, len(s.steps))
+	for _, step := range s.steps {
+		results = append(results, step.result)
+	}
+	return results
+}
+
+func markAssetCompensated(assetID int32) {
+	compensatedAssets.Store(assetID, time.Now())
+}
+
+// isAssetCompensated checks if the asset was recently deleted by a rollback.
+func isAssetCompensated(assetID int32) bool {
+	compensatedAt, ok := compensatedAssets.Load(assetID)
+	if !ok {
+		return false
+	}
+	if time.Since(compensatedAt.(time.Time)) > compensatedAssetTTL {
+		compensatedAssets.Delete(assetID)
+		return false
+	}
+	return true
+}
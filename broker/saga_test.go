@@ -0,0 +1,51 @@
+package broker
+
+import (
+	"errors"
+	"loriot-io/apiserver"
+	"testing"
+)
+
+func TestSagaRollback(t *testing.T) {
+	var compensated []string
+	compensate := func(step string, err error) func() error {
+		return func() error {
+			compensated = append(compensated, step)
+			return err
+		}
+	}
+
+	s := &saga{}
+	s.applied(apiserver.PutDeviceStep{Step: StepLoriotDevice, Status: StepCreated}, compensate(StepLoriotDevice, nil))
+	s.applied(apiserver.PutDeviceStep{Step: StepRootAsset, Status: StepUnchanged}, nil)
+	s.applied(apiserver.PutDeviceStep{Step: StepDeviceAsset, Status: StepUpdated}, compensate(StepDeviceAsset, errors.New("eliona down")))
+	s.failed(apiserver.PutDeviceStep{Step: StepAppAsset}, errors.New("db down"))
+	s.rollback()
+
+	if len(compensated) != 2 || compensated[0] != StepDeviceAsset || compensated[1] != StepLoriotDevice {
+		t.Fatalf("compensated = %v, want reverse order of applied steps", compensated)
+	}
+	want := []string{StepCompensated, StepUnchanged, StepCompensationFailed, StepFailed}
+	results := s.results()
+	for i, result := range results {
+		if result.Status != want[i] {
+			t.Errorf("step %s: status = %s, want %s", result.Step, result.Status, want[i])
+		}
+	}
+	if results[2].Error == nil || *results[2].Error != "eliona down" {
+		t.Errorf("step %s: error = %v, want compensation error", results[2].Step, results[2].Error)
+	}
+	if results[3].Error == nil || *results[3].Error != "db down" {
+		t.Errorf("step %s: error = %v, want step error", results[3].Step, results[3].Error)
+	}
+}
+
+func TestIsAssetCompensated(t *testing.T) {
+	markAssetCompensated(7)
+	if !isAssetCompensated(7) {
+		t.Error("asset 7 should be compensated")
+	}
+	if isAssetCompensated(8) {
+		t.Error("asset 8 should not be compensated")
+	}
+}
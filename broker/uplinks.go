@@ -0,0 +1,165 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package broker
+
+import (
+	"context"
+	"fmt"
+	"loriot-io/apiserver"
+	"loriot-io/app"
+	"loriot-io/appdb"
+	"loriot-io/decoder"
+	"loriot-io/eliona"
+	"loriot-io/loriot"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 5 * time.Minute
+)
+
+func init() {
+	// Codecs stored for asset types are used if no built-in decoder matches
+	decoder.RegisterProvider(app.CodecDecoder)
+}
+
+// ListenForUplinks keeps one connection to the Loriot application WebSocket open per enabled configuration
+// and writes all received uplinks as data to the Eliona assets of the sending devices.
+func ListenForUplinks() {
+	newConfigWorkers("loriot", listenForConfigUplinks).run()
+}
+
+// listenForConfigUplinks listens to the application WebSocket of the configuration until the context is
+// cancelled. Broken connections are reopened with an exponential backoff.
+func listenForConfigUplinks(ctx context.Context, config apiserver.Configuration) {
+	delay := minReconnectDelay
+	for {
+		started := time.Now()
+		err := listenForAppMessages(ctx, config)
+		if ctx.Err() != nil {
+			return
+		}
+
+		// A connection which was stable for a while resets the backoff
+		if time.Since(started) > maxReconnectDelay {
+			delay = minReconnectDelay
+		}
+		log.Warn("loriot", "Application websocket for config %d broke: %v. Reconnecting in %v.", *config.Id, err, delay)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxReconnectDelay)
+	}
+}
+
+func listenForAppMessages(ctx context.Context, config apiserver.Configuration) error {
+	conn, err := loriot.DialApp(ctx, config)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Closing the connection unblocks the pending read if the context is cancelled
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.Close()
+	})
+	defer stop()
+
+	log.Info("loriot", "Connected to application websocket for config %d", *config.Id)
+	setAppConnection(*config.Id, conn)
+	defer removeAppConnection(*config.Id, conn)
+
+	for {
+		message, err := conn.Read()
+		if err != nil {
+			return err
+		}
+		handleAppMessage(ctx, config, *message)
+	}
+}
+
+func handleAppMessage(ctx context.Context, config apiserver.Configuration, message loriot.Message) {
+	handleDownlinkMessage(ctx, *config.Id, message)
+	if !message.IsUplink() {
+		log.Debug("loriot", "Ignoring application websocket message %s", message.Cmd)
+		return
+	}
+	dbAssets, err := app.GetDbDeviceAssetsByDevEUI(ctx, *config.Id, message.EUI)
+	if err != nil {
+		log.Error("app", "Error getting assets for device %s: %v", message.EUI, err)
+		return
+	}
+	if len(dbAssets) == 0 {
+		log.Debug("loriot", "Ignoring uplink from device %s without asset in config %d", message.EUI, *config.Id)
+		return
+	}
+	for _, dbAsset := range dbAssets {
+		if err := app.RecordUplink(ctx, dbAsset, message.Timestamp()); err != nil {
+			log.Error("app", "%v", err)
+		}
+		decoded := decodeUplink(ctx, dbAsset, message)
+		if err := eliona.UpsertUplinkData(dbAsset.AssetID, dbAsset.AssetType.String, message, decoded); err != nil {
+			log.Error("eliona", "Error writing uplink of device %s: %v", message.EUI, err)
+			continue
+		}
+		log.Debug("eliona", "Uplink %d of device %s written to asset %d", message.FCnt, message.EUI, dbAsset.AssetID)
+	}
+}
+
+// decodeUplink decodes the payload with the decoder of the device or, if not defined, the decoder of the asset type.
+// Errors are recorded for the device and nil is returned, so the raw uplink is written anyway.
+func decodeUplink(ctx context.Context, dbAsset *appdb.Asset, message loriot.Message) map[string]any {
+	decoded, payloadDecoder, err := decodePayload(dbAsset, message)
+	if err != nil {
+		log.Warn("decoder", "Error decoding uplink %d of device %s: %v", message.FCnt, message.EUI, err)
+		if err := app.RecordDecodingError(ctx, dbAsset, err); err != nil {
+			log.Error("app", "%v", err)
+		}
+		return nil
+	}
+
+	// Self-describing decoders extend the asset type, so no value is invisible in Eliona
+	if describer, ok := payloadDecoder.(decoder.Describer); ok && dbAsset.AssetType.Valid {
+		if err := eliona.UpsertMissingAttributes(dbAsset.AssetType.String, decoded, describer); err != nil {
+			log.Error("eliona", "Error adding attributes for device %s: %v", message.EUI, err)
+		}
+	}
+	return decoded
+}
+
+func decodePayload(dbAsset *appdb.Asset, message loriot.Message) (map[string]any, decoder.Decoder, error) {
+	payloadDecoder, err := decoder.Lookup(dbAsset.Decoder.String, dbAsset.AssetType.String)
+	if err != nil {
+		return nil, nil, err
+	}
+	if payloadDecoder == nil {
+		if dbAsset.Decoder.Valid {
+			return nil, nil, fmt.Errorf("decoder %s not found", dbAsset.Decoder.String)
+		}
+		return nil, nil, nil
+	}
+	payload, err := message.Payload()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid payload %s: %w", message.Data, err)
+	}
+	decoded, err := payloadDecoder.Decode(message.Port, payload)
+	return decoded, payloadDecoder, err
+}
@@ -0,0 +1,137 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package broker
+
+import (
+	"context"
+	"fmt"
+	"loriot-io/apiserver"
+	"loriot-io/app"
+	"loriot-io/appdb"
+	"loriot-io/eliona"
+	"loriot-io/loriot"
+	"strings"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// Connection states of a device watched by its reporting interval.
+const (
+	DeviceOnline  = "online"
+	DeviceLate    = "late"
+	DeviceOffline = "offline"
+)
+
+// defaultDeviceOfflineIntervals is used if the configuration defines no number of missed intervals.
+const defaultDeviceOfflineIntervals = 3
+
+// watchDevice compares the last uplink of the device with its expected reporting interval. Changes of the connection
+// state are stored, and the user is notified when the device goes offline or comes back. The returned offline state
+// is nil if the device has no reporting interval and is therefore not watched.
+func watchDevice(ctx context.Context, config apiserver.Configuration, device loriot.Device, dbAsset *appdb.Asset) *bool {
+	interval := reportingInterval(config, dbAsset)
+	if interval <= 0 {
+		return nil
+	}
+	lastUplink := device.LastSeen
+	if dbAsset.LastUplinkAt.Valid && dbAsset.LastUplinkAt.Time.After(lastUplink) {
+		lastUplink = dbAsset.LastUplinkAt.Time
+	}
+	state, since := deviceConnectionState(lastUplink, interval, deviceOfflineIntervals(config), time.Now())
+	offline := state == DeviceOffline
+
+	if !dbAsset.AlarmRuleID.Valid {
+		alarmRuleID, err := eliona.UpsertAlarmRule(dbAsset.AssetID, api.SUBTYPE_STATUS, "offline", deviceOfflineAlarmMessage(device.DevEUI))
+		if err != nil {
+			log.Error("eliona", "Error creating offline alarm rule for device %s: %v", device.DevEUI, err)
+		} else if err := app.SetDeviceAlarmRule(ctx, dbAsset, alarmRuleID); err != nil {
+			log.Error("app", "%v", err)
+		}
+	}
+
+	previous := dbAsset.ConnectionState.String
+	if state == previous {
+		return &offline
+	}
+	if err := app.SetDeviceConnectionState(ctx, dbAsset, state, since); err != nil {
+		log.Error("app", "%v", err)
+		return &offline
+	}
+	log.Info("loriot", "Device %s of asset %d changed to %s", device.DevEUI, dbAsset.AssetID, state)
+	if offline || previous == DeviceOffline {
+		app.NotifyUser(config.UserId, &dbAsset.ProjectID, deviceOfflineNotification(device.DevEUI, offline))
+	}
+	return &offline
+}
+
+// deviceConnectionState determines the connection state of a device from its last uplink and since when the device
+// is in this state. A device is late if it missed one reporting interval and offline if it missed the given number
+// of intervals. A device that never sent an uplink is offline.
+func deviceConnectionState(lastUplink time.Time, interval time.Duration, offlineIntervals int, now time.Time) (string, time.Time) {
+	if lastUplink.IsZero() {
+		return DeviceOffline, now
+	}
+	offlineAfter := time.Duration(offlineIntervals) * interval
+	switch silence := now.Sub(lastUplink); {
+	case silence >= offlineAfter:
+		return DeviceOffline, lastUplink.Add(offlineAfter)
+	case silence >= interval:
+		return DeviceLate, lastUplink.Add(interval)
+	default:
+		return DeviceOnline, lastUplink
+	}
+}
+
+// reportingInterval returns the expected reporting interval of the device asset. The interval of the asset is
+// preferred over the one configured for its asset type.
+func reportingInterval(config apiserver.Configuration, dbAsset *appdb.Asset) time.Duration {
+	if dbAsset.ReportingInterval.Valid {
+		return time.Duration(dbAsset.ReportingInterval.Int32) * time.Second
+	}
+	for assetType, interval := range config.ReportingIntervals {
+		if strings.EqualFold(assetType, dbAsset.AssetType.String) {
+			return time.Duration(interval) * time.Second
+		}
+	}
+	return 0
+}
+
+func deviceOfflineIntervals(config apiserver.Configuration) int {
+	if config.DeviceOfflineIntervals == nil || *config.DeviceOfflineIntervals <= 0 {
+		return defaultDeviceOfflineIntervals
+	}
+	return int(*config.DeviceOfflineIntervals)
+}
+
+func deviceOfflineAlarmMessage(devEUI string) api.Translation {
+	return api.Translation{
+		De: api.PtrString(fmt.Sprintf("Loriot Gerät '%s' ist offline.", strings.ToUpper(devEUI))),
+		En: api.PtrString(fmt.Sprintf("Loriot device '%s' is offline.", strings.ToUpper(devEUI))),
+	}
+}
+
+func deviceOfflineNotification(devEUI string, offline bool) *api.Translation {
+	if !offline {
+		return &api.Translation{
+			De: api.PtrString(fmt.Sprintf("Loriot Gerät '%s' ist wieder online.", strings.ToUpper(devEUI))),
+			En: api.PtrString(fmt.Sprintf("Loriot device '%s' is online again.", strings.ToUpper(devEUI))),
+		}
+	}
+	return common.Ptr(deviceOfflineAlarmMessage(devEUI))
+}
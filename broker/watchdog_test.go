@@ -0,0 +1,47 @@
+package broker
+
+import (
+	"loriot-io/apiserver"
+	"loriot-io/appdb"
+	"testing"
+	"time"
+
+	"github.com/volatiletech/null/v8"
+)
+
+func TestDeviceConnectionState(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	interval := 10 * time.Minute
+	tests := []struct {
+		name       string
+		lastUplink time.Time
+		wantState  string
+		wantSince  time.Time
+	}{
+		{"never seen", time.Time{}, DeviceOffline, now},
+		{"online", now.Add(-5 * time.Minute), DeviceOnline, now.Add(-5 * time.Minute)},
+		{"late", now.Add(-15 * time.Minute), DeviceLate, now.Add(-5 * time.Minute)},
+		{"offline", now.Add(-time.Hour), DeviceOffline, now.Add(-30 * time.Minute)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, since := deviceConnectionState(tt.lastUplink, interval, 3, now)
+			if state != tt.wantState || !since.Equal(tt.wantSince) {
+				t.Errorf("deviceConnectionState() = %v, %v, want %v, %v", state, since, tt.wantState, tt.wantSince)
+			}
+		})
+	}
+}
+
+func TestReportingInterval(t *testing.T) {
+	config := apiserver.Configuration{ReportingIntervals: map[string]int32{"Loriot_Cayenne_LPP": 900}}
+	if got := reportingInterval(config, &appdb.Asset{AssetType: null.StringFrom("loriot_cayenne_lpp")}); got != 15*time.Minute {
+		t.Errorf("reportingInterval() by asset type = %v", got)
+	}
+	if got := reportingInterval(config, &appdb.Asset{AssetType: null.StringFrom("loriot_cayenne_lpp"), ReportingInterval: null.Int32From(60)}); got != time.Minute {
+		t.Errorf("reportingInterval() by asset = %v", got)
+	}
+	if got := reportingInterval(config, &appdb.Asset{AssetType: null.StringFrom("other")}); got != 0 {
+		t.Errorf("reportingInterval() unwatched = %v", got)
+	}
+}
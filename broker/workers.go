@@ -0,0 +1,120 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package broker
+
+import (
+	"context"
+	"loriot-io/apiserver"
+	"loriot-io/app"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// configSyncInterval defines how often the workers are compared with the stored configurations.
+const configSyncInterval = time.Minute
+
+var (
+	allConfigWorkersMutex sync.Mutex
+	allConfigWorkers      []*configWorkers
+)
+
+// configWorkers runs one worker per enabled configuration. Workers of removed, disabled or changed
+// configurations are stopped by cancelling their context.
+type configWorkers struct {
+	name    string
+	work    func(ctx context.Context, config apiserver.Configuration)
+	mutex   sync.Mutex
+	running map[int64]*configWorker
+}
+
+type configWorker struct {
+	config apiserver.Configuration
+	cancel context.CancelFunc
+}
+
+func newConfigWorkers(name string, work func(ctx context.Context, config apiserver.Configuration)) *configWorkers {
+	return &configWorkers{
+		name:    name,
+		work:    work,
+		running: make(map[int64]*configWorker),
+	}
+}
+
+// run keeps the workers in sync with the stored configurations until the app is terminated.
+func (w *configWorkers) run() {
+	allConfigWorkersMutex.Lock()
+	allConfigWorkers = append(allConfigWorkers, w)
+	allConfigWorkersMutex.Unlock()
+
+	common.Loop(w.syncStored, configSyncInterval)()
+}
+
+func (w *configWorkers) syncStored() {
+	configs, err := app.GetConfigs(context.Background())
+	if err != nil {
+		log.Error(w.name, "Error getting configs: %v", err)
+		return
+	}
+	w.sync(configs)
+}
+
+// SyncConfigWorkers immediately starts and stops the workers of all configurations, e.g. after configurations were
+// changed, instead of waiting for the next periodic sync.
+func SyncConfigWorkers() {
+	allConfigWorkersMutex.Lock()
+	defer allConfigWorkersMutex.Unlock()
+	for _, w := range allConfigWorkers {
+		w.syncStored()
+	}
+}
+
+// sync starts workers for new enabled configurations and stops workers of configurations which are
+// removed, disabled or changed. Changed configurations get a new worker.
+func (w *configWorkers) sync(configs []apiserver.Configuration) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	wanted := make(map[int64]apiserver.Configuration)
+	for _, config := range configs {
+		if config.Id != nil && app.IsConfigEnabled(config) {
+			wanted[*config.Id] = config
+		}
+	}
+
+	for id, worker := range w.running {
+		config, ok := wanted[id]
+		if ok && reflect.DeepEqual(config, worker.config) {
+			continue
+		}
+		log.Info(w.name, "Stopping worker for config %d", id)
+		worker.cancel()
+		delete(w.running, id)
+	}
+
+	for id, config := range wanted {
+		if _, ok := w.running[id]; ok {
+			continue
+		}
+		log.Info(w.name, "Starting worker for config %d", id)
+		ctx, cancel := context.WithCancel(context.Background())
+		w.running[id] = &configWorker{config: config, cancel: cancel}
+		go w.work(ctx, config)
+	}
+}
@@ -0,0 +1,290 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package codec
+
+import (
+	"errors"
+	"fmt"
+	"loriot-io/decoder"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+)
+
+const (
+	FunctionDecodeUplink   = "decodeUplink"
+	FunctionEncodeDownlink = "encodeDownlink"
+)
+
+// Limits restricts the resources a script can use for one execution.
+type Limits struct {
+	Timeout          time.Duration
+	MemoryLimit      uint64
+	MaxCallStackSize int
+}
+
+// DefaultLimits are used for scripts without explicit limits.
+var DefaultLimits = Limits{
+	Timeout:          time.Second,
+	MemoryLimit:      16 << 20,
+	MaxCallStackSize: 256,
+}
+
+var (
+	ErrTimeout             = errors.New("script execution timed out")
+	ErrMemoryLimitExceeded = errors.New("script exceeded memory limit")
+)
+
+// Script is a compiled TTN/ChirpStack compatible codec providing decodeUplink(input) and/or encodeDownlink(input).
+type Script struct {
+	program *goja.Program
+	limits  Limits
+}
+
+// Result is the output of a codec function as defined by the TTN codec API. Additionally, encodeDownlink
+// may return confirmed to request a confirmed downlink.
+type Result struct {
+	Data      map[string]any
+	Bytes     []byte
+	FPort     *int
+	Confirmed bool
+	Warnings  []string
+	Errors    []string
+}
+
+// Compile parses the script source. Zero limits are replaced by the default limits.
+func Compile(source string, limits Limits) (*Script, error) {
+	program, err := goja.Compile("codec.js", source, false)
+	if err != nil {
+		return nil, fmt.Errorf("compiling script: %w", err)
+	}
+	if limits.Timeout <= 0 {
+		limits.Timeout = DefaultLimits.Timeout
+	}
+	if limits.MemoryLimit == 0 {
+		limits.MemoryLimit = DefaultLimits.MemoryLimit
+	}
+	if limits.MaxCallStackSize <= 0 {
+		limits.MaxCallStackSize = DefaultLimits.MaxCallStackSize
+	}
+	return &Script{program: program, limits: limits}, nil
+}
+
+// Decode implements decoder.Decoder. Errors reported by the script are returned as error, warnings are dropped.
+func (s *Script) Decode(port int, payload []byte) (map[string]any, error) {
+	result, err := s.DecodeUplink(port, payload, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Errors) > 0 {
+		return nil, fmt.Errorf("decodeUplink: %s", strings.Join(result.Errors, "; "))
+	}
+	return result.Data, nil
+}
+
+// Encode implements decoder.Encoder. Errors reported by the script are returned as error, warnings are dropped.
+func (s *Script) Encode(data map[string]any) (decoder.Downlink, error) {
+	result, err := s.EncodeDownlink(data)
+	if err != nil {
+		return decoder.Downlink{}, err
+	}
+	if len(result.Errors) > 0 {
+		return decoder.Downlink{}, fmt.Errorf("encodeDownlink: %s", strings.Join(result.Errors, "; "))
+	}
+	if result.FPort == nil {
+		return decoder.Downlink{}, errors.New("encodeDownlink returned no fPort")
+	}
+	return decoder.Downlink{
+		Port:      *result.FPort,
+		Payload:   result.Bytes,
+		Confirmed: result.Confirmed,
+	}, nil
+}
+
+// DecodeUplink calls decodeUplink({bytes, fPort, recvTime}) of the script.
+func (s *Script) DecodeUplink(port int, payload []byte, recvTime time.Time) (Result, error) {
+	return s.run(FunctionDecodeUplink, func(vm *goja.Runtime) goja.Value {
+		input := vm.NewObject()
+		_ = input.Set("bytes", bytesToArray(vm, payload))
+		_ = input.Set("fPort", port)
+		if date, err := vm.New(vm.Get("Date"), vm.ToValue(recvTime.UnixMilli())); err == nil {
+			_ = input.Set("recvTime", date)
+		}
+		return input
+	})
+}
+
+// EncodeDownlink calls encodeDownlink({data}) of the script.
+func (s *Script) EncodeDownlink(data map[string]any) (Result, error) {
+	return s.run(FunctionEncodeDownlink, func(vm *goja.Runtime) goja.Value {
+		input := vm.NewObject()
+		_ = input.Set("data", data)
+		return input
+	})
+}
+
+// run calls the function of the script in a new runtime, so scripts can run concurrently.
+func (s *Script) run(function string, input func(vm *goja.Runtime) goja.Value) (result Result, err error) {
+	vm := goja.New()
+	vm.SetMaxCallStackSize(s.limits.MaxCallStackSize)
+	runningScripts.Add(1)
+	defer runningScripts.Add(-1)
+	meter := newMemoryMeter(vm, s.limits.MemoryLimit)
+	stop := s.guard(vm, meter)
+	defer func() {
+		if guardErr := stop(); meter.exceeded.Load() {
+			err = ErrMemoryLimitExceeded
+		} else if guardErr != nil {
+			err = guardErr
+		}
+	}()
+
+	if _, err := vm.RunProgram(s.program); err != nil {
+		return Result{}, fmt.Errorf("running script: %w", err)
+	}
+	fn, ok := goja.AssertFunction(vm.Get(function))
+	if !ok {
+		return Result{}, fmt.Errorf("script defines no function %s", function)
+	}
+	output, err := fn(goja.Undefined(), input(vm))
+	if err != nil {
+		return Result{}, fmt.Errorf("%s: %w", function, err)
+	}
+	return resultFromValue(output)
+}
+
+// guard interrupts the execution if the timeout is exceeded, and checks the heap growth of the run periodically. The
+// returned function stops the guard and returns the reason of an interruption by timeout.
+func (s *Script) guard(vm *goja.Runtime, meter *memoryMeter) func() error {
+	done := make(chan struct{})
+	reason := make(chan error, 1)
+	go func() {
+		timeout := time.NewTimer(s.limits.Timeout)
+		defer timeout.Stop()
+		heap := time.NewTicker(heapSampleInterval)
+		defer heap.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-heap.C:
+				// Both may be ready, a finished run must not be checked anymore
+				select {
+				case <-done:
+					return
+				default:
+				}
+				meter.checkHeap()
+			case <-timeout.C:
+				reason <- ErrTimeout
+				vm.Interrupt(ErrTimeout)
+				return
+			}
+		}
+	}()
+	return func() error {
+		close(done)
+		select {
+		case err := <-reason:
+			return err
+		default:
+			return nil
+		}
+	}
+}
+
+func bytesToArray(vm *goja.Runtime, payload []byte) goja.Value {
+	values := make([]any, len(payload))
+	for idx, b := range payload {
+		values[idx] = int64(b)
+	}
+	return vm.NewArray(values...)
+}
+
+func resultFromValue(value goja.Value) (Result, error) {
+	if value == nil || goja.IsUndefined(value) || goja.IsNull(value) {
+		return Result{}, errors.New("script returned no result")
+	}
+	output, ok := value.Export().(map[string]any)
+	if !ok {
+		return Result{}, fmt.Errorf("script returned %T instead of an object", value.Export())
+	}
+
+	var result Result
+	if data, ok := output["data"]; ok && data != nil {
+		if result.Data, ok = data.(map[string]any); !ok {
+			return Result{}, fmt.Errorf("script returned data of type %T instead of an object", data)
+		}
+	}
+	if bytes, ok := output["bytes"]; ok && bytes != nil {
+		var err error
+		if result.Bytes, err = toBytes(bytes); err != nil {
+			return Result{}, err
+		}
+	}
+	if fPort, ok := output["fPort"]; ok && fPort != nil {
+		port, ok := toInt(fPort)
+		if !ok {
+			return Result{}, fmt.Errorf("script returned fPort of type %T instead of a number", fPort)
+		}
+		result.FPort = &port
+	}
+	if confirmed, ok := output["confirmed"].(bool); ok {
+		result.Confirmed = confirmed
+	}
+	result.Warnings = toStrings(output["warnings"])
+	result.Errors = toStrings(output["errors"])
+	return result, nil
+}
+
+func toBytes(value any) ([]byte, error) {
+	values, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("script returned bytes of type %T instead of an array", value)
+	}
+	bytes := make([]byte, len(values))
+	for idx, v := range values {
+		b, ok := toInt(v)
+		if !ok || b < 0 || b > 255 {
+			return nil, fmt.Errorf("script returned invalid byte %v at index %d", v, idx)
+		}
+		bytes[idx] = byte(b)
+	}
+	return bytes, nil
+}
+
+func toInt(value any) (int, bool) {
+	switch v := value.(type) {
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), v == float64(int(v))
+	}
+	return 0, false
+}
+
+func toStrings(value any) []string {
+	values, ok := value.([]any)
+	if !ok {
+		return nil
+	}
+	var strs []string
+	for _, v := range values {
+		strs = append(strs, fmt.Sprint(v))
+	}
+	return strs
+}
@@ -0,0 +1,134 @@
+package codec
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+const temperatureCodec = `
+function decodeUplink(input) {
+	if (input.fPort !== 1) {
+		return { errors: ["unknown port " + input.fPort] };
+	}
+	return {
+		data: { temperature: ((input.bytes[0] << 8) | input.bytes[1]) / 10, year: input.recvTime.getUTCFullYear() },
+		warnings: input.bytes.length > 2 ? ["ignored trailing bytes"] : []
+	};
+}
+
+function encodeDownlink(input) {
+	return { bytes: [input.data.interval >> 8, input.data.interval & 0xff], fPort: 2, confirmed: true };
+}
+`
+
+func TestDecodeUplink(t *testing.T) {
+	script, err := Compile(temperatureCodec, Limits{})
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+
+	result, err := script.DecodeUplink(1, []byte{0x00, 0xd7, 0xff}, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("DecodeUplink() error = %v", err)
+	}
+	if result.Data["temperature"] != 21.5 || result.Data["year"] != int64(2024) {
+		t.Errorf("DecodeUplink() data = %v", result.Data)
+	}
+	if len(result.Warnings) != 1 || len(result.Errors) != 0 {
+		t.Errorf("DecodeUplink() warnings = %v, errors = %v", result.Warnings, result.Errors)
+	}
+
+	if _, err := script.Decode(3, []byte{0x00}); err == nil {
+		t.Errorf("Decode() expected error reported by script")
+	}
+}
+
+func TestEncodeDownlink(t *testing.T) {
+	script, err := Compile(temperatureCodec, Limits{})
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	result, err := script.EncodeDownlink(map[string]any{"interval": 600})
+	if err != nil {
+		t.Fatalf("EncodeDownlink() error = %v", err)
+	}
+	if len(result.Bytes) != 2 || result.Bytes[0] != 0x02 || result.Bytes[1] != 0x58 || result.FPort == nil || *result.FPort != 2 {
+		t.Errorf("EncodeDownlink() = %+v", result)
+	}
+
+	downlink, err := script.Encode(map[string]any{"interval": 60})
+	if err != nil || downlink.Port != 2 || !downlink.Confirmed || len(downlink.Payload) != 2 || downlink.Payload[1] != 60 {
+		t.Errorf("Encode() = %+v, %v", downlink, err)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	tests := []struct {
+		name             string
+		source           string
+		limits           Limits
+		want             error
+		wantCompileError bool
+	}{
+		{"timeout", `function decodeUplink(input) { for (;;) {} }`, Limits{Timeout: 50 * time.Millisecond}, ErrTimeout, false},
+		{"memory", `function decodeUplink(input) { var a = []; for (;;) { a.push(new Array(1000).fill(a.length)); } }`, Limits{Timeout: 10 * time.Second, MemoryLimit: 8 << 20}, ErrMemoryLimitExceeded, false},
+		{"string memory", `function decodeUplink(input) { var s = "x".repeat(1 << 24); return {data: {length: s.length}}; }`, Limits{Timeout: 10 * time.Second, MemoryLimit: 8 << 20}, ErrMemoryLimitExceeded, false},
+		{"caught memory", `function decodeUplink(input) { try { "x".repeat(1 << 24); } catch (e) {} return {data: {}}; }`, Limits{Timeout: 10 * time.Second, MemoryLimit: 8 << 20}, ErrMemoryLimitExceeded, false},
+		{"repeat", `function decodeUplink(input) { var s = "ab".repeat(1 << 28); return {data: {length: s.length}}; }`, Limits{Timeout: 10 * time.Second, MemoryLimit: 1 << 20}, ErrMemoryLimitExceeded, false},
+		{"pad", `function decodeUplink(input) { var s = "a".padStart(1 << 28); return {data: {length: s.length}}; }`, Limits{Timeout: 10 * time.Second, MemoryLimit: 1 << 20}, ErrMemoryLimitExceeded, false},
+		{"join", `function decodeUplink(input) { var s = new Array(1 << 28).join("ab"); return {data: {length: s.length}}; }`, Limits{Timeout: 10 * time.Second, MemoryLimit: 1 << 20}, ErrMemoryLimitExceeded, false},
+		{"index growth", `function decodeUplink(input) { var a = []; for (var k = 0; k < 3e6; k++) { a[k] = k; } return {data: {length: a.length}}; }`, Limits{Timeout: 10 * time.Second, MemoryLimit: 1 << 20}, ErrMemoryLimitExceeded, false},
+		{"recursion", `function decodeUplink(input) { return decodeUplink(input); }`, Limits{}, nil, false},
+		{"missing function", `function encodeDownlink(input) { return {}; }`, Limits{}, nil, false},
+		{"syntax", `function decodeUplink(input) {`, Limits{}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script, err := Compile(tt.source, tt.limits)
+			if (err != nil) != tt.wantCompileError {
+				t.Fatalf("Compile() error = %v, wantCompileError %v", err, tt.wantCompileError)
+			}
+			if err != nil {
+				return
+			}
+			_, err = script.DecodeUplink(1, []byte{0x01}, time.Now())
+			if err == nil {
+				t.Fatalf("DecodeUplink() expected error")
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("DecodeUplink() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestConcurrentRuns(t *testing.T) {
+	script, err := Compile(`function decodeUplink(input) { var a = []; for (var i = 0; i < 1000; i++) { a.push(i); } return {data: {port: input.fPort, sum: a.length}}; }`, Limits{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for port := 1; port <= 20; port++ {
+		wg.Add(1)
+		go func(port int) {
+			defer wg.Done()
+			data, err := script.Decode(port, []byte{0x01})
+			if err != nil {
+				errs <- err
+				return
+			}
+			if data["port"] != int64(port) || data["sum"] != int64(1000) {
+				errs <- fmt.Errorf("port %d: unexpected data %v", port, data)
+			}
+		}(port)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,281 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package codec
+
+import (
+	"math"
+	"runtime"
+	"runtime/metrics"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dop251/goja"
+)
+
+// valueSlotSize is the estimated size of one element of an array or object.
+const valueSlotSize = 16
+
+// heapSampleInterval is the interval in which the heap growth during a script run is checked.
+const heapSampleInterval = 10 * time.Millisecond
+
+var (
+	// runningScripts counts the script runs in progress in all runtimes.
+	runningScripts atomic.Int64
+	// heapCollection serializes the garbage collections forced to measure the heap.
+	heapCollection sync.Mutex
+)
+
+// meteredBuiltins are the builtins which can allocate large strings, arrays or objects, by object path.
+var meteredBuiltins = map[string][]string{
+	"String.prototype": {"concat", "padEnd", "padStart", "repeat", "replace", "replaceAll", "split", "toLowerCase", "toUpperCase"},
+	"String":           {"fromCharCode", "fromCodePoint"},
+	"Array.prototype":  {"concat", "fill", "flat", "flatMap", "filter", "join", "map", "push", "slice", "splice", "unshift"},
+	"Array":            {"from", "of"},
+	"Object":           {"assign", "entries", "keys", "values"},
+	"JSON":             {"parse", "stringify"},
+}
+
+// requestedSizes estimate the size a builtin allocates from its arguments, by object path and method. These builtins
+// are charged before they are called, all others with the size of their result.
+var requestedSizes = map[string]func(call goja.FunctionCall) uint64{
+	"String.prototype.concat": func(call goja.FunctionCall) uint64 {
+		return (lengthOf(call.This) + lengthsOf(call.Arguments)) * 2
+	},
+	"String.prototype.padEnd":   paddedSize,
+	"String.prototype.padStart": paddedSize,
+	"String.prototype.repeat": func(call goja.FunctionCall) uint64 {
+		return saturatingMul(lengthOf(call.This), integerOf(call.Argument(0)), 2)
+	},
+	"String.prototype.toLowerCase": func(call goja.FunctionCall) uint64 {
+		return lengthOf(call.This) * 2
+	},
+	"String.prototype.toUpperCase": func(call goja.FunctionCall) uint64 {
+		return lengthOf(call.This) * 2
+	},
+	"Array.prototype.concat": func(call goja.FunctionCall) uint64 {
+		return (lengthOf(call.This) + lengthsOf(call.Arguments)) * valueSlotSize
+	},
+	"Array.prototype.fill": func(call goja.FunctionCall) uint64 {
+		return lengthOf(call.This) * valueSlotSize
+	},
+	"Array.prototype.join": func(call goja.FunctionCall) uint64 {
+		separator := uint64(1)
+		if !goja.IsUndefined(call.Argument(0)) {
+			separator = lengthOf(call.Argument(0))
+		}
+		return saturatingMul(lengthOf(call.This), separator+1, 2)
+	},
+	"Array.prototype.map": func(call goja.FunctionCall) uint64 {
+		return lengthOf(call.This) * valueSlotSize
+	},
+	"Array.from": func(call goja.FunctionCall) uint64 {
+		return lengthOf(call.Argument(0)) * valueSlotSize
+	},
+}
+
+// memoryMeter enforces the memory limit of one runtime. The builtins allocating large values are wrapped: the size
+// they request is charged before they are called if it can be derived from their arguments, otherwise the size of
+// their result. The runtime is interrupted as soon as the charged size exceeds the limit. Allocations by operators,
+// literals and index assignments can't be charged; they are bounded by the heap growth during the run, see checkHeap.
+type memoryMeter struct {
+	vm       *goja.Runtime
+	limit    uint64
+	used     uint64
+	heapBase uint64
+	exceeded atomic.Bool
+}
+
+func newMemoryMeter(vm *goja.Runtime, limit uint64) *memoryMeter {
+	m := &memoryMeter{vm: vm, limit: limit, heapBase: heapObjects()}
+	for path, methods := range meteredBuiltins {
+		object := m.object(path)
+		if object == nil {
+			continue
+		}
+		for _, method := range methods {
+			m.wrap(object, method, requestedSizes[path+"."+method])
+		}
+	}
+	return m
+}
+
+// object returns the object at the dotted path, starting at the global object.
+func (m *memoryMeter) object(path string) *goja.Object {
+	object := m.vm.GlobalObject()
+	for _, name := range strings.Split(path, ".") {
+		value := object.Get(name)
+		if value == nil || goja.IsUndefined(value) || goja.IsNull(value) {
+			return nil
+		}
+		object = value.ToObject(m.vm)
+	}
+	return object
+}
+
+// wrap replaces the method of the object by a function charging the requested size before calling it. Without
+// requested size the size of its result is charged, or for methods adding elements the size of the arguments.
+func (m *memoryMeter) wrap(object *goja.Object, method string, requested func(call goja.FunctionCall) uint64) {
+	original, ok := goja.AssertFunction(object.Get(method))
+	if !ok {
+		return
+	}
+	wrapper := func(call goja.FunctionCall) goja.Value {
+		if requested != nil {
+			// The interrupt stops the script at its next instruction, so nothing is allocated
+			if !m.charge(requested(call)) {
+				return goja.Undefined()
+			}
+		}
+		result, err := original(call.This, call.Arguments...)
+		if err != nil {
+			panic(err)
+		}
+		switch {
+		case requested != nil:
+		case method == "push" || method == "unshift":
+			m.charge(uint64(len(call.Arguments)) * valueSlotSize)
+		default:
+			m.charge(m.sizeOf(result))
+		}
+		return result
+	}
+	_ = object.DefineDataProperty(method, m.vm.ToValue(wrapper), goja.FLAG_TRUE, goja.FLAG_TRUE, goja.FLAG_FALSE)
+}
+
+// charge adds the allocated bytes and interrupts the runtime if the limit is exceeded. Returns false if the limit is
+// exceeded.
+func (m *memoryMeter) charge(bytes uint64) bool {
+	m.used = saturatingAdd(m.used, bytes)
+	if m.used > m.limit {
+		m.exceed()
+		return false
+	}
+	return true
+}
+
+// checkHeap interrupts the runtime if the heap grew by more than the limits of all running scripts since the run
+// started. The heap is shared with the rest of the app and with other runs, so this only bounds the memory of all
+// scripts together, also for allocations that can't be charged. If the heap including garbage grew by more, a garbage
+// collection is forced to measure the live heap.
+func (m *memoryMeter) checkHeap() {
+	threshold := saturatingMul(m.limit, uint64(max(runningScripts.Load(), 1)))
+	if heapObjects() <= saturatingAdd(m.heapBase, threshold) {
+		return
+	}
+	heapCollection.Lock()
+	runtime.GC()
+	live := heapObjects()
+	heapCollection.Unlock()
+	if live < m.heapBase {
+		// The base included garbage or memory released by other runs
+		m.heapBase = live
+		return
+	}
+	if live-m.heapBase > threshold {
+		m.exceed()
+	}
+}
+
+func (m *memoryMeter) exceed() {
+	if m.exceeded.CompareAndSwap(false, true) {
+		m.vm.Interrupt(ErrMemoryLimitExceeded)
+	}
+}
+
+// sizeOf estimates the size of a value without following references: two bytes per character of strings and one
+// slot per element of arrays and per property of objects.
+func (m *memoryMeter) sizeOf(value goja.Value) uint64 {
+	switch v := value.(type) {
+	case goja.String:
+		return uint64(v.Length()) * 2
+	case *goja.Object:
+		if length := v.Get("length"); length != nil && !goja.IsUndefined(length) {
+			return uint64(max(length.ToInteger(), 0)) * valueSlotSize
+		}
+		return uint64(len(v.Keys())) * valueSlotSize
+	}
+	return 0
+}
+
+// heapObjects returns the size of the heap objects, including garbage not yet collected.
+func heapObjects() uint64 {
+	sample := []metrics.Sample{{Name: "/memory/classes/heap/objects:bytes"}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return sample[0].Value.Uint64()
+}
+
+// paddedSize is the requested size of padStart and padEnd, the target length or the length of the string.
+func paddedSize(call goja.FunctionCall) uint64 {
+	return max(integerOf(call.Argument(0)), lengthOf(call.This)) * 2
+}
+
+// lengthOf returns the length of strings and of objects with a length property, e.g. arrays, without converting the
+// value.
+func lengthOf(value goja.Value) uint64 {
+	switch v := value.(type) {
+	case goja.String:
+		return uint64(v.Length())
+	case *goja.Object:
+		return integerOf(v.Get("length"))
+	}
+	return 0
+}
+
+func lengthsOf(values []goja.Value) uint64 {
+	var length uint64
+	for _, value := range values {
+		length = saturatingAdd(length, max(lengthOf(value), 1))
+	}
+	return length
+}
+
+// integerOf returns the non-negative integer of a number, or 0 for other values.
+func integerOf(value goja.Value) uint64 {
+	if value == nil || goja.IsUndefined(value) || goja.IsNull(value) {
+		return 0
+	}
+	number := value.ToFloat()
+	if number != number || number <= 0 {
+		return 0
+	}
+	if number >= math.MaxUint32 {
+		return math.MaxUint32
+	}
+	return uint64(number)
+}
+
+func saturatingMul(factors ...uint64) uint64 {
+	product := uint64(1)
+	for _, factor := range factors {
+		if factor != 0 && product > math.MaxUint64/factor {
+			return math.MaxUint64
+		}
+		product *= factor
+	}
+	return product
+}
+
+func saturatingAdd(a, b uint64) uint64 {
+	if a > math.MaxUint64-b {
+		return math.MaxUint64
+	}
+	return a + b
+}
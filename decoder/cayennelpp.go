@@ -0,0 +1,198 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package decoder
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// CayenneLPPName is the name the CayenneLPP decoder is registered with.
+	CayenneLPPName = "cayennelpp"
+
+	// CayenneLPPAssetType is the asset type for devices sending CayenneLPP payloads.
+	CayenneLPPAssetType = "loriot_io_cayenne_lpp"
+)
+
+func init() {
+	Register(CayenneLPPName, CayenneLPP{})
+	Register(CayenneLPPAssetType, CayenneLPP{})
+}
+
+// lppField is one value of an LPP data type. Multi value types like GPS have one field per value.
+type lppField struct {
+	suffix  string
+	size    int
+	signed  bool
+	divisor float64
+	unit    string
+}
+
+// lppType describes an IPSO data type as used by Cayenne Low Power Payload.
+type lppType struct {
+	name   string
+	fields []lppField
+	unit   string
+	en, de string
+}
+
+func (t lppType) size() int {
+	size := 0
+	for _, field := range t.fields {
+		size += field.size
+	}
+	return size
+}
+
+func single(size int, signed bool, divisor float64) []lppField {
+	return []lppField{{size: size, signed: signed, divisor: divisor}}
+}
+
+func axes(size int, divisor float64) []lppField {
+	return []lppField{
+		{suffix: "x", size: size, signed: true, divisor: divisor},
+		{suffix: "y", size: size, signed: true, divisor: divisor},
+		{suffix: "z", size: size, signed: true, divisor: divisor},
+	}
+}
+
+var lppTypes = map[byte]lppType{
+	0:   {name: "digital_input", fields: single(1, false, 1), en: "Digital input", de: "Digitaler Eingang"},
+	1:   {name: "digital_output", fields: single(1, false, 1), en: "Digital output", de: "Digitaler Ausgang"},
+	2:   {name: "analog_input", fields: single(2, true, 100), en: "Analog input", de: "Analoger Eingang"},
+	3:   {name: "analog_output", fields: single(2, true, 100), en: "Analog output", de: "Analoger Ausgang"},
+	100: {name: "generic_sensor", fields: single(4, false, 1), en: "Generic sensor", de: "Generischer Sensor"},
+	101: {name: "illuminance", fields: single(2, false, 1), unit: "lx", en: "Illuminance", de: "Beleuchtungsstärke"},
+	102: {name: "presence", fields: single(1, false, 1), en: "Presence", de: "Präsenz"},
+	103: {name: "temperature", fields: single(2, true, 10), unit: "°C", en: "Temperature", de: "Temperatur"},
+	104: {name: "humidity", fields: single(1, false, 2), unit: "%", en: "Humidity", de: "Luftfeuchtigkeit"},
+	113: {name: "accelerometer", fields: axes(2, 1000), unit: "G", en: "Accelerometer", de: "Beschleunigung"},
+	115: {name: "barometer", fields: single(2, false, 10), unit: "hPa", en: "Barometer", de: "Luftdruck"},
+	116: {name: "voltage", fields: single(2, false, 100), unit: "V", en: "Voltage", de: "Spannung"},
+	117: {name: "current", fields: single(2, false, 1000), unit: "A", en: "Current", de: "Stromstärke"},
+	118: {name: "frequency", fields: single(4, false, 1), unit: "Hz", en: "Frequency", de: "Frequenz"},
+	120: {name: "percentage", fields: single(1, false, 1), unit: "%", en: "Percentage", de: "Prozent"},
+	121: {name: "altitude", fields: single(2, true, 1), unit: "m", en: "Altitude", de: "Höhe"},
+	125: {name: "concentration", fields: single(2, false, 1), unit: "ppm", en: "Concentration", de: "Konzentration"},
+	128: {name: "power", fields: single(2, false, 1), unit: "W", en: "Power", de: "Leistung"},
+	130: {name: "distance", fields: single(4, false, 1000), unit: "m", en: "Distance", de: "Distanz"},
+	131: {name: "energy", fields: single(4, false, 1000), unit: "kWh", en: "Energy", de: "Energie"},
+	132: {name: "direction", fields: single(2, false, 1), unit: "°", en: "Direction", de: "Richtung"},
+	133: {name: "unix_time", fields: single(4, false, 1), unit: "s", en: "Unix time", de: "Unix-Zeit"},
+	134: {name: "gyrometer", fields: axes(2, 100), unit: "°/s", en: "Gyrometer", de: "Gyrometer"},
+	135: {name: "colour", fields: []lppField{
+		{suffix: "r", size: 1, divisor: 1},
+		{suffix: "g", size: 1, divisor: 1},
+		{suffix: "b", size: 1, divisor: 1},
+	}, en: "Colour", de: "Farbe"},
+	136: {name: "gps", fields: []lppField{
+		{suffix: "latitude", size: 3, signed: true, divisor: 10000, unit: "°"},
+		{suffix: "longitude", size: 3, signed: true, divisor: 10000, unit: "°"},
+		{suffix: "altitude", size: 3, signed: true, divisor: 100, unit: "m"},
+	}, en: "GPS", de: "GPS"},
+	142: {name: "switch", fields: single(1, false, 1), en: "Switch", de: "Schalter"},
+}
+
+// lppTypesByName allows describing attributes by the type name contained in the attribute name.
+var lppTypesByName = func() map[string]lppType {
+	types := make(map[string]lppType)
+	for _, t := range lppTypes {
+		types[t.name] = t
+	}
+	return types
+}()
+
+// CayenneLPP decodes Cayenne Low Power Payload. Each value is named by the data type and the channel,
+// e.g. temperature_1. Types with multiple values append the value name, e.g. gps_2_latitude.
+type CayenneLPP struct{}
+
+func (CayenneLPP) Decode(port int, payload []byte) (map[string]any, error) {
+	values := make(map[string]any)
+	for idx := 0; idx < len(payload); {
+		if idx+2 > len(payload) {
+			return nil, fmt.Errorf("incomplete header at byte %d", idx)
+		}
+		channel, typeID := payload[idx], payload[idx+1]
+		idx += 2
+		t, ok := lppTypes[typeID]
+		if !ok {
+			return nil, fmt.Errorf("unknown data type %d on channel %d", typeID, channel)
+		}
+		if idx+t.size() > len(payload) {
+			return nil, fmt.Errorf("incomplete %s value on channel %d", t.name, channel)
+		}
+		for _, field := range t.fields {
+			values[lppAttributeName(t, channel, field)] = field.value(payload[idx : idx+field.size])
+			idx += field.size
+		}
+	}
+	return values, nil
+}
+
+// Describe implements Describer for all attribute names produced by Decode.
+func (CayenneLPP) Describe(name string) (Attribute, bool) {
+	parts := strings.Split(name, "_")
+	for idx := len(parts) - 1; idx > 0; idx-- {
+		t, ok := lppTypesByName[strings.Join(parts[:idx], "_")]
+		if !ok {
+			continue
+		}
+		channel, suffix, _ := strings.Cut(strings.Join(parts[idx:], "_"), "_")
+		for _, field := range t.fields {
+			if field.suffix != suffix {
+				continue
+			}
+			unit := t.unit
+			if field.unit != "" {
+				unit = field.unit
+			}
+			label := strings.TrimSpace(fmt.Sprintf("%s %s", channel, suffix))
+			return Attribute{
+				Name: name,
+				Unit: unit,
+				En:   fmt.Sprintf("%s %s", t.en, label),
+				De:   fmt.Sprintf("%s %s", t.de, label),
+			}, true
+		}
+	}
+	return Attribute{}, false
+}
+
+func lppAttributeName(t lppType, channel byte, field lppField) string {
+	name := fmt.Sprintf("%s_%d", t.name, channel)
+	if field.suffix != "" {
+		name += "_" + field.suffix
+	}
+	return name
+}
+
+func (f lppField) value(data []byte) any {
+	var raw uint64
+	for _, b := range data {
+		raw = raw<<8 | uint64(b)
+	}
+	var value int64
+	if f.signed && raw&(1<<(8*len(data)-1)) != 0 {
+		value = int64(raw) - int64(1)<<(8*len(data))
+	} else {
+		value = int64(raw)
+	}
+	if f.divisor == 1 {
+		return value
+	}
+	return float64(value) / f.divisor
+}
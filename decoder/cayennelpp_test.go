@@ -0,0 +1,61 @@
+package decoder
+
+import (
+	"encoding/hex"
+	"reflect"
+	"testing"
+)
+
+func TestCayenneLPPDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    map[string]any
+		wantErr bool
+	}{
+		{"two temperatures", "03670110056700ff", map[string]any{"temperature_3": 27.2, "temperature_5": 25.5}, false},
+		{"negative temperature", "0167ffd7", map[string]any{"temperature_1": -4.1}, false},
+		{"accelerometer", "067104d2fb2e0000", map[string]any{"accelerometer_6_x": 1.234, "accelerometer_6_y": -1.234, "accelerometer_6_z": 0.0}, false},
+		{"gps", "018806765ff2960a0003e8", map[string]any{"gps_1_latitude": 42.3519, "gps_1_longitude": -87.9094, "gps_1_altitude": 10.0}, false},
+		{"mixed channels", "026864000001016500c8", map[string]any{"humidity_2": 50.0, "digital_input_0": int64(1), "illuminance_1": int64(200)}, false},
+		{"empty", "", map[string]any{}, false},
+		{"unknown type", "01ff00", nil, true},
+		{"truncated value", "016701", nil, true},
+		{"truncated header", "0167011001", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, _ := hex.DecodeString(tt.payload)
+			got, err := CayenneLPP{}.Decode(1, payload)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Decode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Decode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCayenneLPPDescribe(t *testing.T) {
+	tests := []struct {
+		name string
+		want Attribute
+		ok   bool
+	}{
+		{"temperature_3", Attribute{Name: "temperature_3", Unit: "°C", En: "Temperature 3", De: "Temperatur 3"}, true},
+		{"gps_1_latitude", Attribute{Name: "gps_1_latitude", Unit: "°", En: "GPS 1 latitude", De: "GPS 1 latitude"}, true},
+		{"gps_1_altitude", Attribute{Name: "gps_1_altitude", Unit: "m", En: "GPS 1 altitude", De: "GPS 1 altitude"}, true},
+		{"unix_time_2", Attribute{Name: "unix_time_2", Unit: "s", En: "Unix time 2", De: "Unix-Zeit 2"}, true},
+		{"gps_1_speed", Attribute{}, false},
+		{"rssi", Attribute{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := CayenneLPP{}.Describe(tt.name)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("Describe() = %+v, %v, want %+v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package decoder
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Decoder converts the application payload of an uplink to attribute values of an Eliona asset. The keys of
+// the returned map are the attribute names defined by the asset type.
+type Decoder interface {
+	Decode(port int, payload []byte) (map[string]any, error)
+}
+
+// Attribute describes an attribute produced by a decoder.
+type Attribute struct {
+	Name string
+	Unit string
+	En   string
+	De   string
+}
+
+// Describer is implemented by self-describing decoders, which know the meaning of each attribute they produce.
+// It allows creating missing asset type attributes on first sight of a value.
+type Describer interface {
+	Describe(name string) (Attribute, bool)
+}
+
+// DecoderFunc allows using an ordinary function as Decoder.
+type DecoderFunc func(port int, payload []byte) (map[string]any, error)
+
+func (f DecoderFunc) Decode(port int, payload []byte) (map[string]any, error) {
+	return f(port, payload)
+}
+
+// Provider returns a decoder for the given name or false if the provider has no decoder with this name.
+// Providers are used for decoders which are not known at compile time, e.g. stored in the database.
+type Provider func(name string) (Decoder, bool, error)
+
+var (
+	mutex     sync.RWMutex
+	decoders  = make(map[string]Decoder)
+	providers []Provider
+)
+
+// Register makes a decoder available under the given name. Registering a name twice replaces the decoder.
+func Register(name string, decoder Decoder) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	decoders[name] = decoder
+}
+
+// RegisterProvider adds a provider asked for all names without registered decoder.
+func RegisterProvider(provider Provider) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	providers = append(providers, provider)
+}
+
+// Lookup returns the decoder for the first of the given names having one. Empty names are skipped, so a
+// per-device override can be passed before the asset type name. Returns nil if no decoder is found. Providers are
+// called without holding the lock, so registering isn't blocked by slow providers.
+func Lookup(names ...string) (Decoder, error) {
+	mutex.RLock()
+	registered := make([]Decoder, len(names))
+	for i, name := range names {
+		registered[i] = decoders[name]
+	}
+	currentProviders := append([]Provider(nil), providers...)
+	mutex.RUnlock()
+
+	for i, name := range names {
+		if name == "" {
+			continue
+		}
+		if registered[i] != nil {
+			return registered[i], nil
+		}
+		for _, provider := range currentProviders {
+			decoder, ok, err := provider(name)
+			if err != nil {
+				return nil, fmt.Errorf("looking up decoder %s: %w", name, err)
+			}
+			if ok {
+				return decoder, nil
+			}
+		}
+	}
+	return nil, nil
+}
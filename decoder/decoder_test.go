@@ -0,0 +1,47 @@
+package decoder
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	Register("test_temperature", DecoderFunc(func(port int, payload []byte) (map[string]any, error) {
+		return map[string]any{"temperature": float64(payload[0]) / 10}, nil
+	}))
+	RegisterProvider(func(name string) (Decoder, bool, error) {
+		switch name {
+		case "test_provided":
+			return DecoderFunc(func(port int, payload []byte) (map[string]any, error) {
+				return map[string]any{"port": port}, nil
+			}), true, nil
+		case "test_broken":
+			return nil, false, errors.New("broken")
+		}
+		return nil, false, nil
+	})
+
+	decoder, err := Lookup("", "test_unknown", "test_temperature")
+	if err != nil || decoder == nil {
+		t.Fatalf("Lookup() = %v, %v", decoder, err)
+	}
+	values, err := decoder.Decode(1, []byte{215})
+	if err != nil || values["temperature"] != 21.5 {
+		t.Errorf("Decode() = %v, %v", values, err)
+	}
+
+	decoder, err = Lookup("test_provided", "test_temperature")
+	if err != nil || decoder == nil {
+		t.Fatalf("Lookup() = %v, %v", decoder, err)
+	}
+	if values, _ := decoder.Decode(7, nil); values["port"] != 7 {
+		t.Errorf("Lookup() prefers the first name, got %v", values)
+	}
+
+	if decoder, err := Lookup("test_unknown"); err != nil || decoder != nil {
+		t.Errorf("Lookup() of unknown = %v, %v", decoder, err)
+	}
+	if _, err := Lookup("test_broken"); err == nil {
+		t.Errorf("Lookup() expected provider error")
+	}
+}
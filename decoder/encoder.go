@@ -0,0 +1,79 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package decoder
+
+import (
+	"fmt"
+)
+
+// Downlink is an encoded payload ready to be sent to a device.
+type Downlink struct {
+	Port      int
+	Payload   []byte
+	Confirmed bool
+}
+
+// Encoder converts output attribute values of an Eliona asset to a downlink payload.
+type Encoder interface {
+	Encode(data map[string]any) (Downlink, error)
+}
+
+// EncoderProvider returns an encoder for the given name or false if the provider has no encoder with this name.
+type EncoderProvider func(name string) (Encoder, bool, error)
+
+var (
+	encoders         = make(map[string]Encoder)
+	encoderProviders []EncoderProvider
+)
+
+// RegisterEncoder makes an encoder available under the given name. Registering a name twice replaces the encoder.
+func RegisterEncoder(name string, encoder Encoder) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	encoders[name] = encoder
+}
+
+// RegisterEncoderProvider adds a provider asked for all names without registered encoder.
+func RegisterEncoderProvider(provider EncoderProvider) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	encoderProviders = append(encoderProviders, provider)
+}
+
+// LookupEncoder returns the encoder for the first of the given names having one. Empty names are skipped.
+// Returns nil if no encoder is found.
+func LookupEncoder(names ...string) (Encoder, error) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		if encoder, ok := encoders[name]; ok {
+			return encoder, nil
+		}
+		for _, provider := range encoderProviders {
+			encoder, ok, err := provider(name)
+			if err != nil {
+				return nil, fmt.Errorf("looking up encoder %s: %w", name, err)
+			}
+			if ok {
+				return encoder, nil
+			}
+		}
+	}
+	return nil, nil
+}
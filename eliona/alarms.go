@@ -0,0 +1,59 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"fmt"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-eliona/client"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+// UpsertAlarmRule creates an alarm rule raising an alarm while the attribute of the asset equals 1, unless the asset
+// already has a rule for the attribute. Returns the ID of the rule.
+func UpsertAlarmRule(assetID int32, subtype api.DataSubtype, attribute string, message api.Translation) (int32, error) {
+	rules, _, err := client.NewClient().AlarmRulesAPI.
+		GetAlarmRules(client.AuthenticationContext()).
+		AssetId(assetID).
+		Execute()
+	if err != nil {
+		return 0, fmt.Errorf("fetching alarm rules of asset %d: %w", assetID, err)
+	}
+	for _, rule := range rules {
+		if rule.Subtype == subtype && rule.Attribute == attribute && rule.Id.Get() != nil {
+			return *rule.Id.Get(), nil
+		}
+	}
+
+	rule := api.NewAlarmRule(assetID, subtype, attribute, api.ALARM_PRIORITY_MEDIUM)
+	rule.Equal = *api.NewNullableFloat64(common.Ptr(float64(1)))
+	rule.Message = map[string]any{
+		"de": message.GetDe(),
+		"en": message.GetEn(),
+	}
+	created, _, err := client.NewClient().AlarmRulesAPI.
+		PostAlarmRule(client.AuthenticationContext()).
+		AlarmRule(*rule).
+		Execute()
+	if err != nil {
+		return 0, fmt.Errorf("creating alarm rule for attribute %s of asset %d: %w", attribute, assetID, err)
+	}
+	if created.Id.Get() == nil {
+		return 0, fmt.Errorf("no id returned for alarm rule of asset %d", assetID)
+	}
+	return *created.Id.Get(), nil
+}
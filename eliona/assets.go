@@ -22,6 +22,8 @@ import (
 	"github.com/eliona-smart-building-assistant/go-utils/common"
 	"github.com/eliona-smart-building-assistant/go-utils/http"
 	"loriot-io/apiserver"
+	"loriot-io/loriot"
+	"strings"
 	"time"
 
 	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
@@ -37,26 +39,115 @@ type Asset interface {
 	Id() string
 }
 
-// UpsertAssetWithPutDeviceRequest creates a new or gets an existing Eliona asset. Returns the new or existing asset or error if failed.
-func UpsertAssetWithPutDeviceRequest(ctx context.Context, projectID string, putDeviceRequest apiserver.PutDeviceRequest) (*api.Asset, error) {
-	return upsertAssetByDeviceId(ctx, api.Asset{
+// UpsertAssetWithPutDeviceRequest creates a new or updates an existing Eliona asset below the root asset of its
+// project. Returns the new or updated asset or error if failed.
+func UpsertAssetWithPutDeviceRequest(ctx context.Context, rootAsset api.Asset, putDeviceRequest apiserver.PutDeviceRequest) (*api.Asset, error) {
+	return putAssetByDeviceId(api.Asset{
 		DeviceIds: []string{
 			putDeviceRequest.DevEUI,
 		},
+		ProjectId:               rootAsset.ProjectId,
+		GlobalAssetIdentifier:   fmt.Sprintf("%s %s", putDeviceRequest.Title, putDeviceRequest.DevEUI[len(putDeviceRequest.DevEUI)-4:]),
+		Name:                    *api.NewNullableString(&putDeviceRequest.Title),
+		Description:             *api.NewNullableString(&putDeviceRequest.Description),
+		AssetType:               putDeviceRequest.AssetTypeName,
+		ParentLocationalAssetId: rootAsset.Id,
+	})
+}
+
+// GetAssetByDeviceId returns the asset of the project having the device ID, or nil if there is none.
+func GetAssetByDeviceId(projectID string, deviceID string) (*api.Asset, error) {
+	assets, err := GetProjectAssets(projectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, asset := range assets {
+		for _, id := range asset.DeviceIds {
+			if strings.EqualFold(id, deviceID) {
+				return &asset, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// RestoreAsset sets the asset identified by its device ID back to the given previous state.
+func RestoreAsset(previous api.Asset) error {
+	previous.ChildrenInfo = nil
+	if _, err := putAssetByDeviceId(previous); err != nil {
+		return fmt.Errorf("restoring asset %s: %w", previous.GlobalAssetIdentifier, err)
+	}
+	return nil
+}
+
+// UpsertAssetWithDevice creates a new or gets an existing Eliona asset for a device provisioned in Loriot.io. Returns the
+// new or existing asset or error if failed.
+func UpsertAssetWithDevice(ctx context.Context, projectID string, device loriot.Device, assetType string) (*api.Asset, error) {
+	title := device.Title
+	if title == "" {
+		title = device.DevEUI
+	}
+	return upsertAssetByDeviceId(ctx, api.Asset{
+		DeviceIds: []string{
+			device.DevEUI,
+		},
 		ProjectId:             projectID,
-		GlobalAssetIdentifier: fmt.Sprintf("%s %s", putDeviceRequest.Title, putDeviceRequest.DevEUI[len(putDeviceRequest.DevEUI)-4:]),
-		Name:                  *api.NewNullableString(&putDeviceRequest.Title),
-		Description:           *api.NewNullableString(&putDeviceRequest.Description),
-		AssetType:             putDeviceRequest.AssetTypeName,
+		GlobalAssetIdentifier: fmt.Sprintf("%s %s", title, device.DevEUI[len(device.DevEUI)-4:]),
+		Name:                  *api.NewNullableString(&title),
+		Description:           *api.NewNullableString(&device.Description),
+		AssetType:             assetType,
 	})
 }
 
+// GetProjectAssets returns all assets of the project including their device IDs.
+func GetProjectAssets(projectID string) ([]api.Asset, error) {
+	assets, _, err := client.NewClient().AssetsAPI.
+		GetAssets(client.AuthenticationContext()).
+		ProjectId(projectID).
+		Expansions([]string{"Asset.deviceIds"}).
+		Execute()
+	if err != nil {
+		return nil, fmt.Errorf("fetching assets of project %s: %w", projectID, err)
+	}
+	return assets, nil
+}
+
+// GetDeviceAssets returns all assets of the project having device IDs.
+func GetDeviceAssets(projectID string) ([]api.Asset, error) {
+	assets, err := GetProjectAssets(projectID)
+	if err != nil {
+		return nil, err
+	}
+	var deviceAssets []api.Asset
+	for _, asset := range assets {
+		if len(asset.DeviceIds) > 0 {
+			deviceAssets = append(deviceAssets, asset)
+		}
+	}
+	return deviceAssets, nil
+}
+
+// DeleteAsset deletes the asset in Eliona.
+func DeleteAsset(assetID int32) error {
+	_, err := client.NewClient().AssetsAPI.
+		DeleteAssetById(client.AuthenticationContext(), assetID).
+		Execute()
+	if err != nil {
+		return fmt.Errorf("deleting asset %d: %w", assetID, err)
+	}
+	return nil
+}
+
 func upsertAssetByDeviceId(ctx context.Context, asset api.Asset) (*api.Asset, error) {
 	rootAsset, err := upsertRootAsset(asset.ProjectId)
 	if err != nil || rootAsset == nil {
 		return rootAsset, err
 	}
 	asset.ParentLocationalAssetId = rootAsset.Id
+	return putAssetByDeviceId(asset)
+}
+
+func putAssetByDeviceId(asset api.Asset) (*api.Asset, error) {
 	assetReturn, _, err := client.NewClient().AssetsAPI.
 		PutAsset(client.AuthenticationContext()).
 		IdentifyBy("deviceId").
@@ -66,15 +157,37 @@ func upsertAssetByDeviceId(ctx context.Context, asset api.Asset) (*api.Asset, er
 }
 
 func upsertRootAsset(projectID string) (*api.Asset, error) {
+	asset, _, err := UpsertRootAsset(projectID)
+	return asset, err
+}
+
+// GetRootAsset returns the root asset of the app in the project, or nil if it doesn't exist yet.
+func GetRootAsset(projectID string) (*api.Asset, error) {
 	assets, _, err := client.NewClient().AssetsAPI.
 		GetAssets(client.AuthenticationContext()).
 		AssetTypeName(RootAssetType).
+		ProjectId(projectID).
 		Execute()
 	if err != nil {
 		return nil, err
 	}
-	if len(assets) > 0 {
-		return common.Ptr(assets[0]), nil
+	for _, asset := range assets {
+		if asset.ProjectId == projectID {
+			return common.Ptr(asset), nil
+		}
+	}
+	return nil, nil
+}
+
+// UpsertRootAsset returns the root asset of the app, creating it in the project if missing. Returns whether the root
+// asset was created.
+func UpsertRootAsset(projectID string) (*api.Asset, bool, error) {
+	rootAsset, err := GetRootAsset(projectID)
+	if err != nil {
+		return nil, false, err
+	}
+	if rootAsset != nil {
+		return rootAsset, false, nil
 	}
 	asset, _, err := client.NewClient().AssetsAPI.
 		PutAsset(client.AuthenticationContext()).
@@ -86,7 +199,7 @@ func upsertRootAsset(projectID string) (*api.Asset, error) {
 				AssetType:             RootAssetType,
 			}).
 		Execute()
-	return asset, err
+	return asset, err == nil, err
 }
 
 func AssetFromAssetListen(assetListen api.AssetListen) (api.Asset, int32) {
@@ -116,12 +229,19 @@ func AssetFromAssetListen(assetListen api.AssetListen) (api.Asset, int32) {
 	}, statusCode
 }
 
-// ListenForAssetChanges returns a channel for listening of asset changes in Eliona
-func ListenForAssetChanges() (chan api.AssetListen, error) {
+// ListenForAssetChanges returns a channel for listening of asset changes in Eliona. The onConnect function is called
+// in the background whenever the websocket is (re)connected, so changes missed meanwhile can be caught up.
+func ListenForAssetChanges(onConnect func()) (chan api.AssetListen, error) {
 	assets := make(chan api.AssetListen)
 	var err error
 	go func() {
-		err = http.ListenWebSocketWithReconnectAlways(assetListenerWebsocket, time.Duration(0), assets)
+		err = http.ListenWebSocketWithReconnectAlways(func() (*websocket.Conn, error) {
+			conn, err := assetListenerWebsocket()
+			if err == nil && onConnect != nil {
+				go onConnect()
+			}
+			return conn, err
+		}, time.Duration(0), assets)
 	}()
 	return assets, err
 }
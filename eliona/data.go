@@ -0,0 +1,182 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"fmt"
+	"loriot-io/decoder"
+	"loriot-io/loriot"
+	"sync"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-eliona/asset"
+	"github.com/eliona-smart-building-assistant/go-eliona/client"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/http"
+	"github.com/gorilla/websocket"
+)
+
+// ClientReference marks data written by this app in Eliona.
+const ClientReference = "loriot-io"
+
+// attributeSubtypesTTL defines how long the attributes of an asset type are cached.
+const attributeSubtypesTTL = 5 * time.Minute
+
+type cachedAttributeSubtypes struct {
+	subtypes  map[string]api.DataSubtype
+	fetchedAt time.Time
+}
+
+var (
+	attributeSubtypesMutex sync.Mutex
+	attributeSubtypes      = make(map[string]cachedAttributeSubtypes)
+)
+
+// AttributeSubtypes returns the subtype of each attribute defined by the asset type. The result is cached for a while
+// to avoid fetching the asset type for each uplink.
+func AttributeSubtypes(assetType string) (map[string]api.DataSubtype, error) {
+	attributeSubtypesMutex.Lock()
+	defer attributeSubtypesMutex.Unlock()
+	if cached, ok := attributeSubtypes[assetType]; ok && time.Since(cached.fetchedAt) < attributeSubtypesTTL {
+		return cached.subtypes, nil
+	}
+	elionaAssetType, _, err := client.NewClient().AssetTypesAPI.
+		GetAssetTypeByName(client.AuthenticationContext(), assetType).
+		Expansions([]string{"AssetType.attributes"}).
+		Execute()
+	if err != nil {
+		return nil, fmt.Errorf("fetching asset type %s: %w", assetType, err)
+	}
+	subtypes := make(map[string]api.DataSubtype)
+	for _, attribute := range elionaAssetType.GetAttributes() {
+		subtypes[attribute.Name] = attribute.Subtype
+	}
+	attributeSubtypes[assetType] = cachedAttributeSubtypes{subtypes: subtypes, fetchedAt: time.Now()}
+	return subtypes, nil
+}
+
+// UpsertMissingAttributes adds an input attribute to the asset type for each decoded value without attribute, so
+// the values of self-describing decoders are visible in Eliona from the first uplink on.
+func UpsertMissingAttributes(assetType string, decoded map[string]any, describer decoder.Describer) error {
+	subtypes, err := AttributeSubtypes(assetType)
+	if err != nil {
+		return err
+	}
+	var added bool
+	for name := range decoded {
+		if _, ok := subtypes[name]; ok {
+			continue
+		}
+		attribute, ok := describer.Describe(name)
+		if !ok {
+			continue
+		}
+		err := asset.UpsertAssetTypeAttribute(api.AssetTypeAttribute{
+			AssetTypeName: *api.NewNullableString(common.Ptr(assetType)),
+			Name:          attribute.Name,
+			Subtype:       api.SUBTYPE_INPUT,
+			Enable:        common.Ptr(true),
+			Unit:          *api.NewNullableString(common.Ptr(attribute.Unit)),
+			Translation: *api.NewNullableTranslation(&api.Translation{
+				De: common.Ptr(attribute.De),
+				En: common.Ptr(attribute.En),
+			}),
+		})
+		if err != nil {
+			return fmt.Errorf("upserting attribute %s for asset type %s: %w", name, assetType, err)
+		}
+		added = true
+	}
+	if added {
+		attributeSubtypesMutex.Lock()
+		delete(attributeSubtypes, assetType)
+		attributeSubtypesMutex.Unlock()
+	}
+	return nil
+}
+
+// UpsertUplinkData writes the payload and radio information of a received uplink as input data to the asset. The
+// decoded values are written to the subtype of the attribute with the same name in the asset type. Values without
+// attribute are written as input data.
+func UpsertUplinkData(assetID int32, assetType string, message loriot.Message, decoded map[string]any) error {
+	input := map[string]any{
+		"payload":   message.Data,
+		"port":      message.Port,
+		"fcnt":      message.FCnt,
+		"rssi":      message.Rssi,
+		"snr":       message.Snr,
+		"frequency": message.Freq,
+		"data_rate": message.Dr,
+	}
+	if gateway := message.BestGateway(); gateway != nil {
+		input["gateway_eui"] = gateway.GwEUI
+		input["gateway_time"] = gateway.Time
+		input["gateways"] = len(message.Gws)
+	}
+	dataBySubtype := map[api.DataSubtype]map[string]any{
+		api.SUBTYPE_INPUT: input,
+	}
+	if len(decoded) > 0 {
+		subtypes := map[string]api.DataSubtype{}
+		if assetType != "" {
+			var err error
+			subtypes, err = AttributeSubtypes(assetType)
+			if err != nil {
+				return err
+			}
+		}
+		for name, value := range decoded {
+			subtype, ok := subtypes[name]
+			if !ok {
+				subtype = api.SUBTYPE_INPUT
+			}
+			if dataBySubtype[subtype] == nil {
+				dataBySubtype[subtype] = make(map[string]any)
+			}
+			dataBySubtype[subtype][name] = value
+		}
+	}
+
+	var datas []api.Data
+	for subtype, data := range dataBySubtype {
+		datas = append(datas, api.Data{
+			AssetId:         assetID,
+			Subtype:         subtype,
+			Timestamp:       *api.NewNullableTime(common.Ptr(message.Timestamp())),
+			Data:            data,
+			ClientReference: *api.NewNullableString(common.Ptr(ClientReference)),
+		})
+	}
+	if err := asset.UpsertDataBulk(datas); err != nil {
+		return fmt.Errorf("upserting uplink data for asset %d: %w", assetID, err)
+	}
+	return nil
+}
+
+// ListenForOutputData returns a channel for listening of output data changes in Eliona
+func ListenForOutputData() (chan api.Data, error) {
+	data := make(chan api.Data)
+	var err error
+	go func() {
+		err = http.ListenWebSocketWithReconnectAlways(outputDataListenerWebsocket, time.Duration(0), data)
+	}()
+	return data, err
+}
+
+func outputDataListenerWebsocket() (*websocket.Conn, error) {
+	return http.NewWebSocketConnectionWithApiKey(common.Getenv("API_ENDPOINT", "")+"/data-listener?dataSubtype="+string(api.SUBTYPE_OUTPUT), "X-API-Key", common.Getenv("API_TOKEN", ""))
+}
@@ -0,0 +1,117 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"context"
+	"fmt"
+	"loriot-io/loriot"
+	"strings"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-eliona/asset"
+	"github.com/eliona-smart-building-assistant/go-eliona/client"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+const (
+	GatewayAssetType = "loriot_io_gateway"
+)
+
+// UpsertGatewayAsset creates a new or gets an existing Eliona asset for the gateway below the root asset of the
+// project. Returns the new or existing asset or error if failed.
+func UpsertGatewayAsset(ctx context.Context, projectID string, gateway loriot.Gateway) (*api.Asset, error) {
+	rootAsset, err := upsertRootAsset(projectID)
+	if err != nil || rootAsset == nil {
+		return rootAsset, err
+	}
+	title := gateway.Title
+	if title == "" {
+		title = gateway.EUI
+	}
+	gatewayAsset := api.Asset{
+		ProjectId:               projectID,
+		GlobalAssetIdentifier:   fmt.Sprintf("%s %s", GatewayAssetType, strings.ToUpper(gateway.EUI)),
+		Name:                    *api.NewNullableString(&title),
+		AssetType:               GatewayAssetType,
+		ParentLocationalAssetId: rootAsset.Id,
+	}
+	if gateway.HasLocation() {
+		gatewayAsset.Latitude = *api.NewNullableFloat64(common.Ptr(gateway.Location.Lat))
+		gatewayAsset.Longitude = *api.NewNullableFloat64(common.Ptr(gateway.Location.Lon))
+	}
+	assetReturn, _, err := client.NewClient().AssetsAPI.
+		PutAsset(client.AuthenticationContext()).
+		Asset(gatewayAsset).
+		Execute()
+	return assetReturn, err
+}
+
+// UpsertGatewayData writes the status of the gateway and the number of devices last heard via the gateway to the asset.
+// The number of devices is omitted if nil. The offline state raises the offline alarm of the gateway.
+func UpsertGatewayData(assetID int32, gateway loriot.Gateway, devices *int, offline bool) error {
+	var datas []api.Data
+	for subtype, data := range gatewayData(gateway, devices, offline) {
+		datas = append(datas, api.Data{
+			AssetId:         assetID,
+			Subtype:         subtype,
+			Data:            data,
+			ClientReference: *api.NewNullableString(common.Ptr(ClientReference)),
+		})
+	}
+	if err := asset.UpsertDataBulk(datas); err != nil {
+		return fmt.Errorf("upserting gateway data for asset %d: %w", assetID, err)
+	}
+	return nil
+}
+
+func gatewayData(gateway loriot.Gateway, devices *int, offline bool) map[api.DataSubtype]map[string]any {
+	status := map[string]any{
+		"online":  0,
+		"offline": 0,
+	}
+	if offline {
+		status["offline"] = 1
+	}
+	if devices != nil {
+		status["devices"] = *devices
+	}
+	if gateway.Connected {
+		status["online"] = 1
+	}
+	if !gateway.LastSeen.IsZero() {
+		status["last_seen"] = gateway.LastSeen.Format(time.RFC3339)
+	}
+	model := gateway.Model
+	if model == "" {
+		model = gateway.Base
+	}
+	info := map[string]any{
+		"eui":      strings.ToUpper(gateway.EUI),
+		"model":    model,
+		"firmware": gateway.Version,
+	}
+	if gateway.HasLocation() {
+		info["latitude"] = gateway.Location.Lat
+		info["longitude"] = gateway.Location.Lon
+		info["altitude"] = gateway.Location.Alt
+	}
+	return map[api.DataSubtype]map[string]any{
+		api.SUBTYPE_STATUS: status,
+		api.SUBTYPE_INFO:   info,
+	}
+}
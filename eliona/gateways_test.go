@@ -0,0 +1,36 @@
+package eliona
+
+import (
+	"loriot-io/loriot"
+	"testing"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+func TestGatewayData(t *testing.T) {
+	data := gatewayData(loriot.Gateway{
+		EUI:       "b827ebfffe000001",
+		Base:      "kerlink",
+		Version:   "5.1",
+		Connected: true,
+		Location:  loriot.GatewayLocation{Lat: 47.37, Lon: 8.54, Alt: 420},
+	}, common.Ptr(3), false)
+
+	status := data[api.SUBTYPE_STATUS]
+	if status["online"] != 1 || status["offline"] != 0 || status["devices"] != 3 {
+		t.Errorf("gatewayData() status = %v", status)
+	}
+	info := data[api.SUBTYPE_INFO]
+	if info["eui"] != "B827EBFFFE000001" || info["model"] != "kerlink" || info["firmware"] != "5.1" || info["latitude"] != 47.37 {
+		t.Errorf("gatewayData() info = %v", info)
+	}
+
+	data = gatewayData(loriot.Gateway{EUI: "B827EBFFFE000001"}, nil, true)
+	if _, ok := data[api.SUBTYPE_STATUS]["devices"]; ok || data[api.SUBTYPE_STATUS]["online"] != 0 || data[api.SUBTYPE_STATUS]["offline"] != 1 {
+		t.Errorf("gatewayData() status = %v", data[api.SUBTYPE_STATUS])
+	}
+	if _, ok := data[api.SUBTYPE_INFO]["latitude"]; ok {
+		t.Errorf("gatewayData() unexpected location for gateway without location")
+	}
+}
@@ -0,0 +1,142 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"fmt"
+	"loriot-io/loriot"
+	"math"
+	"strings"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-eliona/asset"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+// statusAttribute describes an attribute of the standard attribute set written for each device asset.
+type statusAttribute struct {
+	name    string
+	subtype api.DataSubtype
+	unit    string
+	en      string
+	de      string
+}
+
+// statusAttributes are the radio and health attributes added to the asset types of all device assets.
+var statusAttributes = []statusAttribute{
+	{"battery", api.SUBTYPE_STATUS, "%", "Battery level", "Batteriestand"},
+	{"last_rssi", api.SUBTYPE_STATUS, "dBm", "Last signal strength", "Letzte Signalstärke"},
+	{"last_snr", api.SUBTYPE_STATUS, "dB", "Last signal-to-noise ratio", "Letztes Signal-Rausch-Verhältnis"},
+	{"spreading_factor", api.SUBTYPE_STATUS, "", "Spreading factor", "Spreizfaktor"},
+	{"last_seen", api.SUBTYPE_STATUS, "", "Last seen", "Zuletzt gesehen"},
+	{"offline", api.SUBTYPE_STATUS, "", "Offline", "Offline"},
+	{"last_join", api.SUBTYPE_INFO, "", "Last join", "Letzter Join"},
+	{"last_frequency", api.SUBTYPE_INFO, "Hz", "Last frequency", "Letzte Frequenz"},
+	{"last_gateway", api.SUBTYPE_INFO, "", "Last gateway", "Letztes Gateway"},
+	{"dev_addr", api.SUBTYPE_INFO, "", "Device address", "Geräteadresse"},
+	{"fcnt_up", api.SUBTYPE_INFO, "", "Uplink frame counter", "Uplink-Frame-Zähler"},
+	{"fcnt_down", api.SUBTYPE_INFO, "", "Downlink frame counter", "Downlink-Frame-Zähler"},
+}
+
+// UpsertStatusAttributes adds the missing attributes of the standard attribute set to the asset type.
+func UpsertStatusAttributes(assetType string) error {
+	subtypes, err := AttributeSubtypes(assetType)
+	if err != nil {
+		return err
+	}
+	var added bool
+	for _, attribute := range statusAttributes {
+		if _, ok := subtypes[attribute.name]; ok {
+			continue
+		}
+		err := asset.UpsertAssetTypeAttribute(api.AssetTypeAttribute{
+			AssetTypeName: *api.NewNullableString(common.Ptr(assetType)),
+			Name:          attribute.name,
+			Subtype:       attribute.subtype,
+			Enable:        common.Ptr(true),
+			Unit:          *api.NewNullableString(common.Ptr(attribute.unit)),
+			Translation: *api.NewNullableTranslation(&api.Translation{
+				De: common.Ptr(attribute.de),
+				En: common.Ptr(attribute.en),
+			}),
+		})
+		if err != nil {
+			return fmt.Errorf("upserting attribute %s for asset type %s: %w", attribute.name, assetType, err)
+		}
+		added = true
+	}
+	if added {
+		attributeSubtypesMutex.Lock()
+		delete(attributeSubtypes, assetType)
+		attributeSubtypesMutex.Unlock()
+	}
+	return nil
+}
+
+// UpsertStatusData writes the radio and health metadata known by Loriot.io as status and info data to the asset. The
+// offline state, if watched, raises the offline alarm of the device.
+func UpsertStatusData(assetID int32, device loriot.Device, offline *bool) error {
+	dataBySubtype := statusData(device, offline)
+	var datas []api.Data
+	for subtype, data := range dataBySubtype {
+		datas = append(datas, api.Data{
+			AssetId:         assetID,
+			Subtype:         subtype,
+			Data:            data,
+			ClientReference: *api.NewNullableString(common.Ptr(ClientReference)),
+		})
+	}
+	if err := asset.UpsertDataBulk(datas); err != nil {
+		return fmt.Errorf("upserting status data for asset %d: %w", assetID, err)
+	}
+	return nil
+}
+
+func statusData(device loriot.Device, offline *bool) map[api.DataSubtype]map[string]any {
+	status := map[string]any{
+		"last_rssi":        device.Rssi,
+		"last_snr":         device.Snr,
+		"spreading_factor": device.Sf,
+	}
+	if offline != nil {
+		status["offline"] = 0
+		if *offline {
+			status["offline"] = 1
+		}
+	}
+	// Loriot reports the battery as defined by LoRaWAN: 0 for external power, 255 if unknown, 1 to 254 otherwise
+	if device.Bat > 0 && device.Bat < 255 {
+		status["battery"] = math.Round(float64(device.Bat) / 254 * 100)
+	}
+	if !device.LastSeen.IsZero() {
+		status["last_seen"] = device.LastSeen.Format(time.RFC3339)
+	}
+	info := map[string]any{
+		"last_frequency": device.Freq,
+		"last_gateway":   strings.ToUpper(device.Gw),
+		"dev_addr":       strings.ToUpper(device.DevAddr),
+		"fcnt_up":        device.SeqNo,
+		"fcnt_down":      device.SeqDN,
+	}
+	if !device.LastJoin.IsZero() {
+		info["last_join"] = device.LastJoin.Format(time.RFC3339)
+	}
+	return map[api.DataSubtype]map[string]any{
+		api.SUBTYPE_STATUS: status,
+		api.SUBTYPE_INFO:   info,
+	}
+}
@@ -0,0 +1,54 @@
+package eliona
+
+import (
+	"loriot-io/loriot"
+	"testing"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+func TestStatusData(t *testing.T) {
+	lastSeen := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	data := statusData(loriot.Device{
+		Bat:      127,
+		Rssi:     -87,
+		Snr:      7.5,
+		Sf:       9,
+		Freq:     868100000,
+		Gw:       "b827ebfffe000001",
+		LastSeen: lastSeen,
+		DevAddr:  "26011bda",
+		SeqNo:    42,
+		SeqDN:    3,
+	}, nil)
+
+	status := data[api.SUBTYPE_STATUS]
+	if status["battery"] != float64(50) || status["last_rssi"] != -87 || status["last_snr"] != 7.5 || status["spreading_factor"] != 9 {
+		t.Errorf("statusData() status = %v", status)
+	}
+	if status["last_seen"] != "2024-03-01T12:00:00Z" {
+		t.Errorf("statusData() last_seen = %v", status["last_seen"])
+	}
+	info := data[api.SUBTYPE_INFO]
+	if info["last_gateway"] != "B827EBFFFE000001" || info["dev_addr"] != "26011BDA" || info["fcnt_up"] != 42 || info["fcnt_down"] != 3 {
+		t.Errorf("statusData() info = %v", info)
+	}
+	if _, ok := status["offline"]; ok {
+		t.Errorf("statusData() unexpected offline state for unwatched device")
+	}
+	if _, ok := info["last_join"]; ok {
+		t.Errorf("statusData() unexpected last_join for device which never joined")
+	}
+
+	for _, bat := range []int{0, 255} {
+		if _, ok := statusData(loriot.Device{Bat: bat}, nil)[api.SUBTYPE_STATUS]["battery"]; ok {
+			t.Errorf("statusData() unexpected battery level for bat %d", bat)
+		}
+	}
+
+	if offline := statusData(loriot.Device{}, common.Ptr(true))[api.SUBTYPE_STATUS]["offline"]; offline != 1 {
+		t.Errorf("statusData() offline = %v", offline)
+	}
+}
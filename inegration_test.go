@@ -18,11 +18,13 @@ func TestApp(t *testing.T) {
 func schema(t *testing.T) {
 	t.Parallel()
 
-	assert.SchemaExists(t, "loriot_io", []string{"configuration", "asset"})
+	assert.SchemaExists(t, "loriot_io", []string{"configuration", "asset", "codec", "downlink", "gateway", "outbox", "idempotency_key", "job", "job_row", "key_batch", "device_key", "key_vault", "key_vault_access"})
 }
 
 func assetTypes(t *testing.T) {
 	t.Parallel()
 
 	assert.AssetTypeExists(t, "loriot_io_root", []string{})
+	assert.AssetTypeExists(t, "loriot_io_cayenne_lpp", []string{"rssi", "snr", "fcnt"})
+	assert.AssetTypeExists(t, "loriot_io_gateway", []string{"online", "offline", "last_seen", "devices", "eui", "model", "firmware", "latitude", "longitude", "altitude"})
 }
@@ -0,0 +1,81 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package loriot
+
+import (
+	"fmt"
+	"loriot-io/apiserver"
+	"regexp"
+)
+
+// Activation modes of a device, derived from the keys given when creating it.
+const (
+	ActivationOTAA10 = "otaa10"
+	ActivationOTAA11 = "otaa11"
+	ActivationABP10  = "abp10"
+	ActivationABP11  = "abp11"
+)
+
+var (
+	eui64Regex   = regexp.MustCompile(`^[A-Fa-f0-9]{16}$`)
+	aesKeyRegex  = regexp.MustCompile(`^[A-Fa-f0-9]{32}$`)
+	devAddrRegex = regexp.MustCompile(`^[A-Fa-f0-9]{8}$`)
+)
+
+// Activation returns the activation mode of a device created with the request.
+func Activation(request apiserver.PutDeviceRequest) string {
+	switch {
+	case request.FNwkSIntKey != "" || request.SNwkSIntKey != "" || request.NwkSEncKey != "":
+		return ActivationABP11
+	case request.DevAddr != "" || request.NwkSKey != "" || request.AppSKey != "":
+		return ActivationABP10
+	case request.JoinEUI != "" || request.NwkKey != "":
+		return ActivationOTAA11
+	default:
+		return ActivationOTAA10
+	}
+}
+
+// ValidateActivation checks if the request contains all keys required by its activation mode in the expected format.
+// Returns the activation mode.
+func ValidateActivation(request apiserver.PutDeviceRequest) (string, error) {
+	activation := Activation(request)
+	type key struct {
+		name  string
+		value string
+		regex *regexp.Regexp
+	}
+	var keys []key
+	switch activation {
+	case ActivationOTAA10:
+		keys = []key{{"appEUI", request.AppEUI, eui64Regex}, {"appKey", request.AppKey, aesKeyRegex}}
+	case ActivationOTAA11:
+		keys = []key{{"joinEUI", request.JoinEUI, eui64Regex}, {"appKey", request.AppKey, aesKeyRegex}, {"nwkKey", request.NwkKey, aesKeyRegex}}
+	case ActivationABP10:
+		keys = []key{{"devAddr", request.DevAddr, devAddrRegex}, {"nwkSKey", request.NwkSKey, aesKeyRegex}, {"appSKey", request.AppSKey, aesKeyRegex}}
+	case ActivationABP11:
+		keys = []key{{"devAddr", request.DevAddr, devAddrRegex}, {"appSKey", request.AppSKey, aesKeyRegex}, {"fNwkSIntKey", request.FNwkSIntKey, aesKeyRegex}, {"sNwkSIntKey", request.SNwkSIntKey, aesKeyRegex}, {"nwkSEncKey", request.NwkSEncKey, aesKeyRegex}}
+	}
+	for _, k := range keys {
+		if k.value == "" {
+			return activation, fmt.Errorf("%s is required for activation %s", k.name, activation)
+		}
+		if !k.regex.MatchString(k.value) {
+			return activation, fmt.Errorf("%s has an invalid format for activation %s", k.name, activation)
+		}
+	}
+	return activation, nil
+}
@@ -0,0 +1,36 @@
+package loriot
+
+import (
+	"loriot-io/apiserver"
+	"testing"
+)
+
+func TestValidateActivation(t *testing.T) {
+	const key = "00112233445566778899AABBCCDDEEFF"
+	tests := []struct {
+		name           string
+		request        apiserver.PutDeviceRequest
+		wantActivation string
+		wantErr        bool
+	}{
+		{"OTAA 1.0", apiserver.PutDeviceRequest{AppEUI: "0123456789ABCDEF", AppKey: key}, ActivationOTAA10, false},
+		{"OTAA 1.0 missing app key", apiserver.PutDeviceRequest{AppEUI: "0123456789ABCDEF"}, ActivationOTAA10, true},
+		{"OTAA 1.1", apiserver.PutDeviceRequest{JoinEUI: "0123456789ABCDEF", AppKey: key, NwkKey: key}, ActivationOTAA11, false},
+		{"OTAA 1.1 short network key", apiserver.PutDeviceRequest{JoinEUI: "0123456789ABCDEF", AppKey: key, NwkKey: "0011"}, ActivationOTAA11, true},
+		{"ABP 1.0", apiserver.PutDeviceRequest{DevAddr: "26011BDA", NwkSKey: key, AppSKey: key}, ActivationABP10, false},
+		{"ABP 1.0 invalid device address", apiserver.PutDeviceRequest{DevAddr: "26011BDX", NwkSKey: key, AppSKey: key}, ActivationABP10, true},
+		{"ABP 1.1", apiserver.PutDeviceRequest{DevAddr: "26011BDA", AppSKey: key, FNwkSIntKey: key, SNwkSIntKey: key, NwkSEncKey: key}, ActivationABP11, false},
+		{"ABP 1.1 missing key", apiserver.PutDeviceRequest{DevAddr: "26011BDA", AppSKey: key, FNwkSIntKey: key}, ActivationABP11, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			activation, err := ValidateActivation(tt.request)
+			if activation != tt.wantActivation {
+				t.Errorf("ValidateActivation() activation = %s, want %s", activation, tt.wantActivation)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateActivation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
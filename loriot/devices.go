@@ -30,11 +30,13 @@ import (
 )
 
 type Meta struct {
-	Apps    []App    `json:"apps"`
-	Devices []Device `json:"devices"`
-	Total   int      `json:"total"`
-	Page    int      `json:"page"`
-	PerPage int      `json:"perPage"`
+	Apps     []App     `json:"apps"`
+	Devices  []Device  `json:"devices"`
+	Networks []Network `json:"networks"`
+	Gateways []Gateway `json:"gateways"`
+	Total    int       `json:"total"`
+	Page     int       `json:"page"`
+	PerPage  int       `json:"perPage"`
 }
 
 type App struct {
@@ -155,13 +157,15 @@ func getFromApi[T any](_ context.Context, config apiserver.Configuration, getDat
 	return results, nil
 }
 
-func getApps(ctx context.Context, config apiserver.Configuration) ([]App, error) {
+// GetApps returns all applications accessible with the token of the configuration.
+func GetApps(ctx context.Context, config apiserver.Configuration) ([]App, error) {
 	var apps []App
 	apps, err := getFromApi[App](ctx, config, func(meta Meta) []App { return meta.Apps }, "/1/nwk/apps")
 	return apps, err
 }
 
-func getDevices(ctx context.Context, config apiserver.Configuration, appId string) ([]Device, error) {
+// GetDevices returns all devices of the application.
+func GetDevices(ctx context.Context, config apiserver.Configuration, appId string) ([]Device, error) {
 	var devices []Device
 	devices, err := getFromApi[Device](ctx, config, func(meta Meta) []Device { return meta.Devices }, "/1/nwk/app/%s/devices", strings.ToUpper(appId))
 	for idx, _ := range devices {
@@ -170,6 +174,11 @@ func getDevices(ctx context.Context, config apiserver.Configuration, appId strin
 	return devices, err
 }
 
+// GetDevice returns the device of the application, or nil if it doesn't exist.
+func GetDevice(ctx context.Context, config apiserver.Configuration, appId string, devEUI string) (*Device, error) {
+	return getDevice(ctx, config, appId, devEUI)
+}
+
 func getDevice(ctx context.Context, config apiserver.Configuration, appId string, devEUI string) (*Device, error) {
 	request, err := http.NewRequestWithBearer(config.ApiBaseUrl+fmt.Sprintf("/1/nwk/app/%s/device/%s", strings.ToUpper(appId), strings.ToUpper(devEUI)), config.ApiToken)
 	if err != nil {
@@ -187,7 +196,7 @@ func getDevice(ctx context.Context, config apiserver.Configuration, appId string
 }
 
 func searchDevice(ctx context.Context, config apiserver.Configuration, devEUI string) (*Device, error) {
-	apps, err := getApps(ctx, config)
+	apps, err := GetApps(ctx, config)
 	if err != nil {
 		return nil, err
 	}
@@ -267,19 +276,21 @@ func deleteDevice(ctx context.Context, config apiserver.Configuration, device De
 	return nil
 }
 
-// UpsertDevice creates or updates a device using the device EUI as primary key.
-func UpsertDevice(ctx context.Context, config apiserver.Configuration, request apiserver.PutDeviceRequest) (*Device, error) {
+// UpsertDevice creates or updates a device using the device EUI as primary key. Returns the device before the update
+// as well, which is nil if the device was created.
+func UpsertDevice(ctx context.Context, config apiserver.Configuration, request apiserver.PutDeviceRequest) (*Device, *Device, error) {
 	device, err := getDevice(ctx, config, request.AppID, request.DevEUI)
 	if err != nil {
-		return nil, fmt.Errorf("error getting device for upserting %s: %w", request.DevEUI, err)
+		return nil, nil, fmt.Errorf("error getting device for upserting %s: %w", request.DevEUI, err)
 	}
 	if device == nil {
 		device, err = postDeviceForCreate(ctx, config, request)
 		if err != nil {
-			return device, fmt.Errorf("error creating device %s: %w", request.DevEUI, err)
+			return device, nil, fmt.Errorf("error creating device %s: %w", request.DevEUI, err)
 		}
-		return device, nil
+		return device, nil, nil
 	} else {
+		previous := *device
 		if len(request.Title) > 0 {
 			device.Title = request.Title
 		}
@@ -288,10 +299,18 @@ func UpsertDevice(ctx context.Context, config apiserver.Configuration, request a
 		}
 		err := postDeviceForUpdate(ctx, config, *device)
 		if err != nil {
-			return device, fmt.Errorf("error updating device %s: %w", request.DevEUI, err)
+			return device, &previous, fmt.Errorf("error updating device %s: %w", request.DevEUI, err)
 		}
-		return device, nil
+		return device, &previous, nil
+	}
+}
+
+// RestoreDevice sets the title and description of the device back to the given previous state.
+func RestoreDevice(ctx context.Context, config apiserver.Configuration, previous Device) error {
+	if err := postDeviceForUpdate(ctx, config, previous); err != nil {
+		return fmt.Errorf("error restoring device %s: %w", previous.DevEUI, err)
 	}
+	return nil
 }
 
 func UpdateDevice(ctx context.Context, config apiserver.Configuration, devEUI string, asset api.Asset) (*Device, error) {
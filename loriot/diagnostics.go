@@ -0,0 +1,159 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package loriot
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"loriot-io/apiserver"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+// defaultTestTimeout is the timeout of a connection test if the configuration defines none.
+const defaultTestTimeout = 30 * time.Second
+
+// TestConnection checks if the Loriot.io API of the configuration is reachable with a valid TLS connection and
+// accepts the token. Lists the applications visible with the token, with the access rights of the token and the
+// device limits. Failures are reported in the result and never returned as error.
+func TestConnection(ctx context.Context, config apiserver.Configuration) apiserver.ConfigurationTestResult {
+	var result apiserver.ConfigurationTestResult
+	fail := func(format string, args ...any) apiserver.ConfigurationTestResult {
+		result.Error = common.Ptr(fmt.Sprintf(format, args...))
+		return result
+	}
+
+	baseUrl, err := url.Parse(config.ApiBaseUrl)
+	if err != nil || (baseUrl.Scheme != "http" && baseUrl.Scheme != "https") || baseUrl.Host == "" {
+		return fail("invalid API base URL '%s'", config.ApiBaseUrl)
+	}
+	if config.ApiToken == "" {
+		return fail("no API token")
+	}
+	timeout := defaultTestTimeout
+	if config.RequestTimeout != nil && *config.RequestTimeout > 0 {
+		timeout = time.Duration(*config.RequestTimeout) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(config.ApiBaseUrl, "/")+"/1/nwk/apps?page=1&perPage=100", nil)
+	if err != nil {
+		return fail("creating request: %v", err)
+	}
+	request.Header.Set("Authorization", "Bearer "+config.ApiToken)
+	start := time.Now()
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		if isTLSError(err) {
+			result.TlsError = common.Ptr(err.Error())
+			return result
+		}
+		return fail("connecting to API: %v", err)
+	}
+	defer response.Body.Close()
+	result.LatencyMs = common.Ptr(time.Since(start).Milliseconds())
+	result.Reachable = true
+	result.StatusCode = common.Ptr(int32(response.StatusCode))
+	if response.TLS != nil {
+		result.TlsVersion = common.Ptr(tls.VersionName(response.TLS.Version))
+		if len(response.TLS.PeerCertificates) > 0 {
+			expiresAt := response.TLS.PeerCertificates[0].NotAfter
+			result.CertificateExpiresAt = &expiresAt
+			if time.Until(expiresAt) < 14*24*time.Hour {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("certificate of the API expires at %s", expiresAt.Format(time.RFC3339)))
+			}
+		}
+	}
+
+	switch {
+	case response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusForbidden:
+		return fail("API token rejected with status %d", response.StatusCode)
+	case response.StatusCode != http.StatusOK:
+		return fail("API responded with status %d", response.StatusCode)
+	}
+	result.Authenticated = true
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fail("reading response: %v", err)
+	}
+	var meta Meta
+	if err := json.Unmarshal(body, &meta); err != nil {
+		return fail("API responded with unexpected content, check the API base URL: %v", err)
+	}
+	apps := meta.Apps
+	if meta.Total > len(apps) {
+		if apps, err = GetApps(ctx, config); err != nil {
+			return fail("reading applications: %v", err)
+		}
+	}
+
+	result.Applications = make([]apiserver.ConfigurationTestApplication, 0, len(apps))
+	for _, app := range apps {
+		result.Applications = append(result.Applications, testApplication(app, config.ApiToken))
+		if app.Suspended {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("application %s is suspended", app.AppHexID))
+		}
+		if app.DeviceLimit > 0 && app.Devices >= app.DeviceLimit {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("application %s reached its limit of %d devices", app.AppHexID, app.DeviceLimit))
+		}
+	}
+	if len(apps) == 0 {
+		result.Warnings = append(result.Warnings, "no applications visible with the API token")
+	}
+	result.Ok = true
+	return result
+}
+
+// testApplication returns the application with the access rights of the token, if the application lists them.
+func testApplication(app App, token string) apiserver.ConfigurationTestApplication {
+	testApp := apiserver.ConfigurationTestApplication{
+		AppID:       strings.ToUpper(app.AppHexID),
+		Name:        app.Name,
+		Devices:     int32(app.Devices),
+		DeviceLimit: int32(app.DeviceLimit),
+		Suspended:   app.Suspended,
+	}
+	for _, rights := range app.AccessRights {
+		if rights.Token != token {
+			continue
+		}
+		testApp.Data = common.Ptr(rights.Data)
+		testApp.AppServer = common.Ptr(rights.AppServer)
+		testApp.DevProvisioning = common.Ptr(rights.DevProvisioning)
+	}
+	return testApp
+}
+
+// isTLSError checks if the connection failed while establishing TLS.
+func isTLSError(err error) bool {
+	var verificationErr *tls.CertificateVerificationError
+	var recordHeaderErr tls.RecordHeaderError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	return errors.As(err, &verificationErr) || errors.As(err, &recordHeaderErr) || errors.As(err, &unknownAuthorityErr) ||
+		errors.As(err, &hostnameErr) || errors.As(err, &invalidErr)
+}
@@ -0,0 +1,80 @@
+package loriot
+
+import (
+	"context"
+	"loriot-io/apiserver"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+func testConnectionHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer s3cr3t" {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	if r.URL.Path != "/1/nwk/apps" {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	_, _ = w.Write([]byte(`{"apps":[
+		{"_id":1,"appHexId":"be7a0001","name":"Sensors","devices":10,"deviceLimit":10,"accessRights":[{"token":"other","data":true},{"token":"s3cr3t","data":true,"appServer":false,"devProvisioning":true}]},
+		{"_id":2,"appHexId":"BE7A0002","name":"Meters","devices":1,"deviceLimit":100}
+	],"total":2,"page":1,"perPage":100}`))
+}
+
+func TestTestConnection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(testConnectionHandler))
+	defer server.Close()
+
+	result := TestConnection(context.Background(), apiserver.Configuration{
+		ApiBaseUrl:     server.URL,
+		ApiToken:       "s3cr3t",
+		RequestTimeout: common.Ptr[int32](5),
+	})
+	if !result.Ok || !result.Reachable || !result.Authenticated || result.LatencyMs == nil || *result.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(result.Applications) != 2 {
+		t.Fatalf("got %d applications, want 2", len(result.Applications))
+	}
+	sensors := result.Applications[0]
+	if sensors.AppID != "BE7A0001" || sensors.Data == nil || !*sensors.Data || *sensors.AppServer || !*sensors.DevProvisioning {
+		t.Errorf("unexpected application: %+v", sensors)
+	}
+	if result.Applications[1].Data != nil {
+		t.Errorf("expected unknown access rights: %+v", result.Applications[1])
+	}
+	if len(result.Warnings) != 1 {
+		t.Errorf("expected warning for device limit, got %v", result.Warnings)
+	}
+}
+
+func TestTestConnectionRejectedToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(testConnectionHandler))
+	defer server.Close()
+
+	result := TestConnection(context.Background(), apiserver.Configuration{ApiBaseUrl: server.URL, ApiToken: "wrong"})
+	if result.Ok || !result.Reachable || result.Authenticated || result.Error == nil {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestTestConnectionUntrustedCertificate(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(testConnectionHandler))
+	defer server.Close()
+
+	result := TestConnection(context.Background(), apiserver.Configuration{ApiBaseUrl: server.URL, ApiToken: "s3cr3t"})
+	if result.Ok || result.Reachable || result.TlsError == nil {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestTestConnectionInvalidUrl(t *testing.T) {
+	result := TestConnection(context.Background(), apiserver.Configuration{ApiBaseUrl: "eu1.loriot.io", ApiToken: "s3cr3t"})
+	if result.Ok || result.Reachable || result.Error == nil {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
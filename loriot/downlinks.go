@@ -0,0 +1,86 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package loriot
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"loriot-io/apiserver"
+	http2 "net/http"
+	"strings"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/http"
+)
+
+// QueuedDownlink is a downlink waiting in the Loriot downlink queue of a device.
+type QueuedDownlink struct {
+	Port      int    `json:"port"`
+	Confirmed bool   `json:"confirmed"`
+	Data      string `json:"data"`
+}
+
+func downlinkQueueUrl(config apiserver.Configuration, appId string, devEUI string) string {
+	return config.ApiBaseUrl + fmt.Sprintf("/1/nwk/app/%s/device/%s/dnq", strings.ToUpper(appId), strings.ToUpper(devEUI))
+}
+
+// GetDownlinkQueue returns the downlinks enqueued for the device and not yet sent by Loriot.
+func GetDownlinkQueue(ctx context.Context, config apiserver.Configuration, appId string, devEUI string) ([]QueuedDownlink, error) {
+	fullUrl := downlinkQueueUrl(config, appId, devEUI)
+	request, err := http.NewRequestWithBearer(fullUrl, config.ApiToken)
+	if err != nil {
+		return nil, fmt.Errorf("error creating get request for %s: %w", fullUrl, err)
+	}
+	queue, statusCode, err := http.ReadWithStatusCode[[]QueuedDownlink](request, time.Duration(*config.RequestTimeout)*time.Second, true)
+	if err != nil || statusCode != http2.StatusOK {
+		return nil, fmt.Errorf("error reading get request for %s: %d %w", fullUrl, statusCode, err)
+	}
+	return queue, nil
+}
+
+// EnqueueDownlink appends the payload to the downlink queue of the device.
+func EnqueueDownlink(ctx context.Context, config apiserver.Configuration, appId string, devEUI string, port int, confirmed bool, payload []byte) error {
+	fullUrl := downlinkQueueUrl(config, appId, devEUI)
+	downlink := QueuedDownlink{
+		Port:      port,
+		Confirmed: confirmed,
+		Data:      hex.EncodeToString(payload),
+	}
+	request, err := http.NewPostRequestWithBearer(fullUrl, downlink, config.ApiToken)
+	if err != nil {
+		return fmt.Errorf("error creating post request for %s: %w", fullUrl, err)
+	}
+	_, statusCode, err := http.ReadWithStatusCode[any](request, time.Duration(*config.RequestTimeout)*time.Second, true)
+	if err != nil || statusCode != http2.StatusOK {
+		return fmt.Errorf("error reading post request for %s: %d %w", fullUrl, statusCode, err)
+	}
+	return nil
+}
+
+// FlushDownlinkQueue removes all downlinks from the downlink queue of the device.
+func FlushDownlinkQueue(ctx context.Context, config apiserver.Configuration, appId string, devEUI string) error {
+	fullUrl := downlinkQueueUrl(config, appId, devEUI)
+	request, err := http.NewDeleteRequestWithBearer(fullUrl, config.ApiToken)
+	if err != nil {
+		return fmt.Errorf("error creating delete request for %s: %w", fullUrl, err)
+	}
+	_, statusCode, err := http.ReadWithStatusCode[any](request, time.Duration(*config.RequestTimeout)*time.Second, true)
+	if err != nil || statusCode != http2.StatusOK {
+		return fmt.Errorf("error reading delete request for %s: %d %w", fullUrl, statusCode, err)
+	}
+	return nil
+}
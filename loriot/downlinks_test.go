@@ -0,0 +1,62 @@
+package loriot
+
+import (
+	"context"
+	"encoding/json"
+	"loriot-io/apiserver"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+func TestDownlinkQueue(t *testing.T) {
+	var queue []QueuedDownlink
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/1/nwk/app/BE7A0001/device/BE7A0000000014E2/dnq" || r.Header.Get("Authorization") != "Bearer s3cr3t" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(queue)
+		case http.MethodPost:
+			var downlink QueuedDownlink
+			if err := json.NewDecoder(r.Body).Decode(&downlink); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			queue = append(queue, downlink)
+		case http.MethodDelete:
+			queue = nil
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	config := apiserver.Configuration{
+		ApiBaseUrl:     server.URL,
+		ApiToken:       "s3cr3t",
+		RequestTimeout: common.Ptr[int32](5),
+	}
+	if err := EnqueueDownlink(ctx, config, "be7a0001", "be7a0000000014e2", 3, true, []byte{0x0a, 0x0b}); err != nil {
+		t.Fatalf("EnqueueDownlink() error = %v", err)
+	}
+	got, err := GetDownlinkQueue(ctx, config, "be7a0001", "be7a0000000014e2")
+	if err != nil {
+		t.Fatalf("GetDownlinkQueue() error = %v", err)
+	}
+	if len(got) != 1 || got[0] != (QueuedDownlink{Port: 3, Confirmed: true, Data: "0a0b"}) {
+		t.Errorf("GetDownlinkQueue() = %+v", got)
+	}
+	if err := FlushDownlinkQueue(ctx, config, "be7a0001", "be7a0000000014e2"); err != nil {
+		t.Fatalf("FlushDownlinkQueue() error = %v", err)
+	}
+	if got, err := GetDownlinkQueue(ctx, config, "be7a0001", "be7a0000000014e2"); err != nil || len(got) != 0 {
+		t.Errorf("GetDownlinkQueue() after flush = %+v, %v", got, err)
+	}
+	if _, err := GetDownlinkQueue(ctx, config, "be7a0001", "0000000000000000"); err == nil {
+		t.Errorf("GetDownlinkQueue() of unknown device expected error")
+	}
+}
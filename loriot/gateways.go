@@ -0,0 +1,70 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package loriot
+
+import (
+	"context"
+	"loriot-io/apiserver"
+	"time"
+)
+
+type Network struct {
+	Id             string    `json:"_id"`
+	Name           string    `json:"name"`
+	OrganizationID int       `json:"organizationId"`
+	Visibility     string    `json:"visibility"`
+	Created        time.Time `json:"created"`
+	Gateways       int       `json:"gateways"`
+}
+
+type Gateway struct {
+	NetworkID string          `json:"-"`
+	Id        string          `json:"_id"`
+	Title     string          `json:"title"`
+	EUI       string          `json:"EUI"`
+	MAC       string          `json:"MAC"`
+	Base      string          `json:"base"`
+	Model     string          `json:"model"`
+	Version   string          `json:"version"`
+	Connected bool            `json:"connected"`
+	LastSeen  time.Time       `json:"lastSeen"`
+	Location  GatewayLocation `json:"location"`
+}
+
+type GatewayLocation struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+	Alt float64 `json:"alt"`
+}
+
+// HasLocation returns true if the location of the gateway is known.
+func (g Gateway) HasLocation() bool {
+	return g.Location.Lat != 0 || g.Location.Lon != 0
+}
+
+// GetNetworks returns all networks accessible with the token of the configuration.
+func GetNetworks(ctx context.Context, config apiserver.Configuration) ([]Network, error) {
+	return getFromApi[Network](ctx, config, func(meta Meta) []Network { return meta.Networks }, "/1/nwk/networks")
+}
+
+// GetGateways returns all gateways of the network including their status.
+func GetGateways(ctx context.Context, config apiserver.Configuration, networkId string) ([]Gateway, error) {
+	gateways, err := getFromApi[Gateway](ctx, config, func(meta Meta) []Gateway { return meta.Gateways }, "/1/nwk/network/%s/gateways", networkId)
+	for idx := range gateways {
+		gateways[idx].NetworkID = networkId
+	}
+	return gateways, err
+}
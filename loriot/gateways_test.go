@@ -0,0 +1,47 @@
+package loriot
+
+import (
+	"context"
+	"loriot-io/apiserver"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+func TestGateways(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cr3t" {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/1/nwk/networks":
+			_, _ = w.Write([]byte(`{"networks":[{"_id":"N1","name":"Office"}],"total":1,"page":1,"perPage":100}`))
+		case "/1/nwk/network/N1/gateways":
+			_, _ = w.Write([]byte(`{"gateways":[{"_id":"B827EBFFFE000001","title":"Roof","EUI":"B827EBFFFE000001","model":"iBTS","version":"5.1","connected":true,"lastSeen":"2024-03-01T12:00:00Z","location":{"lat":47.37,"lon":8.54,"alt":420}}],"total":1,"page":1,"perPage":100}`))
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	config := apiserver.Configuration{
+		ApiBaseUrl:     server.URL,
+		ApiToken:       "s3cr3t",
+		RequestTimeout: common.Ptr[int32](5),
+	}
+	networks, err := GetNetworks(ctx, config)
+	if err != nil || len(networks) != 1 || networks[0].Id != "N1" {
+		t.Fatalf("GetNetworks() = %+v, %v", networks, err)
+	}
+	gateways, err := GetGateways(ctx, config, "N1")
+	if err != nil || len(gateways) != 1 {
+		t.Fatalf("GetGateways() = %+v, %v", gateways, err)
+	}
+	if gw := gateways[0]; gw.NetworkID != "N1" || !gw.Connected || gw.Model != "iBTS" || !gw.HasLocation() || gw.LastSeen.IsZero() {
+		t.Errorf("GetGateways() = %+v, unexpected gateway", gw)
+	}
+}
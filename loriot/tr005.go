@@ -0,0 +1,123 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package loriot
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// tr005Version is the version of the LoRa Alliance TR005 QR code format supported.
+const tr005Version = "D0"
+
+var (
+	tr005ProfileRegex  = regexp.MustCompile(`^[A-Fa-f0-9]{8}$`)
+	tr005ChecksumRegex = regexp.MustCompile(`^[A-Fa-f0-9]{4}$`)
+)
+
+// QRCode is the content of a device QR code in the LoRa Alliance TR005 format
+// `LW:D0:<JoinEUI>:<DevEUI>:<ProfileID>[:<Option>...]`.
+type QRCode struct {
+	JoinEUI   string
+	DevEUI    string
+	ProfileID string
+
+	// Options identified by their prefix: O owner token, S serial number, P proprietary and C checksum.
+	OwnerToken   string
+	SerialNumber string
+	Proprietary  string
+	Checksum     string
+}
+
+// ParseQRCode parses and validates a TR005 QR code. EUIs and the profile ID are returned in upper case. The checksum
+// option must be the last option and must match the CRC-16 of the code preceding it.
+func ParseQRCode(code string) (*QRCode, error) {
+	code = strings.TrimSpace(code)
+	fields := strings.Split(code, ":")
+	if len(fields) < 5 {
+		return nil, fmt.Errorf("QR code has %d instead of at least 5 fields", len(fields))
+	}
+	if !strings.EqualFold(fields[0], "LW") {
+		return nil, fmt.Errorf("QR code schema %s is not LW", fields[0])
+	}
+	if !strings.EqualFold(fields[1], tr005Version) {
+		return nil, fmt.Errorf("QR code version %s is not supported", fields[1])
+	}
+	qr := QRCode{
+		JoinEUI:   strings.ToUpper(fields[2]),
+		DevEUI:    strings.ToUpper(fields[3]),
+		ProfileID: strings.ToUpper(fields[4]),
+	}
+	if !eui64Regex.MatchString(qr.JoinEUI) {
+		return nil, fmt.Errorf("QR code contains invalid JoinEUI %s", fields[2])
+	}
+	if !eui64Regex.MatchString(qr.DevEUI) {
+		return nil, fmt.Errorf("QR code contains invalid DevEUI %s", fields[3])
+	}
+	if !tr005ProfileRegex.MatchString(qr.ProfileID) {
+		return nil, fmt.Errorf("QR code contains invalid profile ID %s", fields[4])
+	}
+	for idx, option := range fields[5:] {
+		if option == "" {
+			return nil, fmt.Errorf("QR code contains an empty option")
+		}
+		value := option[1:]
+		switch option[0] {
+		case 'O':
+			qr.OwnerToken = value
+		case 'S':
+			qr.SerialNumber = value
+		case 'P':
+			qr.Proprietary = value
+		case 'C':
+			if !tr005ChecksumRegex.MatchString(value) {
+				return nil, fmt.Errorf("QR code contains invalid checksum %s", value)
+			}
+			if idx != len(fields)-6 {
+				return nil, fmt.Errorf("QR code contains options after the checksum")
+			}
+			qr.Checksum = strings.ToUpper(value)
+			if expected := fmt.Sprintf("%04X", tr005CRC16(code[:len(code)-len(option)])); qr.Checksum != expected {
+				return nil, fmt.Errorf("QR code checksum %s does not match %s", qr.Checksum, expected)
+			}
+		default:
+			return nil, fmt.Errorf("QR code contains unknown option %c", option[0])
+		}
+	}
+	return &qr, nil
+}
+
+// tr005CRC16 returns the CRC-16/ISO-IEC-14443-3-A of the data, the checksum defined by TR005.
+func tr005CRC16(data string) uint16 {
+	crc := uint16(0x6363)
+	for i := 0; i < len(data); i++ {
+		crc ^= uint16(data[i])
+		for bit := 0; bit < 8; bit++ {
+			if crc&1 != 0 {
+				crc = crc>>1 ^ 0x8408
+			} else {
+				crc >>= 1
+			}
+		}
+	}
+	return crc
+}
+
+// VendorID returns the LoRa Alliance vendor ID of the device, the first half of the profile ID.
+func (qr QRCode) VendorID() string {
+	return qr.ProfileID[:4]
+}
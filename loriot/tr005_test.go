@@ -0,0 +1,45 @@
+package loriot
+
+import "testing"
+
+func TestParseQRCode(t *testing.T) {
+	qr, err := ParseQRCode("LW:D0:1122334455667788:70b3d57ed0000001:AABB1122:O0123456789:SSN-42:C3D43")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if qr.JoinEUI != "1122334455667788" || qr.DevEUI != "70B3D57ED0000001" || qr.ProfileID != "AABB1122" {
+		t.Errorf("unexpected identifiers: %+v", qr)
+	}
+	if qr.OwnerToken != "0123456789" || qr.SerialNumber != "SN-42" || qr.Checksum != "3D43" {
+		t.Errorf("unexpected options: %+v", qr)
+	}
+	if qr.VendorID() != "AABB" {
+		t.Errorf("VendorID() = %s, want AABB", qr.VendorID())
+	}
+}
+
+func TestTR005CRC16(t *testing.T) {
+	if crc := tr005CRC16("123456789"); crc != 0xBF05 {
+		t.Errorf("tr005CRC16() = %04X, want BF05", crc)
+	}
+}
+
+func TestParseQRCodeInvalid(t *testing.T) {
+	tests := map[string]string{
+		"too short":         "LW:D0:1122334455667788:70B3D57ED0000001",
+		"wrong schema":      "XX:D0:1122334455667788:70B3D57ED0000001:AABB1122",
+		"wrong version":     "LW:D1:1122334455667788:70B3D57ED0000001:AABB1122",
+		"invalid JoinEUI":   "LW:D0:11223344:70B3D57ED0000001:AABB1122",
+		"invalid DevEUI":    "LW:D0:1122334455667788:70B3D57ED00000XY:AABB1122",
+		"invalid profile":   "LW:D0:1122334455667788:70B3D57ED0000001:AABB",
+		"unknown option":    "LW:D0:1122334455667788:70B3D57ED0000001:AABB1122:X1",
+		"invalid checksum":  "LW:D0:1122334455667788:70B3D57ED0000001:AABB1122:C12",
+		"wrong checksum":    "LW:D0:1122334455667788:70b3d57ed0000001:AABB1122:O0123456789:SSN-42:C3D44",
+		"checksum not last": "LW:D0:1122334455667788:70b3d57ed0000001:AABB1122:C3D43:SSN-42",
+	}
+	for name, code := range tests {
+		if _, err := ParseQRCode(code); err == nil {
+			t.Errorf("%s: expected error for %s", name, code)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package loriot
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"loriot-io/apiserver"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	CmdUplink        = "rx"
+	CmdUplinkGateway = "gw"
+	CmdDownlink      = "tx"
+	CmdDownlinkSent  = "txd"
+)
+
+// Message is a message received from the Loriot application WebSocket. Uplinks are sent with the
+// command "rx", or "gw" if the application output includes the receiving gateways. Downlinks are
+// answered with "tx" containing either success or error, and reported with "txd" once transmitted.
+type Message struct {
+	Cmd     string           `json:"cmd"`
+	SeqNo   int              `json:"seqno"`
+	EUI     string           `json:"EUI"`
+	Ts      int64            `json:"ts"`
+	FCnt    int              `json:"fcnt"`
+	Port    int              `json:"port"`
+	Freq    int              `json:"freq"`
+	Rssi    int              `json:"rssi"`
+	Snr     float64          `json:"snr"`
+	Toa     int              `json:"toa"`
+	Dr      string           `json:"dr"`
+	Ack     bool             `json:"ack"`
+	Bat     int              `json:"bat"`
+	Offline bool             `json:"offline"`
+	Data    string           `json:"data"`
+	Gws     []MessageGateway `json:"gws"`
+	SeqDn   int              `json:"seqdn"`
+	Success string           `json:"success"`
+	Error   string           `json:"error"`
+}
+
+// MessageGateway describes a gateway which received an uplink.
+type MessageGateway struct {
+	Rssi  int     `json:"rssi"`
+	Snr   float64 `json:"snr"`
+	Ts    int64   `json:"ts"`
+	Time  string  `json:"time"`
+	GwEUI string  `json:"gweui"`
+	Ant   int     `json:"ant"`
+	Lat   float64 `json:"lat"`
+	Lon   float64 `json:"lon"`
+}
+
+// IsUplink returns true if the message carries an uplink of a device.
+func (m Message) IsUplink() bool {
+	return m.Cmd == CmdUplink || m.Cmd == CmdUplinkGateway
+}
+
+// Timestamp returns the time the uplink was received by the network server.
+func (m Message) Timestamp() time.Time {
+	return time.UnixMilli(m.Ts)
+}
+
+// Payload returns the decoded application payload of the uplink.
+func (m Message) Payload() ([]byte, error) {
+	return hex.DecodeString(m.Data)
+}
+
+// BestGateway returns the gateway which received the uplink with the strongest signal or nil if
+// the message contains no gateway information.
+func (m Message) BestGateway() *MessageGateway {
+	var best *MessageGateway
+	for idx := range m.Gws {
+		if best == nil || m.Gws[idx].Rssi > best.Rssi {
+			best = &m.Gws[idx]
+		}
+	}
+	return best
+}
+
+// DownlinkCommand requests Loriot to enqueue a downlink for a device.
+type DownlinkCommand struct {
+	Cmd       string `json:"cmd"`
+	EUI       string `json:"EUI"`
+	Port      int    `json:"port"`
+	Confirmed bool   `json:"confirmed"`
+	Data      string `json:"data"`
+}
+
+// NewDownlinkCommand creates the tx command for the payload.
+func NewDownlinkCommand(devEUI string, port int, confirmed bool, payload []byte) DownlinkCommand {
+	return DownlinkCommand{
+		Cmd:       CmdDownlink,
+		EUI:       devEUI,
+		Port:      port,
+		Confirmed: confirmed,
+		Data:      hex.EncodeToString(payload),
+	}
+}
+
+// AppConnection is a connection to the Loriot application WebSocket of a configuration.
+type AppConnection struct {
+	conn       *websocket.Conn
+	writeMutex sync.Mutex
+}
+
+// DialApp opens the application WebSocket (/app?token=...) for the given configuration.
+func DialApp(ctx context.Context, config apiserver.Configuration) (*AppConnection, error) {
+	dialer := websocket.Dialer{
+		HandshakeTimeout: time.Duration(*config.RequestTimeout) * time.Second,
+	}
+	conn, _, err := dialer.DialContext(ctx, appWebSocketUrl(config), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error dialing application websocket for %s: %w", config.ApiBaseUrl, err)
+	}
+	return &AppConnection{conn: conn}, nil
+}
+
+func appWebSocketUrl(config apiserver.Configuration) string {
+	baseUrl := strings.TrimSuffix(config.ApiBaseUrl, "/")
+	baseUrl = strings.Replace(baseUrl, "https://", "wss://", 1)
+	baseUrl = strings.Replace(baseUrl, "http://", "ws://", 1)
+	return baseUrl + "/app?token=" + url.QueryEscape(config.ApiToken)
+}
+
+// Read blocks until the next message is received. Malformed messages are logged and skipped. Returns an error if the
+// connection is broken or closed.
+func (c *AppConnection) Read() (*Message, error) {
+	for {
+		messageType, data, err := c.conn.ReadMessage()
+		if err != nil {
+			return nil, err
+		}
+		if messageType != websocket.TextMessage {
+			continue
+		}
+		var message Message
+		if err := json.Unmarshal(data, &message); err != nil {
+			log.Warn("loriot", "Skipping malformed application websocket message %q: %v", data, err)
+			continue
+		}
+		return &message, nil
+	}
+}
+
+// Send writes the command to the connection. Safe for concurrent use.
+func (c *AppConnection) Send(command DownlinkCommand) error {
+	c.writeMutex.Lock()
+	defer c.writeMutex.Unlock()
+	if err := c.conn.WriteJSON(command); err != nil {
+		return fmt.Errorf("error sending %s to application websocket: %w", command.Cmd, err)
+	}
+	return nil
+}
+
+// Close closes the connection. Pending reads return with an error.
+func (c *AppConnection) Close() error {
+	return c.conn.Close()
+}
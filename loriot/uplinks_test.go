@@ -0,0 +1,138 @@
+package loriot
+
+import (
+	"context"
+	"loriot-io/apiserver"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/gorilla/websocket"
+)
+
+// newAppWebSocketStandIn starts a local server behaving like the Loriot application WebSocket, which
+// sends the given messages to each client connecting with the expected token.
+func newAppWebSocketStandIn(t *testing.T, token string, messages ...string) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/app" || r.URL.Query().Get("token") != token {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrading connection: %v", err)
+			return
+		}
+		defer conn.Close()
+		for _, message := range messages {
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(message)); err != nil {
+				t.Errorf("writing message: %v", err)
+				return
+			}
+		}
+		_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	}))
+}
+
+func TestAppConnectionRead(t *testing.T) {
+	server := newAppWebSocketStandIn(t, "s3cr3t",
+		`{"cmd":"rx","seqno":5,"EUI":"BE7A0000000014E2","ts":1470850675433,"fcnt":1,"port":2,"freq":867500000,"rssi":-21,"snr":10,"toa":206,"dr":"SF9 BW125 4/5","ack":false,"bat":255,"data":"0100"}`,
+		`{"cmd":"gw","EUI":"BE7A0000000014E2","ts":1470850675434,"fcnt":2,"port":2,"data":"ff","gws":[{"rssi":-90,"snr":1.5,"time":"2016-08-10T17:37:55.433Z","gweui":"0000000000000001"},{"rssi":-60,"snr":7,"time":"2016-08-10T17:37:55.434Z","gweui":"0000000000000002"}]}`,
+	)
+	defer server.Close()
+
+	conn, err := DialApp(context.Background(), apiserver.Configuration{
+		ApiBaseUrl:     server.URL,
+		ApiToken:       "s3cr3t",
+		RequestTimeout: common.Ptr[int32](5),
+	})
+	if err != nil {
+		t.Fatalf("DialApp() error = %v", err)
+	}
+	defer conn.Close()
+
+	rx, err := conn.Read()
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if !rx.IsUplink() || rx.EUI != "BE7A0000000014E2" || rx.Port != 2 || rx.FCnt != 1 || rx.Rssi != -21 || rx.Snr != 10 {
+		t.Errorf("Read() = %+v, unexpected uplink", rx)
+	}
+	if payload, err := rx.Payload(); err != nil || len(payload) != 2 || payload[0] != 0x01 {
+		t.Errorf("Payload() = %v, %v", payload, err)
+	}
+	if rx.Timestamp().UnixMilli() != 1470850675433 {
+		t.Errorf("Timestamp() = %v", rx.Timestamp())
+	}
+
+	gw, err := conn.Read()
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if !gw.IsUplink() || gw.BestGateway() == nil || gw.BestGateway().GwEUI != "0000000000000002" {
+		t.Errorf("Read() = %+v, unexpected gateway uplink", gw)
+	}
+
+	if _, err := conn.Read(); err == nil {
+		t.Errorf("Read() after close expected error")
+	}
+}
+
+func TestDialAppWithWrongToken(t *testing.T) {
+	server := newAppWebSocketStandIn(t, "s3cr3t")
+	defer server.Close()
+
+	_, err := DialApp(context.Background(), apiserver.Configuration{
+		ApiBaseUrl:     server.URL,
+		ApiToken:       "wrong",
+		RequestTimeout: common.Ptr[int32](5),
+	})
+	if err == nil {
+		t.Errorf("DialApp() with wrong token expected error")
+	}
+}
+
+func TestAppConnectionSend(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrading connection: %v", err)
+			return
+		}
+		defer conn.Close()
+		var command DownlinkCommand
+		if err := conn.ReadJSON(&command); err != nil {
+			t.Errorf("reading command: %v", err)
+			return
+		}
+		if command.Cmd != CmdDownlink || command.EUI != "BE7A0000000014E2" || command.Port != 3 || !command.Confirmed || command.Data != "0a0b" {
+			t.Errorf("unexpected command %+v", command)
+		}
+		_ = conn.WriteJSON(map[string]any{"cmd": "tx", "EUI": command.EUI, "success": "Data enqueued"})
+	}))
+	defer server.Close()
+
+	conn, err := DialApp(context.Background(), apiserver.Configuration{
+		ApiBaseUrl:     server.URL,
+		ApiToken:       "s3cr3t",
+		RequestTimeout: common.Ptr[int32](5),
+	})
+	if err != nil {
+		t.Fatalf("DialApp() error = %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.Send(NewDownlinkCommand("BE7A0000000014E2", 3, true, []byte{0x0a, 0x0b})); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	answer, err := conn.Read()
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if answer.Cmd != CmdDownlink || answer.Success == "" || answer.IsUplink() {
+		t.Errorf("Read() = %+v, unexpected answer", answer)
+	}
+}
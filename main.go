@@ -17,6 +17,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"github.com/eliona-smart-building-assistant/go-eliona/app"
 	"github.com/eliona-smart-building-assistant/go-eliona/asset"
 	"github.com/eliona-smart-building-assistant/go-eliona/dashboard"
@@ -25,12 +26,16 @@ import (
 	"github.com/eliona-smart-building-assistant/go-utils/log"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"loriot-io/apiservices"
+	appconf "loriot-io/app"
 	broker "loriot-io/broker"
 )
 
 // The main function starts the app by starting all services necessary for this app and waits
 // until all services are finished.
 func main() {
+	rotateSecretKey := flag.Bool("rotate-secret-key", false, "re-encrypt all API tokens and device keys with SECRET_KEY and exit")
+	flag.Parse()
+
 	log.Info("main", "Starting the app.")
 
 	// Set default database to use boil.*G functions.
@@ -50,10 +55,31 @@ func main() {
 	// Initialize the app
 	initialization()
 
+	// Re-encrypt the secrets with a new key, see README.md.
+	if *rotateSecretKey {
+		configs, vaults, deviceKeys, importRows, err := appconf.RotateSecretKey(context.Background())
+		if err != nil {
+			log.Fatal("main", "Error rotating secret key: %v", err)
+		}
+		log.Info("main", "Re-encrypted the API tokens of %d configs, the keys of %d devices in the vault, the loaded keys of %d devices and the keys of %d pending import rows.", configs, vaults, deviceKeys, importRows)
+		return
+	}
+	if err := appconf.EncryptConfigTokens(context.Background()); err != nil {
+		log.Error("main", "Error encrypting API tokens: %v", err)
+	}
+	if err := appconf.EncryptDeviceKeys(context.Background()); err != nil {
+		log.Error("main", "Error encrypting loaded device keys: %v", err)
+	}
+
 	// Starting the service to collect the data for this app.
 	common.WaitForWithOs(
 		apiservices.ListenApi,
 		broker.ListenForAssetChanges,
+		broker.ListenForUplinks,
+		broker.ListenForOutputChanges,
+		broker.SyncDevices,
+		broker.ProcessOutbox,
+		broker.ResumeImportJobs,
 	)
 
 	log.Info("main", "Terminate the app.")
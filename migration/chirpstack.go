@@ -0,0 +1,91 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package migration
+
+import (
+	"fmt"
+)
+
+// chirpStackDevice is a device as returned by the ChirpStack v4 API, combining the responses of
+// `GET /api/devices/{dev_eui}`, `GET /api/devices/{dev_eui}/keys`, `GET /api/devices/{dev_eui}/activation` and
+// `GET /api/device-profiles/{id}` of the device.
+type chirpStackDevice struct {
+	Device struct {
+		DevEUI      string `json:"devEui"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		JoinEUI     string `json:"joinEui"`
+	} `json:"device"`
+	DeviceKeys struct {
+		NwkKey string `json:"nwkKey"`
+		AppKey string `json:"appKey"`
+	} `json:"deviceKeys"`
+	DeviceActivation struct {
+		DevAddr     string `json:"devAddr"`
+		AppSKey     string `json:"appSKey"`
+		NwkSEncKey  string `json:"nwkSEncKey"`
+		SNwkSIntKey string `json:"sNwkSIntKey"`
+		FNwkSIntKey string `json:"fNwkSIntKey"`
+		FCntUp      uint32 `json:"fCntUp"`
+		NFCntDown   uint32 `json:"nFCntDown"`
+		AFCntDown   uint32 `json:"aFCntDown"`
+	} `json:"deviceActivation"`
+	DeviceProfile struct {
+		MacVersion     string `json:"macVersion"`
+		SupportsOtaa   bool   `json:"supportsOtaa"`
+		SupportsClassB bool   `json:"supportsClassB"`
+		SupportsClassC bool   `json:"supportsClassC"`
+	} `json:"deviceProfile"`
+}
+
+func parseChirpStack(data []byte) ([]Device, error) {
+	csDevices, err := decodeObjects[chirpStackDevice](data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing ChirpStack export: %v", err)
+	}
+	devices := make([]Device, 0, len(csDevices))
+	for _, csDevice := range csDevices {
+		device := Device{
+			DevEUI:      csDevice.Device.DevEUI,
+			Name:        csDevice.Device.Name,
+			Description: csDevice.Device.Description,
+			MacVersion:  macVersion(csDevice.DeviceProfile.MacVersion),
+			Otaa:        csDevice.DeviceProfile.SupportsOtaa,
+			Class:       deviceClass(csDevice.DeviceProfile.SupportsClassB, csDevice.DeviceProfile.SupportsClassC),
+			JoinEUI:     csDevice.Device.JoinEUI,
+			NwkKey:      csDevice.DeviceKeys.NwkKey,
+			AppKey:      csDevice.DeviceKeys.AppKey,
+			DevAddr:     csDevice.DeviceActivation.DevAddr,
+			AppSKey:     csDevice.DeviceActivation.AppSKey,
+			FNwkSIntKey: csDevice.DeviceActivation.FNwkSIntKey,
+			SNwkSIntKey: csDevice.DeviceActivation.SNwkSIntKey,
+			NwkSEncKey:  csDevice.DeviceActivation.NwkSEncKey,
+			FCntUp:      csDevice.DeviceActivation.FCntUp,
+			NFCntDown:   csDevice.DeviceActivation.NFCntDown,
+			AFCntDown:   csDevice.DeviceActivation.AFCntDown,
+		}
+		if device.MacVersion == "" {
+			device.Warnings = append(device.Warnings, "device profile missing, LoRaWAN 1.0 and OTAA assumed")
+			device.Otaa = true
+		}
+		if device.Otaa && !device.IsLoRaWAN11() && device.NwkKey != "" {
+			// ChirpStack stores the AppKey of LoRaWAN 1.0.x devices as network key
+			device.AppKey = device.NwkKey
+		}
+		devices = append(devices, device)
+	}
+	return devices, nil
+}
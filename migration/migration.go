@@ -0,0 +1,166 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package migration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"loriot-io/apiserver"
+	"strconv"
+	"strings"
+)
+
+// Network servers whose device exports can be migrated.
+const (
+	SourceTTN        = "ttn"
+	SourceChirpStack = "chirpstack"
+)
+
+// Device is a device exported from another network server with the values Loriot.io creates devices with.
+type Device struct {
+	DevEUI      string
+	Name        string
+	Description string
+
+	// MacVersion is the LoRaWAN version of the device, e.g. 1.0.3 or 1.1.0.
+	MacVersion string
+	Otaa       bool
+	Class      string
+
+	// Root keys of OTAA devices. For LoRaWAN 1.0.x only the AppKey is used.
+	JoinEUI string
+	AppKey  string
+	NwkKey  string
+
+	// Session of ABP devices. For LoRaWAN 1.0.x the FNwkSIntKey is the NwkSKey.
+	DevAddr     string
+	AppSKey     string
+	FNwkSIntKey string
+	SNwkSIntKey string
+	NwkSEncKey  string
+	FCntUp      uint32
+	NFCntDown   uint32
+	AFCntDown   uint32
+
+	// Warnings about values of the export that can't be migrated.
+	Warnings []string
+}
+
+// Target defines where the migrated devices are created.
+type Target struct {
+	ConfigID      int64
+	AppID         string
+	AssetTypeName string
+}
+
+// Parse reads the devices of the export of the network server.
+func Parse(source string, data []byte) ([]Device, error) {
+	switch source {
+	case SourceTTN:
+		return parseTTN(data)
+	case SourceChirpStack:
+		return parseChirpStack(data)
+	default:
+		return nil, fmt.Errorf("unknown migration source: %s", source)
+	}
+}
+
+// IsLoRaWAN11 checks if the device uses LoRaWAN 1.1, which has separate network keys.
+func (d Device) IsLoRaWAN11() bool {
+	return strings.HasPrefix(d.MacVersion, "1.1")
+}
+
+// PutDeviceRequest maps the device onto the request creating it in the target Loriot.io application.
+func (d Device) PutDeviceRequest(target Target) apiserver.PutDeviceRequest {
+	configID := int32(target.ConfigID)
+	request := apiserver.PutDeviceRequest{
+		DevEUI:        strings.ToUpper(d.DevEUI),
+		AppID:         target.AppID,
+		AssetTypeName: target.AssetTypeName,
+		ConfigID:      &configID,
+		Title:         d.Name,
+		Description:   d.Description,
+		DevClass:      d.Class,
+	}
+	if d.Otaa {
+		request.AppKey = strings.ToUpper(d.AppKey)
+		if d.IsLoRaWAN11() {
+			request.JoinEUI = strings.ToUpper(d.JoinEUI)
+			request.NwkKey = strings.ToUpper(d.NwkKey)
+		} else {
+			request.AppEUI = strings.ToUpper(d.JoinEUI)
+		}
+		return request
+	}
+	request.DevAddr = strings.ToUpper(d.DevAddr)
+	request.AppSKey = strings.ToUpper(d.AppSKey)
+	request.SeqNo = strconv.FormatUint(uint64(d.FCntUp), 10)
+	if d.IsLoRaWAN11() {
+		request.FNwkSIntKey = strings.ToUpper(d.FNwkSIntKey)
+		request.SNwkSIntKey = strings.ToUpper(d.SNwkSIntKey)
+		request.NwkSEncKey = strings.ToUpper(d.NwkSEncKey)
+		request.NfCntDwn = strconv.FormatUint(uint64(d.NFCntDown), 10)
+		request.AfCntDwn = strconv.FormatUint(uint64(d.AFCntDown), 10)
+	} else {
+		request.NwkSKey = strings.ToUpper(d.FNwkSIntKey)
+		request.SeqDN = strconv.FormatUint(uint64(d.NFCntDown), 10)
+	}
+	return request
+}
+
+// macVersion returns the version of a MAC version enum like MAC_V1_0_3 or LORAWAN_1_1_0, e.g. 1.0.3 or 1.1.0.
+func macVersion(enum string) string {
+	idx := strings.IndexFunc(enum, func(r rune) bool { return r >= '0' && r <= '9' })
+	if idx < 0 {
+		return ""
+	}
+	return strings.ReplaceAll(enum[idx:], "_", ".")
+}
+
+// deviceClass returns the LoRaWAN class of the device, A if it supports neither B nor C.
+func deviceClass(classB bool, classC bool) string {
+	switch {
+	case classC:
+		return "C"
+	case classB:
+		return "B"
+	default:
+		return "A"
+	}
+}
+
+// decodeObjects reads a JSON array of objects, a single object or a stream of objects like JSON lines.
+func decodeObjects[T any](data []byte) ([]T, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("[")) {
+		var objects []T
+		if err := json.Unmarshal(data, &objects); err != nil {
+			return nil, err
+		}
+		return objects, nil
+	}
+	var objects []T
+	d := json.NewDecoder(bytes.NewReader(data))
+	for d.More() {
+		var object T
+		if err := d.Decode(&object); err != nil {
+			return nil, err
+		}
+		objects = append(objects, object)
+	}
+	return objects, nil
+}
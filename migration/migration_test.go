@@ -0,0 +1,87 @@
+package migration
+
+import (
+	"testing"
+)
+
+const key1 = "00112233445566778899AABBCCDDEEFF"
+const key2 = "FFEEDDCCBBAA99887766554433221100"
+
+func TestParseTTN(t *testing.T) {
+	export := `{"ids": {"device_id": "sensor-1", "dev_eui": "70b3d57ed0000001", "join_eui": "70b3d57ed0000000"},
+		"lorawan_version": "MAC_V1_0_3", "supports_join": true, "supports_class_c": true,
+		"root_keys": {"app_key": {"key": "` + key1 + `"}}}
+	{"ids": {"device_id": "sensor-2", "dev_eui": "70b3d57ed0000002"}, "name": "Sensor 2",
+		"lorawan_version": "MAC_V1_1", "supports_join": false,
+		"session": {"dev_addr": "26011bda", "keys": {"app_s_key": {"key": "` + key1 + `"}, "f_nwk_s_int_key": {"key": "` + key2 + `"},
+		"s_nwk_s_int_key": {"encrypted_key": "abc", "kek_label": "ns"}}, "last_f_cnt_up": 12, "last_n_f_cnt_down": 3, "last_a_f_cnt_down": 2}}`
+	devices, err := Parse(SourceTTN, []byte(export))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 2 {
+		t.Fatalf("got %d devices, want 2", len(devices))
+	}
+
+	otaa := devices[0].PutDeviceRequest(Target{ConfigID: 1, AppID: "BE7A0001", AssetTypeName: "loriot_io_cayenne_lpp"})
+	if otaa.DevEUI != "70B3D57ED0000001" || otaa.Title != "sensor-1" || otaa.AppEUI != "70B3D57ED0000000" || otaa.AppKey != key1 || otaa.DevClass != "C" {
+		t.Errorf("unexpected OTAA request: %+v", otaa)
+	}
+	if otaa.JoinEUI != "" || otaa.NwkKey != "" {
+		t.Errorf("LoRaWAN 1.0 request with 1.1 keys: %+v", otaa)
+	}
+
+	abp := devices[1].PutDeviceRequest(Target{ConfigID: 1, AppID: "BE7A0001", AssetTypeName: "loriot_io_cayenne_lpp"})
+	if abp.DevAddr != "26011BDA" || abp.AppSKey != key1 || abp.FNwkSIntKey != key2 || abp.SeqNo != "12" || abp.NfCntDwn != "3" || abp.AfCntDwn != "2" {
+		t.Errorf("unexpected ABP request: %+v", abp)
+	}
+	if len(devices[1].Warnings) != 1 {
+		t.Errorf("warnings = %v, want one for the encrypted key", devices[1].Warnings)
+	}
+}
+
+func TestParseChirpStack(t *testing.T) {
+	export := `[{"device": {"devEui": "0123456789abcdef", "name": "Room 1", "joinEui": "0000000000000000"},
+		"deviceKeys": {"nwkKey": "` + key1 + `", "appKey": "00000000000000000000000000000000"},
+		"deviceProfile": {"macVersion": "LORAWAN_1_0_3", "supportsOtaa": true}},
+		{"device": {"devEui": "0123456789abcdf0", "name": "Room 2"},
+		"deviceActivation": {"devAddr": "26011bdb", "appSKey": "` + key1 + `", "fNwkSIntKey": "` + key2 + `", "fCntUp": 7, "nFCntDown": 4},
+		"deviceProfile": {"macVersion": "LORAWAN_1_0_2", "supportsOtaa": false, "supportsClassB": true}}]`
+	devices, err := Parse(SourceChirpStack, []byte(export))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 2 {
+		t.Fatalf("got %d devices, want 2", len(devices))
+	}
+
+	otaa := devices[0].PutDeviceRequest(Target{ConfigID: 1, AppID: "BE7A0001"})
+	if otaa.AppKey != key1 || otaa.AppEUI != "0000000000000000" || otaa.DevClass != "A" {
+		t.Errorf("unexpected OTAA request: %+v", otaa)
+	}
+
+	abp := devices[1].PutDeviceRequest(Target{ConfigID: 1, AppID: "BE7A0001"})
+	if abp.DevAddr != "26011BDB" || abp.NwkSKey != key2 || abp.AppSKey != key1 || abp.SeqNo != "7" || abp.SeqDN != "4" || abp.DevClass != "B" {
+		t.Errorf("unexpected ABP request: %+v", abp)
+	}
+}
+
+func TestParseUnknownSource(t *testing.T) {
+	if _, err := Parse("helium", []byte("[]")); err == nil {
+		t.Error("expected error for unknown source")
+	}
+}
+
+func TestMacVersion(t *testing.T) {
+	tests := map[string]string{
+		"MAC_V1_0_3":    "1.0.3",
+		"MAC_V1_1":      "1.1",
+		"LORAWAN_1_1_0": "1.1.0",
+		"":              "",
+	}
+	for enum, want := range tests {
+		if got := macVersion(enum); got != want {
+			t.Errorf("macVersion(%q) = %q, want %q", enum, got, want)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package migration
+
+import (
+	"fmt"
+)
+
+// ttnEndDevice is an end device as exported by The Things Stack, e.g. with
+// `ttn-lw-cli end-devices get <app> <device> --all`.
+type ttnEndDevice struct {
+	Ids struct {
+		DeviceID string `json:"device_id"`
+		DevEUI   string `json:"dev_eui"`
+		JoinEUI  string `json:"join_eui"`
+		AppEUI   string `json:"app_eui"`
+		DevAddr  string `json:"dev_addr"`
+	} `json:"ids"`
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	LorawanVersion string `json:"lorawan_version"`
+	SupportsJoin   bool   `json:"supports_join"`
+	SupportsClassB bool   `json:"supports_class_b"`
+	SupportsClassC bool   `json:"supports_class_c"`
+	RootKeys       struct {
+		AppKey ttnKey `json:"app_key"`
+		NwkKey ttnKey `json:"nwk_key"`
+	} `json:"root_keys"`
+	Session struct {
+		DevAddr string `json:"dev_addr"`
+		Keys    struct {
+			AppSKey     ttnKey `json:"app_s_key"`
+			FNwkSIntKey ttnKey `json:"f_nwk_s_int_key"`
+			SNwkSIntKey ttnKey `json:"s_nwk_s_int_key"`
+			NwkSEncKey  ttnKey `json:"nwk_s_enc_key"`
+		} `json:"keys"`
+		LastFCntUp    uint32 `json:"last_f_cnt_up"`
+		LastNFCntDown uint32 `json:"last_n_f_cnt_down"`
+		LastAFCntDown uint32 `json:"last_a_f_cnt_down"`
+	} `json:"session"`
+}
+
+// ttnKey is a key of The Things Stack, which is only exported in plain text if requested.
+type ttnKey struct {
+	Key          string `json:"key"`
+	EncryptedKey string `json:"encrypted_key"`
+	KekLabel     string `json:"kek_label"`
+}
+
+func parseTTN(data []byte) ([]Device, error) {
+	endDevices, err := decodeObjects[ttnEndDevice](data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing The Things Stack export: %v", err)
+	}
+	devices := make([]Device, 0, len(endDevices))
+	for _, endDevice := range endDevices {
+		device := Device{
+			DevEUI:      endDevice.Ids.DevEUI,
+			Name:        endDevice.Name,
+			Description: endDevice.Description,
+			MacVersion:  macVersion(endDevice.LorawanVersion),
+			Otaa:        endDevice.SupportsJoin,
+			Class:       deviceClass(endDevice.SupportsClassB, endDevice.SupportsClassC),
+			JoinEUI:     endDevice.Ids.JoinEUI,
+			DevAddr:     endDevice.Session.DevAddr,
+			FCntUp:      endDevice.Session.LastFCntUp,
+			NFCntDown:   endDevice.Session.LastNFCntDown,
+			AFCntDown:   endDevice.Session.LastAFCntDown,
+		}
+		if device.Name == "" {
+			device.Name = endDevice.Ids.DeviceID
+		}
+		if device.JoinEUI == "" {
+			device.JoinEUI = endDevice.Ids.AppEUI
+		}
+		if device.DevAddr == "" {
+			device.DevAddr = endDevice.Ids.DevAddr
+		}
+		device.AppKey = device.ttnKey("app_key", endDevice.RootKeys.AppKey)
+		device.NwkKey = device.ttnKey("nwk_key", endDevice.RootKeys.NwkKey)
+		device.AppSKey = device.ttnKey("app_s_key", endDevice.Session.Keys.AppSKey)
+		device.FNwkSIntKey = device.ttnKey("f_nwk_s_int_key", endDevice.Session.Keys.FNwkSIntKey)
+		device.SNwkSIntKey = device.ttnKey("s_nwk_s_int_key", endDevice.Session.Keys.SNwkSIntKey)
+		device.NwkSEncKey = device.ttnKey("nwk_s_enc_key", endDevice.Session.Keys.NwkSEncKey)
+		if device.Otaa && !device.IsLoRaWAN11() && device.AppKey == "" {
+			// LoRaWAN 1.0.x devices registered with a network key only
+			device.AppKey = device.NwkKey
+		}
+		devices = append(devices, device)
+	}
+	return devices, nil
+}
+
+// ttnKey returns the plain key and warns if the key was exported encrypted.
+func (d *Device) ttnKey(name string, key ttnKey) string {
+	if key.Key == "" && key.EncryptedKey != "" {
+		d.Warnings = append(d.Warnings, fmt.Sprintf("%s is encrypted with KEK %q and can't be migrated", name, key.KekLabel))
+	}
+	return key.Key
+}
@@ -39,5 +39,153 @@ create table if not exists loriot_io.asset
     latest_status_code  int
 );
 
+alter table loriot_io.configuration add column if not exists default_asset_types jsonb;
+alter table loriot_io.configuration add column if not exists gateway_offline_threshold integer not null default 600;
+alter table loriot_io.configuration add column if not exists reporting_intervals jsonb;
+alter table loriot_io.configuration add column if not exists device_offline_intervals integer not null default 3;
+alter table loriot_io.configuration add column if not exists reconcile_policy text not null default 'report';
+alter table loriot_io.configuration add column if not exists dev_eui_block text;
+alter table loriot_io.configuration add column if not exists version integer not null default 1;
+alter table loriot_io.configuration add column if not exists routing_rules jsonb;
+
+alter table loriot_io.asset add column if not exists asset_type text;
+alter table loriot_io.asset add column if not exists decoder text;
+alter table loriot_io.asset add column if not exists last_decoding_error text;
+alter table loriot_io.asset add column if not exists last_decoding_error_at timestamp;
+alter table loriot_io.asset add column if not exists reporting_interval integer;
+alter table loriot_io.asset add column if not exists last_uplink_at timestamp;
+alter table loriot_io.asset add column if not exists connection_state text;
+alter table loriot_io.asset add column if not exists connection_state_since timestamp;
+alter table loriot_io.asset add column if not exists alarm_rule_id integer;
+alter table loriot_io.asset add column if not exists asset_name text;
+alter table loriot_io.asset add column if not exists asset_description text;
+
+create table if not exists loriot_io.codec
+(
+	id               bigserial primary key,
+	asset_type       text      not null unique,
+	script           text      not null,
+	timeout          integer   not null default 1000,
+	memory_limit     integer   not null default 16,
+	user_id          text,
+	modified_at      timestamp
+);
+
+create table if not exists loriot_io.downlink
+(
+	id               bigserial primary key,
+	configuration_id bigint    not null references loriot_io.configuration(id) ON DELETE CASCADE,
+	asset_id         integer,
+	dev_eui          text      not null,
+	port             integer   not null,
+	confirmed        boolean   not null default false,
+	payload          text      not null,
+	status           text      not null,
+	error            text,
+	created_at       timestamp not null default now(),
+	modified_at      timestamp
+);
+
+alter table loriot_io.downlink add column if not exists user_id text;
+
+create table if not exists loriot_io.gateway
+(
+	asset_id         integer   primary key,
+	configuration_id bigint    not null references loriot_io.configuration(id) ON DELETE CASCADE,
+	project_id       text      not null,
+	global_asset_id  text      not null,
+	network_id       text      not null,
+	gateway_id       text      not null,
+	eui              text      not null,
+	modified_at      timestamp
+);
+
+alter table loriot_io.gateway add column if not exists disconnected_since timestamp;
+alter table loriot_io.gateway add column if not exists offline boolean not null default false;
+alter table loriot_io.gateway add column if not exists alarm_rule_id integer;
+
+create table if not exists loriot_io.outbox
+(
+	id               bigserial primary key,
+	configuration_id bigint    not null references loriot_io.configuration(id) ON DELETE CASCADE,
+	asset_id         integer   not null,
+	dev_eui          text      not null,
+	operation        text      not null,
+	asset            jsonb     not null,
+	status           text      not null,
+	attempts         integer   not null default 0,
+	next_attempt_at  timestamp not null default now(),
+	last_error       text,
+	created_at       timestamp not null default now(),
+	modified_at      timestamp
+);
+
+create table if not exists loriot_io.idempotency_key
+(
+	key          text      primary key,
+	request_hash text      not null,
+	state        text      not null default 'done',
+	response     jsonb,
+	created_at   timestamp not null default now()
+);
+
+create table if not exists loriot_io.job
+(
+	id          bigserial primary key,
+	kind        text      not null,
+	status      text      not null,
+	user_id     text,
+	created_at  timestamp not null default now(),
+	modified_at timestamp
+);
+
+create table if not exists loriot_io.job_row
+(
+	job_id      bigint    not null references loriot_io.job(id) ON DELETE CASCADE,
+	row_number  integer   not null,
+	dev_eui     text      not null,
+	request     jsonb,
+	status      text      not null,
+	error       text,
+	asset_ids   integer[],
+	modified_at timestamp,
+	primary key (job_id, row_number)
+);
+
+create table if not exists loriot_io.key_batch
+(
+	id         bigserial primary key,
+	name       text      not null,
+	user_id    text,
+	created_at timestamp not null default now()
+);
+
+create table if not exists loriot_io.device_key
+(
+	dev_eui        text      primary key,
+	key_batch_id   bigint    not null references loriot_io.key_batch(id) ON DELETE CASCADE,
+	join_eui       text,
+	app_key        text      not null,
+	nwk_key        text,
+	owner_token    text,
+	provisioned_at timestamp
+);
+
+create table if not exists loriot_io.key_vault
+(
+	dev_eui     text      primary key,
+	keys        bytea     not null,
+	created_at  timestamp not null default now(),
+	modified_at timestamp
+);
+
+create table if not exists loriot_io.key_vault_access
+(
+	id          bigserial primary key,
+	dev_eui     text      not null,
+	user_id     text,
+	accessed_at timestamp not null default now()
+);
+
 -- Makes the new objects available for all other init steps
 commit;
@@ -0,0 +1,202 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+// ErrNotConfigured is returned if neither SECRET_KEY nor SECRET_KEY_FILE defines the key used for encryption.
+var ErrNotConfigured = errors.New("no secret key configured, set SECRET_KEY or SECRET_KEY_FILE")
+
+// keySize is the size of the AES-256 key in bytes.
+const keySize = 32
+
+// EncryptedPrefix marks strings encrypted by EncryptString.
+const EncryptedPrefix = "enc:"
+
+// Configured checks if a key for encryption is defined.
+func Configured() bool {
+	return common.Getenv("SECRET_KEY", "") != "" || common.Getenv("SECRET_KEY_FILE", "") != ""
+}
+
+// Key returns the key used for encryption. The key is read base64 encoded from the environment variable SECRET_KEY
+// or from the file named by SECRET_KEY_FILE, e.g. a mounted Docker secret.
+func Key() ([]byte, error) {
+	key, err := readKey("SECRET_KEY")
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, ErrNotConfigured
+	}
+	return key, nil
+}
+
+// PreviousKey returns the key used before the last key rotation, read from SECRET_KEY_PREVIOUS or
+// SECRET_KEY_PREVIOUS_FILE. Returns nil if no previous key is defined.
+func PreviousKey() ([]byte, error) {
+	return readKey("SECRET_KEY_PREVIOUS")
+}
+
+// readKey reads the key from the environment variable or from the file named by the variable with suffix _FILE.
+// Returns nil if neither is defined.
+func readKey(name string) ([]byte, error) {
+	encoded := common.Getenv(name, "")
+	if encoded == "" {
+		path := common.Getenv(name+"_FILE", "")
+		if path == "" {
+			return nil, nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s file: %v", name, err)
+		}
+		encoded = string(data)
+	}
+	return ParseKey(encoded)
+}
+
+// ParseKey decodes a base64 encoded AES-256 key.
+func ParseKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("decoding secret key: %v", err)
+	}
+	if len(key) != keySize {
+		return nil, fmt.Errorf("secret key has %d bytes, expected %d", len(key), keySize)
+	}
+	return key, nil
+}
+
+// Encrypt encrypts the plaintext with the configured key.
+func Encrypt(plaintext []byte) ([]byte, error) {
+	key, err := Key()
+	if err != nil {
+		return nil, err
+	}
+	return EncryptWithKey(key, plaintext)
+}
+
+// Decrypt decrypts the ciphertext with the configured key. During a key rotation, ciphertexts not yet re-encrypted
+// are decrypted with the previous key.
+func Decrypt(ciphertext []byte) ([]byte, error) {
+	key, err := Key()
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := DecryptWithKey(key, ciphertext)
+	if err == nil {
+		return plaintext, nil
+	}
+	previousKey, previousErr := PreviousKey()
+	if previousErr != nil || previousKey == nil {
+		return nil, err
+	}
+	return DecryptWithKey(previousKey, ciphertext)
+}
+
+// EncryptString encrypts the plaintext with the configured key and returns it base64 encoded with a prefix marking
+// it as encrypted.
+func EncryptString(plaintext string) (string, error) {
+	ciphertext, err := Encrypt([]byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+	return EncryptedPrefix + base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// DecryptString decrypts a value created by EncryptString. Values without prefix were stored before encryption was
+// introduced and are returned unchanged.
+func DecryptString(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, EncryptedPrefix))
+	if err != nil {
+		return "", fmt.Errorf("decoding encrypted value: %v", err)
+	}
+	plaintext, err := Decrypt(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// IsEncrypted checks if the value was created by EncryptString.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, EncryptedPrefix)
+}
+
+// EncryptWithKey encrypts the plaintext with AES-256-GCM. The random nonce is prepended to the ciphertext.
+func EncryptWithKey(key []byte, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generating nonce: %v", err)
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// DecryptWithKey decrypts a ciphertext created by EncryptWithKey.
+func DecryptWithKey(key []byte, ciphertext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting: %v", err)
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("creating cipher: %v", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("creating GCM: %v", err)
+	}
+	return gcm, nil
+}
+
+// RandomHex returns size cryptographically random bytes as uppercase hexadecimal string, e.g. for LoRaWAN keys.
+func RandomHex(size int) (string, error) {
+	data := make([]byte, size)
+	if _, err := rand.Read(data); err != nil {
+		return "", fmt.Errorf("generating random bytes: %v", err)
+	}
+	return strings.ToUpper(fmt.Sprintf("%x", data)), nil
+}
@@ -0,0 +1,103 @@
+package secret
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var testKey = base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, keySize))
+
+func TestEncryptDecrypt(t *testing.T) {
+	t.Setenv("SECRET_KEY", testKey)
+	ciphertext, err := Encrypt([]byte("00112233445566778899AABBCCDDEEFF"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(ciphertext, []byte("00112233445566778899AABBCCDDEEFF")) {
+		t.Error("ciphertext contains plaintext")
+	}
+	plaintext, err := Decrypt(ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(plaintext) != "00112233445566778899AABBCCDDEEFF" {
+		t.Errorf("got %q", plaintext)
+	}
+}
+
+func TestDecryptWithOtherKey(t *testing.T) {
+	ciphertext, err := EncryptWithKey(bytes.Repeat([]byte{1}, keySize), []byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DecryptWithKey(bytes.Repeat([]byte{2}, keySize), ciphertext); err == nil {
+		t.Error("expected error decrypting with other key")
+	}
+}
+
+func TestKeyFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key")
+	if err := os.WriteFile(path, []byte(testKey+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SECRET_KEY", "")
+	t.Setenv("SECRET_KEY_FILE", path)
+	if !Configured() {
+		t.Fatal("expected key to be configured")
+	}
+	if _, err := Key(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestKeyNotConfigured(t *testing.T) {
+	t.Setenv("SECRET_KEY", "")
+	t.Setenv("SECRET_KEY_FILE", "")
+	if _, err := Encrypt([]byte("secret")); err != ErrNotConfigured {
+		t.Errorf("got %v, want ErrNotConfigured", err)
+	}
+}
+
+func TestParseKeyInvalidSize(t *testing.T) {
+	if _, err := ParseKey(base64.StdEncoding.EncodeToString([]byte("short"))); err == nil {
+		t.Error("expected error for short key")
+	}
+}
+
+func TestEncryptDecryptString(t *testing.T) {
+	t.Setenv("SECRET_KEY", testKey)
+	encrypted, err := EncryptString("token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsEncrypted(encrypted) {
+		t.Errorf("%q not marked as encrypted", encrypted)
+	}
+	decrypted, err := DecryptString(encrypted)
+	if err != nil || decrypted != "token" {
+		t.Errorf("got %q, %v", decrypted, err)
+	}
+	if plaintext, err := DecryptString("legacy-token"); err != nil || plaintext != "legacy-token" {
+		t.Errorf("got %q, %v for unencrypted value", plaintext, err)
+	}
+}
+
+func TestDecryptWithPreviousKey(t *testing.T) {
+	previousKey := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{9}, keySize))
+	t.Setenv("SECRET_KEY", previousKey)
+	encrypted, err := EncryptString("token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SECRET_KEY", testKey)
+	if _, err := DecryptString(encrypted); err == nil {
+		t.Fatal("expected error without previous key")
+	}
+	t.Setenv("SECRET_KEY_PREVIOUS", previousKey)
+	if decrypted, err := DecryptString(encrypted); err != nil || decrypted != "token" {
+		t.Errorf("got %q, %v", decrypted, err)
+	}
+}